@@ -21,8 +21,22 @@ import (
 
 var build string
 
-// Start initialize a restQL runtime as a server
+// commands are the subcommands available in the restQL binary,
+// receiving the arguments after its name and returning the exit code.
+var commands = map[string]func(args []string) int{
+	"run":  runCommand,
+	"test": testCommand,
+}
+
+// Start initialize a restQL runtime as a server,
+// or executes the subcommand given as first argument.
 func Start() {
+	if len(os.Args) > 1 {
+		if command, found := commands[os.Args[1]]; found {
+			os.Exit(command(os.Args[2:]))
+		}
+	}
+
 	if err := startServer(); err != nil {
 		fmt.Printf("[ERROR] failed to start restQL : %v", err)
 		os.Exit(1)
@@ -58,10 +72,21 @@ func startServer() error {
 	signal.Notify(shutdownSignal, os.Interrupt, syscall.SIGTERM)
 
 	serverCfg := cfg.HTTP.Server
-	apiHandler, err := web.API(log, cfg)
+	apiHandler, reload, apiCloser, err := web.API(log, cfg)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err := apiCloser.Close(); err != nil {
+			log.Error("failed to release api resources", err)
+		}
+	}()
+
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	if cfg.Reload.Enable {
+		go conf.NewWatcher(log, cfg).Run(watchCtx, reload)
+	}
 
 	api := &fasthttp.Server{
 		Name:                          "restql",
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/logger"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+	"github.com/pkg/errors"
+)
+
+const runUsage = `Usage: restql run [flags] [file]
+
+Executes the query in the file, or read from the standard input
+when the file is absent or "-", and prints the response body.
+
+The exit code is 0 when the response status code is lower than 400,
+4 for client errors (4xx), 5 for server errors (5xx) and 1 when
+the query cannot be executed.
+
+Flags:
+`
+
+// keyValueFlag collects the repeated name=value flags.
+type keyValueFlag []string
+
+func (kv *keyValueFlag) String() string {
+	return strings.Join(*kv, ", ")
+}
+
+func (kv *keyValueFlag) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return errors.Errorf("invalid value %q, expected name=value", value)
+	}
+
+	*kv = append(*kv, value)
+	return nil
+}
+
+type runOptions struct {
+	params         keyValueFlag
+	headers        keyValueFlag
+	tenant         string
+	debug          bool
+	timing         bool
+	includeHeaders bool
+	verbose        bool
+}
+
+// runCommand executes a query in-process, without starting
+// the server, returning an exit code derived from its status code.
+func runCommand(args []string) int {
+	var o runOptions
+
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.Var(&o.params, "param", "query parameter as name=value, can be repeated")
+	fs.Var(&o.headers, "header", "request header as name=value, can be repeated")
+	fs.StringVar(&o.tenant, "tenant", "", "tenant used to fetch the mappings, defaults to RESTQL_TENANT")
+	fs.BoolVar(&o.debug, "debug", false, "include the debugging information in the response")
+	fs.BoolVar(&o.timing, "timing", false, "print the statements timing table to the standard error")
+	fs.BoolVar(&o.includeHeaders, "i", false, "include the status code and headers in the output")
+	fs.BoolVar(&o.verbose, "v", false, "print the restQL logs")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), runUsage)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	queryTxt, err := readQuery(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] failed to read query : %v\n", err)
+		return 1
+	}
+
+	statusCode, err := runQuery(os.Stdout, os.Stderr, queryTxt, o)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] failed to run query : %v\n", err)
+		return 1
+	}
+
+	return statusCodeToExitCode(statusCode)
+}
+
+func readQuery(path string) (string, error) {
+	if path == "" || path == "-" {
+		data, err := ioutil.ReadAll(os.Stdin)
+		return string(data), err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	return string(data), err
+}
+
+func runQuery(out io.Writer, errOut io.Writer, queryTxt string, o runOptions) (int, error) {
+	cfg, err := conf.LoadCLI(build)
+	if err != nil {
+		return 0, err
+	}
+
+	log := logger.New(errOut, logger.LogOptions{
+		Enable:               o.verbose,
+		TimestampFieldName:   cfg.Logging.TimestampFieldName,
+		TimestampFieldFormat: cfg.Logging.TimestampFieldFormat,
+		Level:                cfg.Logging.Level,
+		Format:               cfg.Logging.Format,
+	})
+
+	tenant := cfg.Tenant
+	if o.tenant != "" {
+		tenant = o.tenant
+	}
+
+	e, err := web.NewEngine(log, cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer e.Close()
+
+	input := engine.Input{Tenant: tenant, Params: makeParams(o.params), Headers: makeHeaders(o.headers)}
+	ctx := restql.WithLogger(context.Background(), log)
+
+	start := time.Now()
+	result, err := e.Run(ctx, queryTxt, input)
+	if err != nil {
+		return 0, err
+	}
+	elapsed := time.Since(start)
+
+	response, err := web.MakeResultResponse(result, restql.QueryInput{Params: input.Params, Headers: input.Headers}, o.debug)
+	if err != nil {
+		return 0, err
+	}
+
+	if o.includeHeaders {
+		printStatusAndHeaders(out, response)
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(response.Body); err != nil {
+		return 0, err
+	}
+
+	if o.timing {
+		printTiming(errOut, result, elapsed)
+	}
+
+	return response.StatusCode, nil
+}
+
+// makeParams builds the query parameters like the API does
+// from the URL query, where repeated names become a list.
+func makeParams(values []string) map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, v := range values {
+		name, value := splitKeyValue(v)
+
+		switch current := params[name].(type) {
+		case nil:
+			params[name] = value
+		case []interface{}:
+			params[name] = append(current, value)
+		default:
+			params[name] = []interface{}{current, value}
+		}
+	}
+
+	return params
+}
+
+func makeHeaders(values []string) map[string]string {
+	headers := make(map[string]string)
+	for _, v := range values {
+		name, value := splitKeyValue(v)
+		headers[name] = value
+	}
+
+	return headers
+}
+
+func splitKeyValue(s string) (string, string) {
+	i := strings.Index(s, "=")
+	return strings.TrimSpace(s[:i]), s[i+1:]
+}
+
+func printStatusAndHeaders(out io.Writer, response web.QueryResponse) {
+	fmt.Fprintf(out, "Status: %d\n", response.StatusCode)
+
+	names := make([]string, 0, len(response.Headers))
+	for name := range response.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(out, "%s: %s\n", name, response.Headers[name])
+	}
+
+	fmt.Fprintln(out)
+}
+
+// printTiming writes the status code and response
+// time of every upstream call made by the query.
+func printTiming(out io.Writer, result engine.Result, elapsed time.Duration) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATEMENT\tSTATUS\tTIME\tURL")
+
+	for _, s := range result.Statements {
+		if s.IsMultiplexed() {
+			printMultiplexedTiming(w, s.Name, s.Multiplexed)
+		} else {
+			printTimingRow(w, s.Name, s.Done)
+		}
+	}
+
+	fmt.Fprintf(w, "total\t\t%s\t\n", elapsed.Round(time.Millisecond))
+	w.Flush()
+}
+
+func printMultiplexedTiming(w io.Writer, name string, resources restql.DoneResources) {
+	for i, r := range resources {
+		rowName := fmt.Sprintf("%s[%d]", name, i)
+
+		switch r := r.(type) {
+		case restql.DoneResource:
+			printTimingRow(w, rowName, r)
+		case restql.DoneResources:
+			printMultiplexedTiming(w, rowName, r)
+		}
+	}
+}
+
+func printTimingRow(w io.Writer, name string, dr restql.DoneResource) {
+	fmt.Fprintf(w, "%s\t%d\t%dms\t%s\n", name, dr.Status, dr.ResponseTime, dr.URL)
+}
+
+func statusCodeToExitCode(statusCode int) int {
+	switch {
+	case statusCode >= 500:
+		return 5
+	case statusCode >= 400:
+		return 4
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/logger"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/querytest"
+)
+
+const testUsage = `Usage: restql test [flags] [paths...]
+
+Runs the query test cases found in the given files or directories,
+comparing each response with the snapshot stored beside the case file.
+Directories are searched recursively for *.test.yml and *.test.yaml files.
+
+Flags:
+`
+
+// testCommand runs the query test cases against their snapshots,
+// returning a non zero exit code if any of them fails.
+func testCommand(args []string) int {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	update := fs.Bool("update", false, "write the actual responses as the new snapshots")
+	run := fs.String("run", "", "only run the cases with name matching the regular expression")
+	verbose := fs.Bool("v", false, "print the restQL logs")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), testUsage)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var filter *regexp.Regexp
+	if *run != "" {
+		var err error
+		filter, err = regexp.Compile(*run)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[ERROR] invalid -run expression : %v\n", err)
+			return 2
+		}
+	}
+
+	failed, err := runTests(os.Stdout, paths, filter, *update, *verbose)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] failed to run tests : %v\n", err)
+		return 1
+	}
+
+	if failed {
+		return 1
+	}
+
+	return 0
+}
+
+func runTests(out io.Writer, paths []string, filter *regexp.Regexp, update bool, verbose bool) (bool, error) {
+	cfg, err := conf.LoadCLI(build)
+	if err != nil {
+		return false, err
+	}
+
+	log := logger.New(os.Stderr, logger.LogOptions{
+		Enable:               verbose,
+		TimestampFieldName:   cfg.Logging.TimestampFieldName,
+		TimestampFieldFormat: cfg.Logging.TimestampFieldFormat,
+		Level:                cfg.Logging.Level,
+		Format:               cfg.Logging.Format,
+	})
+
+	files, err := querytest.FindCases(paths)
+	if err != nil {
+		return false, err
+	}
+
+	r, err := querytest.NewRunner(log, cfg)
+	if err != nil {
+		return false, err
+	}
+
+	var passed, failed, updated, skipped int
+	for _, file := range files {
+		c, err := querytest.LoadCase(file)
+		if err != nil {
+			fmt.Fprintf(out, "FAIL   %s\n       %v\n", file, err)
+			failed++
+			continue
+		}
+
+		if filter != nil && !filter.MatchString(c.Name) {
+			skipped++
+			continue
+		}
+
+		name := fmt.Sprintf("%s (%s)", c.Path, c.Name)
+
+		actual, err := r.Run(context.Background(), c)
+		if err != nil {
+			fmt.Fprintf(out, "FAIL   %s\n       %v\n", name, err)
+			failed++
+			continue
+		}
+
+		if update {
+			if err := querytest.WriteSnapshot(c.SnapshotPath(), actual); err != nil {
+				fmt.Fprintf(out, "FAIL   %s\n       %v\n", name, err)
+				failed++
+				continue
+			}
+
+			fmt.Fprintf(out, "UPDATE %s\n", name)
+			updated++
+			continue
+		}
+
+		diff, err := querytest.CompareSnapshot(c.SnapshotPath(), actual)
+		switch {
+		case errors.Is(err, querytest.ErrSnapshotNotFound):
+			fmt.Fprintf(out, "FAIL   %s\n       %v, run with -update to create it\n", name, err)
+			failed++
+		case err != nil:
+			fmt.Fprintf(out, "FAIL   %s\n       %v\n", name, err)
+			failed++
+		case diff != "":
+			fmt.Fprintf(out, "FAIL   %s\n%s", name, diff)
+			failed++
+		default:
+			fmt.Fprintf(out, "PASS   %s\n", name)
+			passed++
+		}
+	}
+
+	fmt.Fprintf(out, "\n%d passed, %d failed, %d updated, %d skipped\n", passed, failed, updated, skipped)
+
+	return failed > 0, nil
+}
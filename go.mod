@@ -1,24 +1,47 @@
 module github.com/b2wdigital/restQL-golang/v6
 
-go 1.16
+go 1.19
 
 require (
 	github.com/bluele/gcache v0.0.0-20190518031135-bc40bd653833
 	github.com/caarlos0/env/v6 v6.3.0
-	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/fasthttp/router v1.3.2
-	github.com/google/go-cmp v0.5.2
+	github.com/fxamacker/cbor/v2 v2.5.0
+	github.com/google/go-cmp v0.5.5
 	github.com/google/uuid v1.1.2
 	github.com/imdario/mergo v0.3.11
-	github.com/klauspost/compress v1.11.9 // indirect
+	github.com/ncruces/go-sqlite3 v0.7.2
 	github.com/pkg/errors v0.9.1
 	github.com/rs/dnscache v0.0.0-20190621150935-06bb5526f76b
 	github.com/rs/zerolog v1.20.0
 	github.com/valyala/fasthttp v1.22.0
+	github.com/vmihailenco/msgpack/v5 v5.3.5
 	go.uber.org/automaxprocs v1.3.0
+	golang.org/x/sync v0.2.0
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v2 v2.3.0
+)
+
+require (
+	github.com/BurntSushi/toml v0.3.1 // indirect
+	github.com/andybalholm/brotli v1.0.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.0 // indirect
+	github.com/klauspost/compress v1.11.9 // indirect
+	github.com/ncruces/julianday v0.1.5 // indirect
+	github.com/savsgio/gotils v0.0.0-20200616100644-13ff1fd2c28c // indirect
+	github.com/tetratelabs/wazero v1.2.0 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
 	golang.org/x/lint v0.0.0-20200302205851-738671d3881b // indirect
-	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208
+	golang.org/x/mod v0.3.0 // indirect
+	golang.org/x/net v0.0.0-20210226101413-39120d07d75e // indirect
+	golang.org/x/sys v0.8.0 // indirect
+	golang.org/x/text v0.3.5 // indirect
 	golang.org/x/tools v0.0.0-20200923182640-463111b69878 // indirect
-	gopkg.in/yaml.v2 v2.3.0
+	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013 // indirect
 	honnef.co/go/tools v0.0.1-2020.1.5 // indirect
 )
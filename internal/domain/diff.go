@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// QueryDiff is the semantic difference between two queries,
+// which is empty when they only differ on formatting, like
+// whitespace, comments or the order of qualifiers.
+type QueryDiff struct {
+	Modifiers []Change        `json:"modifiers,omitempty"`
+	Added     []ResourceID    `json:"added,omitempty"`
+	Removed   []ResourceID    `json:"removed,omitempty"`
+	Changed   []StatementDiff `json:"changed,omitempty"`
+}
+
+// StatementDiff holds the changes of a statement present
+// on both queries, identified by its alias or resource.
+type StatementDiff struct {
+	Resource ResourceID `json:"resource"`
+	Changes  []Change   `json:"changes"`
+}
+
+// Change is a field with different values on each query,
+// like `with.id` or `timeout`. Values are described as
+// restQL text and are empty when the field is absent.
+type Change struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// Empty tells if the queries have the same behaviour.
+func (d QueryDiff) Empty() bool {
+	return len(d.Modifiers) == 0 && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffQueries compares the queries modifiers and statements,
+// matching the statements by their ResourceID.
+func DiffQueries(from Query, to Query) QueryDiff {
+	var diff QueryDiff
+	diff.Modifiers = diffMaps("use", from.Use, to.Use)
+
+	fromStatements := make(map[ResourceID]Statement, len(from.Statements))
+	for _, s := range from.Statements {
+		fromStatements[NewResourceID(s)] = s
+	}
+
+	toStatements := make(map[ResourceID]Statement, len(to.Statements))
+	for _, s := range to.Statements {
+		id := NewResourceID(s)
+		toStatements[id] = s
+
+		previous, found := fromStatements[id]
+		if !found {
+			diff.Added = append(diff.Added, id)
+			continue
+		}
+
+		changes := diffStatements(previous, s)
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, StatementDiff{Resource: id, Changes: changes})
+		}
+	}
+
+	for _, s := range from.Statements {
+		id := NewResourceID(s)
+		if _, found := toStatements[id]; !found {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+
+	return diff
+}
+
+func diffStatements(from Statement, to Statement) []Change {
+	var changes []Change
+	add := func(field string, fromValue, toValue interface{}) {
+		f, t := describe(fromValue), describe(toValue)
+		if f != t {
+			changes = append(changes, Change{Field: field, From: f, To: t})
+		}
+	}
+
+	add("method", from.Method, to.Method)
+	add("resource", from.Resource, to.Resource)
+	add("in", from.In, to.In)
+	add("depends-on", from.DependsOn.Target, to.DependsOn.Target)
+	add("with.body", from.With.Body, to.With.Body)
+	changes = append(changes, diffMaps("with", from.With.Values, to.With.Values)...)
+	changes = append(changes, diffMaps("headers", from.Headers, to.Headers)...)
+	add("only", from.Only, to.Only)
+	add("timeout", from.Timeout, to.Timeout)
+	add("max-age", from.CacheControl.MaxAge, to.CacheControl.MaxAge)
+	add("s-max-age", from.CacheControl.SMaxAge, to.CacheControl.SMaxAge)
+	add("hidden", from.Hidden, to.Hidden)
+	add("ignore-errors", from.IgnoreErrors, to.IgnoreErrors)
+	add("criticality", string(from.Criticality), string(to.Criticality))
+
+	return changes
+}
+
+func diffMaps(prefix string, from map[string]interface{}, to map[string]interface{}) []Change {
+	keys := make(map[string]struct{}, len(from)+len(to))
+	for k := range from {
+		keys[k] = struct{}{}
+	}
+	for k := range to {
+		keys[k] = struct{}{}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	var changes []Change
+	for _, k := range sortedKeys {
+		f, t := describe(from[k]), describe(to[k])
+		if f != t {
+			changes = append(changes, Change{Field: prefix + "." + k, From: f, To: t})
+		}
+	}
+
+	return changes
+}
+
+// describe writes the value as restQL text, so that
+// values holding functions provided by plugins,
+// which cannot be compared, are compared by their text.
+func describe(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if !v {
+			return ""
+		}
+		return "true"
+	case string:
+		if v == "" {
+			return ""
+		}
+		return strconv.Quote(v)
+	case Variable:
+		return "$" + v.Target
+	case Chain:
+		parts := make([]string, len(v))
+		for i, p := range v {
+			if s, ok := p.(string); ok {
+				parts[i] = s
+			} else {
+				parts[i] = describe(p)
+			}
+		}
+		return strings.Join(parts, ".")
+	case []string:
+		return strings.Join(v, ".")
+	case []interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = describe(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			items[i] = strconv.Quote(k) + ": " + describe(v[k])
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case Function:
+		return describeFunction(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func describeFunction(fn Function) string {
+	var name string
+	switch f := fn.(type) {
+	case CustomFunction:
+		name = f.Fn.Name
+	case NoMultiplex:
+		name = "no-multiplex"
+	case JSON:
+		name = "json"
+	case Base64:
+		name = "base64"
+	case Match, FilterByRegex:
+		name = "matches"
+	case AsBody:
+		name = "as-body"
+	case Flatten:
+		name = "flatten"
+	case NoExplode:
+		name = "no-explode"
+	case AsQuery:
+		name = "as-query"
+	default:
+		name = fmt.Sprintf("%T", fn)
+	}
+
+	args := fn.Arguments()
+	if len(args) == 0 {
+		return describe(fn.Target()) + " -> " + name
+	}
+
+	values := make([]string, len(args))
+	for i, a := range args {
+		values[i] = describe(a.Value)
+	}
+
+	return describe(fn.Target()) + " -> " + name + "(" + strings.Join(values, ", ") + ")"
+}
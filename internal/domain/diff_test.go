@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestDiffQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected domain.QueryDiff
+	}{
+		{
+			"should be empty on formatting changes",
+			`from hero with id = 1, name = "batman" only name, age`,
+			`
+			// find the hero
+			from hero
+				with
+					name = "batman"
+					id = 1
+				only
+					name
+					age
+			`,
+			domain.QueryDiff{},
+		},
+		{
+			"should find added and removed statements",
+			"from hero\nfrom villain",
+			"from hero\nfrom sidekick\nfrom villain as enemy",
+			domain.QueryDiff{
+				Added:   []domain.ResourceID{"sidekick", "enemy"},
+				Removed: []domain.ResourceID{"villain"},
+			},
+		},
+		{
+			"should find changed with params",
+			`from hero with id = $id, name = "batman", weapons = ["bat-rang"] -> flatten`,
+			`from hero with id = $heroId, weapons = ["bat-rang", "belt"] -> flatten, city = "gotham"`,
+			domain.QueryDiff{
+				Changed: []domain.StatementDiff{{
+					Resource: "hero",
+					Changes: []domain.Change{
+						{Field: "with.city", To: `"gotham"`},
+						{Field: "with.id", From: "$id", To: "$heroId"},
+						{Field: "with.name", From: `"batman"`},
+						{Field: "with.weapons", From: `["bat-rang"] -> flatten`, To: `["bat-rang", "belt"] -> flatten`},
+					},
+				}},
+			},
+		},
+		{
+			"should find changed qualifiers",
+			`from hero headers X-Tid = "1" timeout 100 only name`,
+			`from hero headers X-Tid = "1" timeout 200 hidden ignore-errors`,
+			domain.QueryDiff{
+				Changed: []domain.StatementDiff{{
+					Resource: "hero",
+					Changes: []domain.Change{
+						{Field: "only", From: "[name]"},
+						{Field: "timeout", From: "100", To: "200"},
+						{Field: "hidden", To: "true"},
+						{Field: "ignore-errors", To: "true"},
+					},
+				}},
+			},
+		},
+		{
+			"should find changed modifiers",
+			"use max-age = 600\nfrom hero",
+			"use max-age = 300\nfrom hero",
+			domain.QueryDiff{
+				Modifiers: []domain.Change{{Field: "use.max-age", From: "600", To: "300"}},
+			},
+		},
+	}
+
+	p, err := parser.New()
+	test.VerifyError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, err := p.Parse(tt.from)
+			test.VerifyError(t, err)
+
+			to, err := p.Parse(tt.to)
+			test.VerifyError(t, err)
+
+			got := domain.DiffQueries(from, to)
+
+			test.Equal(t, got, tt.expected)
+			test.Equal(t, got.Empty(), len(tt.expected.Modifiers)+len(tt.expected.Added)+len(tt.expected.Removed)+len(tt.expected.Changed) == 0)
+		})
+	}
+}
@@ -1,5 +1,16 @@
 package domain
 
+import (
+	"runtime/debug"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// ErrFunctionPanicked represents the event of a plugin
+// function that panicked while applied on a value.
+var ErrFunctionPanicked = errors.New("plugin function produced a panic")
+
 // Function is the interface implemented by types that
 // provide encoding, filters and special behaviour through
 // the apply operator.
@@ -353,3 +364,70 @@ func (f AsQuery) Arguments() []Arg {
 func (f AsQuery) Map(fn func(target interface{}) interface{}) Function {
 	return AsQuery{Value: fn(f.Value)}
 }
+
+// CustomFunction is a Function provided by plugins,
+// bound to its implementation when the query is parsed.
+type CustomFunction struct {
+	Value interface{}
+	Fn    restql.Function
+	Args  []Arg
+}
+
+// Argument fetches a CustomFunction argument by name
+func (cf CustomFunction) Argument(name string) Arg {
+	for _, arg := range cf.Args {
+		if arg.Name == name {
+			return arg
+		}
+	}
+
+	return Arg{}
+}
+
+// SetArgument immutably updates the value of an argument by name
+func (cf CustomFunction) SetArgument(name string, value interface{}) Function {
+	args := make([]Arg, len(cf.Args))
+	for i, arg := range cf.Args {
+		if arg.Name == name {
+			arg.Value = value
+		}
+		args[i] = arg
+	}
+
+	return CustomFunction{Value: cf.Value, Fn: cf.Fn, Args: args}
+}
+
+// Target return the value upon which CustomFunction will be applied.
+func (cf CustomFunction) Target() interface{} {
+	return cf.Value
+}
+
+// Arguments return the arguments provided to CustomFunction
+func (cf CustomFunction) Arguments() []Arg {
+	return cf.Args
+}
+
+// Map apply the given function to the Target value
+// preserving the CustomFunction as a wrapper.
+func (cf CustomFunction) Map(fn func(target interface{}) interface{}) Function {
+	return CustomFunction{Value: fn(cf.Value), Fn: cf.Fn, Args: cf.Args}
+}
+
+// Call applies the plugin function on the value
+// with the arguments provided in the query.
+// A panic in the plugin function is recovered and
+// returned as an ErrFunctionPanicked.
+func (cf CustomFunction) Call(value interface{}) (result interface{}, err error) {
+	defer func() {
+		if reason := recover(); reason != nil {
+			err = errors.Wrapf(ErrFunctionPanicked, "function %s\n\t reason : %v\n\t stack : %v", cf.Fn.Name, reason, string(debug.Stack()))
+		}
+	}()
+
+	args := make([]interface{}, len(cf.Args))
+	for i, arg := range cf.Args {
+		args[i] = arg.Value
+	}
+
+	return cf.Fn.Call(value, args...)
+}
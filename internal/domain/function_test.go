@@ -0,0 +1,28 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestCustomFunctionCall(t *testing.T) {
+	upper := restql.Function{Name: "upper", Apply: func(value interface{}, args []interface{}) (interface{}, error) {
+		return "BATMAN", nil
+	}}
+	broken := restql.Function{Name: "broken", Apply: func(value interface{}, args []interface{}) (interface{}, error) {
+		panic("unexpected value")
+	}}
+
+	got, err := domain.CustomFunction{Fn: upper}.Call("batman")
+	test.VerifyError(t, err)
+	test.Equal(t, got, "BATMAN")
+
+	_, err = domain.CustomFunction{Fn: broken}.Call("batman")
+	if !errors.Is(err, domain.ErrFunctionPanicked) {
+		t.Errorf("Call() error = %v, want %v", err, domain.ErrFunctionPanicked)
+	}
+}
@@ -1,15 +1,13 @@
 package domain
 
 import (
-	"context"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
-	"github.com/pkg/errors"
 )
 
 // ErrRequestTimeout is the error returned by HTTPClient
 // when a HTTP call fails due to the request exceeding
 // the timeout defined in HTTPRequest.
-var ErrRequestTimeout = errors.New("request timed out")
+var ErrRequestTimeout = restql.ErrRequestTimeout
 
 // EnvSource expose access to environment variables.
 type EnvSource interface {
@@ -17,10 +15,7 @@ type EnvSource interface {
 	GetAll() map[string]string
 }
 
-// HTTPClient is the interface that wrap the method Do
-//
-// Do takes an HTTPRequest and execute it respecting
-// the cancellation signal from the given Context.
-type HTTPClient interface {
-	Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error)
-}
+// HTTPClient is the client used to execute the statements
+// upstream calls, shared with the public restql package
+// so that custom clients can be provided to the engine.
+type HTTPClient = restql.HTTPClient
@@ -1,5 +1,7 @@
 package domain
 
+import "github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+
 // Methods available to be used in query statements.
 const (
 	FromMethod   string = "from"
@@ -32,6 +34,7 @@ type Statement struct {
 	Hidden       bool
 	CacheControl CacheControl
 	IgnoreErrors bool
+	Criticality  restql.Criticality
 }
 
 // Params is the internal representation of the `with` clause.
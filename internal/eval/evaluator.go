@@ -20,6 +20,15 @@ var (
 	errInvalidTenant    = errors.New("tenant must be not empty")
 )
 
+// Result is the outcome of a query evaluation, holding
+// the statements results, the query modifiers and the
+// statements identifiers in order of declaration.
+type Result struct {
+	Resources  domain.Resources
+	Modifiers  domain.Modifiers
+	Statements []domain.ResourceID
+}
+
 // Evaluator is the interpreter of the restQL language.
 // It can execute saved or ad-hoc queries.
 type Evaluator struct {
@@ -45,53 +54,75 @@ func NewEvaluator(log restql.Logger, mr MappingsReader, qr QueryReader, r runner
 
 // AdHocQuery executes an ad-hoc send by the client with
 // the options and HTTP information.
-func (e Evaluator) AdHocQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
+func (e Evaluator) AdHocQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (Result, error) {
+	if queryOpts.Tenant == "" {
+		return Result{}, fmt.Errorf("%w: %s", ErrValidation, errInvalidTenant)
+	}
+
+	return e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput, nil)
+}
+
+// StreamAdHocQuery executes an ad-hoc query like AdHocQuery,
+// but also calls the emit function with the statement results,
+// already filtered and aggregated, as soon as they are done.
+func (e Evaluator) StreamAdHocQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput, emit runner.EmitFn) (Result, error) {
 	if queryOpts.Tenant == "" {
-		return nil, fmt.Errorf("%w: %s", ErrValidation, errInvalidTenant)
+		return Result{}, fmt.Errorf("%w: %s", ErrValidation, errInvalidTenant)
 	}
 
-	return e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput)
+	return e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput, emit)
 }
 
 // SavedQuery executes a saved query identified by namespace,
 // id and revision with the options and HTTP information
 // send by the client.
-func (e Evaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
+func (e Evaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (Result, error) {
+	return e.savedQuery(ctx, queryOpts, queryInput, nil)
+}
+
+// StreamSavedQuery executes a saved query like SavedQuery,
+// but also calls the emit function with the statement results,
+// already filtered and aggregated, as soon as they are done.
+func (e Evaluator) StreamSavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput, emit runner.EmitFn) (Result, error) {
+	return e.savedQuery(ctx, queryOpts, queryInput, emit)
+}
+
+func (e Evaluator) savedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput, emit runner.EmitFn) (Result, error) {
 	err := validateQueryOptions(queryOpts)
 	if err != nil {
-		return nil, err
+		return Result{}, err
 	}
 
 	savedQuery, err := e.queryReader.Get(ctx, queryOpts.Namespace, queryOpts.Id, queryOpts.Revision)
 	if err != nil {
-		return nil, err
+		return Result{}, err
 	}
 
 	log := restql.GetLogger(ctx)
 	log.Debug("Saved query retrieved", "query", savedQuery)
 
-	return e.evaluateQuery(ctx, savedQuery.Text, queryOpts, queryInput)
+	return e.evaluateQuery(ctx, savedQuery.Text, queryOpts, queryInput, emit)
 }
 
-func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
+func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput, emit runner.EmitFn) (Result, error) {
 	log := restql.GetLogger(ctx)
 
 	query, err := e.parser.Parse(queryTxt)
 	if err != nil {
 		log.Debug("failed to parse query", "error", err)
-		return nil, fmt.Errorf("%w: invalid query syntax %s", ErrParser, err)
+		return e.queryFailed(ctx, queryTxt, parser.WrapSyntaxError(fmt.Errorf("%w: invalid query syntax %s", ErrParser, err), err))
 	}
 
 	mappings, err := e.mappingsReader.FromTenant(ctx, queryOpts.Tenant)
 	if err != nil {
 		log.Error("failed to fetch mappings", err)
-		return nil, err
+		return e.queryFailed(ctx, queryTxt, err)
 	}
 
 	err = validateQueryResources(query, mappings)
 	if err != nil {
 		log.Error("query reference invalid resource", err, "mappings", fmt.Sprintf("%#v", mappings))
-		return nil, err
+		return e.queryFailed(ctx, queryTxt, err)
 	}
 
 	queryContext := restql.QueryContext{
@@ -100,26 +131,31 @@ func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts
 		Input:    queryInput,
 	}
 
-	queryCtx := e.lifecycle.BeforeQuery(ctx, queryTxt, queryContext)
+	queryCtx, queryContext := e.lifecycle.BeforeQuery(ctx, queryTxt, queryContext)
 
 	query = ResolveVariables(query, queryContext.Input)
 
-	resources, err := e.runner.ExecuteQuery(queryCtx, query, queryContext)
+	var resources domain.Resources
+	if emit != nil {
+		resources, err = e.runner.StreamQuery(queryCtx, query, queryContext, streamResults(log, query, emit))
+	} else {
+		resources, err = e.runner.ExecuteQuery(queryCtx, query, queryContext)
+	}
 	switch {
 	case err == runner.ErrQueryTimedOut:
-		return nil, fmt.Errorf("%w: %s", ErrTimeout, err)
+		return e.queryFailed(queryCtx, queryTxt, fmt.Errorf("%w: %s", ErrTimeout, err))
 	case errors.Is(err, runner.ErrInvalidChainedParameter):
-		return nil, fmt.Errorf("%w: %s", ErrParser, err)
+		return e.queryFailed(queryCtx, queryTxt, fmt.Errorf("%w: %s", ErrParser, err))
 	case errors.Is(err, runner.ErrInvalidDependsOnTarget):
-		return nil, fmt.Errorf("%w: %s", ErrParser, err)
+		return e.queryFailed(queryCtx, queryTxt, fmt.Errorf("%w: %s", ErrParser, err))
 	case err != nil:
-		return nil, err
+		return e.queryFailed(queryCtx, queryTxt, err)
 	}
 
 	resources, err = ApplyFilters(log, query, resources)
 	if err != nil {
 		log.Error("failed to apply filters", err, "input", fmt.Sprintf("%+#v", queryContext.Input))
-		return nil, err
+		return e.queryFailed(queryCtx, queryTxt, err)
 	}
 
 	resources = ApplyAggregators(log, query, resources)
@@ -128,7 +164,51 @@ func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts
 
 	resources = ApplyHidden(query, resources)
 
-	return resources, nil
+	return Result{Resources: resources, Modifiers: query.Use, Statements: statementsOrder(query)}, nil
+}
+
+// queryFailed notifies the lifecycle plugins about
+// the query execution error before returning it.
+func (e Evaluator) queryFailed(ctx context.Context, queryTxt string, err error) (Result, error) {
+	e.lifecycle.OnQueryError(ctx, queryTxt, err)
+	return Result{}, err
+}
+
+func statementsOrder(query domain.Query) []domain.ResourceID {
+	order := make([]domain.ResourceID, len(query.Statements))
+	for i, stmt := range query.Statements {
+		order[i] = domain.NewResourceID(stmt)
+	}
+
+	return order
+}
+
+// streamResults applies the `only`, `in` and `hidden` clauses
+// on the partial results emitted by the runner before passing
+// them to the emit function.
+func streamResults(log restql.Logger, query domain.Query, emit runner.EmitFn) runner.EmitFn {
+	return func(resources domain.Resources) {
+		partialQuery := query
+		partialQuery.Statements = nil
+		for _, stmt := range query.Statements {
+			if _, found := resources[domain.NewResourceID(stmt)]; found {
+				partialQuery.Statements = append(partialQuery.Statements, stmt)
+			}
+		}
+
+		resources, err := ApplyFilters(log, partialQuery, resources)
+		if err != nil {
+			log.Error("failed to apply filters on partial result", err)
+			return
+		}
+
+		resources = ApplyAggregators(log, partialQuery, resources)
+		resources = ApplyHidden(partialQuery, resources)
+
+		if len(resources) > 0 {
+			emit(resources)
+		}
+	}
 }
 
 func validateQueryResources(query domain.Query, mappings map[string]restql.Mapping) error {
@@ -90,6 +90,12 @@ func extractUsingFilters(filters map[string]interface{}, resourceResult interfac
 				if err != nil {
 					return nil, err
 				}
+			case domain.CustomFunction:
+				result, err := subFilter.Call(value)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to apply %s function", subFilter.Fn.Name)
+				}
+				node[key] = result
 			case map[string]interface{}:
 				f, err := extractUsingFilters(subFilter, value)
 				if err != nil {
@@ -58,7 +58,7 @@ func resolveWithParamValue(value interface{}, input restql.QueryInput) (interfac
 		return resolveChain(value, input)
 	case domain.Function:
 		v, ok := resolveWithParamValue(value.Target(), input)
-		fnValue := value.Map(func(target interface{}) interface{} { return v })
+		fnValue := resolveFunction(value, input).Map(func(target interface{}) interface{} { return v })
 		return fnValue, ok
 	case map[string]interface{}:
 		return resolveComplexWithParam(value, input), true
@@ -84,7 +84,7 @@ func resolveWithBody(body interface{}, input restql.QueryInput) interface{} {
 
 		return b
 	case domain.Function:
-		return body.Map(func(target interface{}) interface{} {
+		return resolveFunction(body, input).Map(func(target interface{}) interface{} {
 			return resolveWithBody(target, input)
 		})
 	default:
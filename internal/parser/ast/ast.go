@@ -15,6 +15,8 @@ const (
 	MaxAgeKeyword       = "max-age"
 	SmaxAgeKeyword      = "s-max-age"
 	IgnoreErrorsKeyword = "ignore-errors"
+	CriticalKeyword     = "critical"
+	OptionalKeyword     = "optional"
 	Matches             = "matches"
 	NoMultiplex         = "no-multiplex"
 	Base64              = "base64"
@@ -55,7 +57,7 @@ type Block struct {
 
 // Qualifier is the syntax node representing statement
 // clauses: `with`, `only`, `hidden`, `headers`, `timeout`
-// `max-age`, `s-max-age` and `ignore-errors`.
+// `max-age`, `s-max-age`, `ignore-errors`, `critical` and `optional`.
 type Qualifier struct {
 	With         *Parameters
 	Only         []Filter
@@ -66,6 +68,7 @@ type Qualifier struct {
 	MaxAge       *MaxAgeValue
 	SMaxAge      *SMaxAgeValue
 	IgnoreErrors bool
+	Criticality  string
 }
 
 // Filter is the syntax node representing entries
@@ -92,6 +95,14 @@ type FilterByRegex struct {
 	RegexVariable *string
 }
 
+// FunctionCall is the syntax node representing a function
+// provided by plugins, applied through the `->` operator
+// on `with` parameters and `only` filters.
+type FunctionCall struct {
+	Name string
+	Args []Value
+}
+
 // Parameters is the syntax node representing
 // the `with` clause.
 type Parameters struct {
@@ -103,7 +114,7 @@ type Parameters struct {
 // the dynamic body feature of the `with` clause.
 type ParameterBody struct {
 	Target    string
-	Functions []string
+	Functions []interface{}
 }
 
 // KeyValue is the syntax node representing
@@ -111,7 +122,7 @@ type ParameterBody struct {
 type KeyValue struct {
 	Key       string
 	Value     Value
-	Functions []string
+	Functions []interface{}
 }
 
 // Value is the syntax node representing
@@ -200,9 +211,42 @@ const noFilename = ""
 func (g Generator) Parse(query string) (*Query, error) {
 	parse, err := Parse(noFilename, []byte(query))
 	if err != nil {
-		return nil, err
+		return nil, newSyntaxError(err)
 	}
 
 	q := parse.(Query)
 	return &q, nil
 }
+
+// SyntaxError is returned when a query string does not comply
+// with the restQL grammar, carrying the position of the query
+// text where the parsing failed.
+type SyntaxError struct {
+	Line   int
+	Column int
+	Offset int
+	Err    error
+}
+
+func newSyntaxError(err error) error {
+	list, ok := err.(errList)
+	if !ok || len(list) == 0 {
+		return err
+	}
+
+	pe, ok := list[0].(*parserError)
+	if !ok {
+		return err
+	}
+
+	return &SyntaxError{Line: pe.pos.line, Column: pe.pos.col, Offset: pe.pos.offset, Err: err}
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
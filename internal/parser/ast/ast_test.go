@@ -1,6 +1,7 @@
 package ast_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser/ast"
@@ -328,7 +329,7 @@ func TestAstGenerator(t *testing.T) {
 										{Primitive: &ast.Primitive{String: String("sword")}},
 										{Primitive: &ast.Primitive{String: String("shield")}},
 									}},
-									Functions: []string{"no-multiplex"},
+									Functions: []interface{}{"no-multiplex"},
 								},
 							},
 						},
@@ -348,7 +349,7 @@ func TestAstGenerator(t *testing.T) {
 							{
 								Key:       "id",
 								Value:     ast.Value{Primitive: &ast.Primitive{Chain: []ast.Chained{{PathItem: "done-resource"}, {PathItem: "id"}}}},
-								Functions: []string{"no-multiplex"},
+								Functions: []interface{}{"no-multiplex"},
 							},
 						},
 					},
@@ -367,7 +368,7 @@ func TestAstGenerator(t *testing.T) {
 							{
 								Key:       "id",
 								Value:     ast.Value{Primitive: &ast.Primitive{String: String("abcdefg12345")}},
-								Functions: []string{"base64"},
+								Functions: []interface{}{"base64"},
 							},
 						},
 					},
@@ -386,7 +387,7 @@ func TestAstGenerator(t *testing.T) {
 							{
 								Key:       "id",
 								Value:     ast.Value{List: []ast.Value{{Object: []ast.ObjectEntry{{Key: "registryNumber", Value: ast.Value{Primitive: &ast.Primitive{String: String("abcdefg12345")}}}}}}},
-								Functions: []string{"as-body"},
+								Functions: []interface{}{"as-body"},
 							},
 						},
 					},
@@ -404,7 +405,7 @@ func TestAstGenerator(t *testing.T) {
 						KeyValues: []ast.KeyValue{{
 							Key:       "id",
 							Value:     ast.Value{Object: []ast.ObjectEntry{{Key: "id", Value: ast.Value{Primitive: &ast.Primitive{String: String("1")}}}}},
-							Functions: []string{"json"},
+							Functions: []interface{}{"json"},
 						}},
 					},
 				}},
@@ -421,7 +422,7 @@ func TestAstGenerator(t *testing.T) {
 						KeyValues: []ast.KeyValue{{
 							Key:       "id",
 							Value:     ast.Value{List: []ast.Value{{Primitive: &ast.Primitive{Int: Int(1)}}, {Primitive: &ast.Primitive{Int: Int(2)}}, {Primitive: &ast.Primitive{Int: Int(3)}}}},
-							Functions: []string{"no-multiplex", "json"},
+							Functions: []interface{}{"no-multiplex", "json"},
 						}},
 					},
 				}},
@@ -442,7 +443,7 @@ func TestAstGenerator(t *testing.T) {
 								{List: []ast.Value{{Primitive: &ast.Primitive{Int: Int(2)}}}},
 								{List: []ast.Value{{Primitive: &ast.Primitive{Int: Int(3)}}}},
 							}},
-							Functions: []string{"flatten"},
+							Functions: []interface{}{"flatten"},
 						}},
 					},
 				}},
@@ -511,7 +512,7 @@ func TestAstGenerator(t *testing.T) {
 								With: &ast.Parameters{
 									Body: &ast.ParameterBody{
 										Target:    "body",
-										Functions: []string{"no-multiplex"},
+										Functions: []interface{}{"no-multiplex"},
 									},
 								},
 							},
@@ -834,7 +835,7 @@ func TestAstGenerator(t *testing.T) {
 											}},
 										},
 									}},
-									Functions: []string{"no-explode"},
+									Functions: []interface{}{"no-explode"},
 								},
 							},
 						},
@@ -970,7 +971,7 @@ func TestAstGenerator(t *testing.T) {
 							{
 								Key:       "context",
 								Value:     ast.Value{Primitive: &ast.Primitive{String: String("crossover")}},
-								Functions: []string{"as-query"},
+								Functions: []interface{}{"as-query"},
 							},
 						},
 					},
@@ -992,3 +993,19 @@ func TestAstGenerator(t *testing.T) {
 		})
 	}
 }
+
+func TestAstGenerator_SyntaxError(t *testing.T) {
+	generator, err := ast.New()
+	test.VerifyError(t, err)
+
+	_, err = generator.Parse("from hero\n  with id = 1 foo")
+
+	var syntaxErr *ast.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected a syntax error, got %v", err)
+	}
+
+	test.Equal(t, syntaxErr.Line, 2)
+	test.Equal(t, syntaxErr.Column, 15)
+	test.Equal(t, syntaxErr.Offset, 24)
+}
@@ -74,7 +74,7 @@ func newUseValue(value interface{}) (UseValue, error) {
 	return UseValue{}, errors.Errorf("unknown use value type : %T", value)
 }
 
-func newBlock(action, modifiers, with, filter, ignore interface{}) (Block, error) {
+func newBlock(action, modifiers, with, filter, flags interface{}) (Block, error) {
 	ac := action.(actionRule)
 	block := Block{
 		Method:   ac.Method,
@@ -130,9 +130,9 @@ func newBlock(action, modifiers, with, filter, ignore interface{}) (Block, error
 		block.Qualifiers = append(block.Qualifiers, q)
 	}
 
-	if ignore != nil {
-		ig := ignore.(ignoreErrors)
-		q := Qualifier{IgnoreErrors: bool(ig)}
+	if flags != nil {
+		f := flags.(blockFlags)
+		q := Qualifier{IgnoreErrors: f.ignoreErrors, Criticality: f.criticality}
 
 		block.Qualifiers = append(block.Qualifiers, q)
 	}
@@ -251,12 +251,15 @@ func newKeyValue(key, value, functions interface{}) (KeyValue, error) {
 	return kv, nil
 }
 
-func newFunctionList(functions interface{}) []string {
+func newFunctionList(functions interface{}) []interface{} {
 	fns := functions.([]interface{})
-	var result []string
+	var result []interface{}
 
 	for _, fn := range fns {
-		if fn, ok := fn.(string); ok {
+		switch fn := fn.(type) {
+		case string:
+			result = append(result, fn)
+		case FunctionCall:
 			result = append(result, fn)
 		}
 	}
@@ -264,6 +267,53 @@ func newFunctionList(functions interface{}) []string {
 	return result
 }
 
+func newFunctionCall(name, args interface{}) (FunctionCall, error) {
+	fc := FunctionCall{Name: name.(string)}
+
+	if args, ok := args.([]Value); ok {
+		fc.Args = args
+	}
+
+	return fc, nil
+}
+
+func newFunctionArgs(args interface{}) ([]Value, error) {
+	if args == nil {
+		return []Value{}, nil
+	}
+
+	return args.([]Value), nil
+}
+
+func newFunctionArgList(first, others interface{}) ([]Value, error) {
+	args := []Value{first.(Value)}
+
+	if others != nil {
+		oa := flatten(others.([]interface{}))
+
+		for _, a := range oa {
+			if a, ok := a.(Value); ok {
+				args = append(args, a)
+			}
+		}
+	}
+
+	return args, nil
+}
+
+func newFunctionArg(value interface{}) (Value, error) {
+	if v, ok := value.(variable); ok {
+		return newValue(v)
+	}
+
+	p, err := newPrimitive(value)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return newValue(p)
+}
+
 func newValue(value interface{}) (Value, error) {
 	switch value := value.(type) {
 	case *Primitive:
@@ -443,6 +493,8 @@ func makeFunctionList(fns interface{}) []interface{} {
 			result = append(result, f)
 		case FilterByRegex:
 			result = append(result, f)
+		case FunctionCall:
+			result = append(result, f)
 		}
 	}
 
@@ -586,17 +638,48 @@ func newDependsOn(target interface{}) (DependsOnValue, error) {
 	return DependsOnValue(d), nil
 }
 
+type blockFlags struct {
+	ignoreErrors bool
+	criticality  string
+}
+
 type ignoreErrors bool
 
-func newFlags(ignoreFlag, others interface{}) (ignoreErrors, error) {
-	i := ignoreFlag.(ignoreErrors)
-	return i, nil
+type criticality string
+
+func newFlags(flag, others interface{}) (blockFlags, error) {
+	flagList := []interface{}{flag}
+	if others, ok := others.([]interface{}); ok {
+		for _, o := range others {
+			seq := o.([]interface{})
+			flagList = append(flagList, seq[len(seq)-1])
+		}
+	}
+
+	var f blockFlags
+	for _, flag := range flagList {
+		switch flag := flag.(type) {
+		case ignoreErrors:
+			f.ignoreErrors = bool(flag)
+		case criticality:
+			if f.criticality != "" && f.criticality != string(flag) {
+				return blockFlags{}, errors.New("critical and optional flags cannot be used in the same statement")
+			}
+			f.criticality = string(flag)
+		}
+	}
+
+	return f, nil
 }
 
 func newIgnoreErrors() (ignoreErrors, error) {
 	return true, nil
 }
 
+func newCriticality(text []byte) (criticality, error) {
+	return criticality(text), nil
+}
+
 func newBoolean(boolean []byte) (bool, error) {
 	return strconv.ParseBool(string(boolean))
 }
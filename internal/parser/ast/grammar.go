@@ -171,27 +171,40 @@ var g = &grammar{
 							pos:  position{line: 21, col: 37, offset: 339},
 							name: "WS",
 						},
+						&zeroOrOneExpr{
+							pos: position{line: 21, col: 40, offset: 342},
+							expr: &litMatcher{
+								pos:        position{line: 21, col: 40, offset: 342},
+								val:        "=",
+								ignoreCase: false,
+								want:       "\"=\"",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 21, col: 45, offset: 347},
+							name: "WS",
+						},
 						&labeledExpr{
-							pos:   position{line: 21, col: 40, offset: 342},
+							pos:   position{line: 21, col: 48, offset: 350},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 43, offset: 345},
+								pos:  position{line: 21, col: 51, offset: 353},
 								name: "USE_VALUE",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 21, col: 54, offset: 356},
+							pos:  position{line: 21, col: 62, offset: 364},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 21, col: 57, offset: 359},
+							pos: position{line: 21, col: 65, offset: 367},
 							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 57, offset: 359},
+								pos:  position{line: 21, col: 65, offset: 367},
 								name: "LS",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 21, col: 61, offset: 363},
+							pos:  position{line: 21, col: 69, offset: 371},
 							name: "WS",
 						},
 					},
@@ -200,53 +213,65 @@ var g = &grammar{
 		},
 		{
 			name: "USE_ACTION",
-			pos:  position{line: 25, col: 1, offset: 392},
+			pos:  position{line: 25, col: 1, offset: 400},
 			expr: &actionExpr{
-				pos: position{line: 25, col: 15, offset: 406},
+				pos: position{line: 25, col: 15, offset: 414},
 				run: (*parser).callonUSE_ACTION1,
 				expr: &choiceExpr{
-					pos: position{line: 25, col: 16, offset: 407},
+					pos: position{line: 25, col: 16, offset: 415},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 25, col: 16, offset: 407},
+							pos:        position{line: 25, col: 16, offset: 415},
 							val:        "timeout",
 							ignoreCase: false,
 							want:       "\"timeout\"",
 						},
 						&litMatcher{
-							pos:        position{line: 25, col: 28, offset: 419},
+							pos:        position{line: 25, col: 28, offset: 427},
 							val:        "max-age",
 							ignoreCase: false,
 							want:       "\"max-age\"",
 						},
 						&litMatcher{
-							pos:        position{line: 25, col: 40, offset: 431},
+							pos:        position{line: 25, col: 40, offset: 439},
 							val:        "s-max-age",
 							ignoreCase: false,
 							want:       "\"s-max-age\"",
 						},
+						&litMatcher{
+							pos:        position{line: 25, col: 54, offset: 453},
+							val:        "response",
+							ignoreCase: false,
+							want:       "\"response\"",
+						},
+						&litMatcher{
+							pos:        position{line: 25, col: 67, offset: 466},
+							val:        "status-policy",
+							ignoreCase: false,
+							want:       "\"status-policy\"",
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "USE_VALUE",
-			pos:  position{line: 29, col: 1, offset: 475},
+			pos:  position{line: 29, col: 1, offset: 514},
 			expr: &actionExpr{
-				pos: position{line: 29, col: 14, offset: 488},
+				pos: position{line: 29, col: 14, offset: 527},
 				run: (*parser).callonUSE_VALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 29, col: 14, offset: 488},
+					pos:   position{line: 29, col: 14, offset: 527},
 					label: "v",
 					expr: &choiceExpr{
-						pos: position{line: 29, col: 17, offset: 491},
+						pos: position{line: 29, col: 17, offset: 530},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 29, col: 17, offset: 491},
+								pos:  position{line: 29, col: 17, offset: 530},
 								name: "String",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 29, col: 26, offset: 500},
+								pos:  position{line: 29, col: 26, offset: 539},
 								name: "Integer",
 							},
 						},
@@ -256,57 +281,57 @@ var g = &grammar{
 		},
 		{
 			name: "BLOCK",
-			pos:  position{line: 33, col: 1, offset: 537},
+			pos:  position{line: 33, col: 1, offset: 576},
 			expr: &actionExpr{
-				pos: position{line: 33, col: 10, offset: 546},
+				pos: position{line: 33, col: 10, offset: 585},
 				run: (*parser).callonBLOCK1,
 				expr: &seqExpr{
-					pos: position{line: 33, col: 10, offset: 546},
+					pos: position{line: 33, col: 10, offset: 585},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 33, col: 10, offset: 546},
+							pos:   position{line: 33, col: 10, offset: 585},
 							label: "action",
 							expr: &ruleRefExpr{
-								pos:  position{line: 33, col: 18, offset: 554},
+								pos:  position{line: 33, col: 18, offset: 593},
 								name: "ACTION_RULE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 31, offset: 567},
+							pos:   position{line: 33, col: 31, offset: 606},
 							label: "m",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 34, offset: 570},
+								pos: position{line: 33, col: 34, offset: 609},
 								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 34, offset: 570},
+									pos:  position{line: 33, col: 34, offset: 609},
 									name: "MODIFIER_RULE",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 50, offset: 586},
+							pos:   position{line: 33, col: 50, offset: 625},
 							label: "w",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 53, offset: 589},
+								pos: position{line: 33, col: 53, offset: 628},
 								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 53, offset: 589},
+									pos:  position{line: 33, col: 53, offset: 628},
 									name: "WITH_RULE",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 65, offset: 601},
+							pos:   position{line: 33, col: 65, offset: 640},
 							label: "f",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 67, offset: 603},
+								pos: position{line: 33, col: 67, offset: 642},
 								expr: &choiceExpr{
-									pos: position{line: 33, col: 68, offset: 604},
+									pos: position{line: 33, col: 68, offset: 643},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 33, col: 68, offset: 604},
+											pos:  position{line: 33, col: 68, offset: 643},
 											name: "HIDDEN_RULE",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 33, col: 82, offset: 618},
+											pos:  position{line: 33, col: 82, offset: 657},
 											name: "ONLY_RULE",
 										},
 									},
@@ -314,18 +339,18 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 94, offset: 630},
+							pos:   position{line: 33, col: 94, offset: 669},
 							label: "fl",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 98, offset: 634},
+								pos: position{line: 33, col: 98, offset: 673},
 								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 98, offset: 634},
+									pos:  position{line: 33, col: 98, offset: 673},
 									name: "FLAGS_RULE",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 33, col: 111, offset: 647},
+							pos:  position{line: 33, col: 111, offset: 686},
 							name: "WS",
 						},
 					},
@@ -334,51 +359,51 @@ var g = &grammar{
 		},
 		{
 			name: "ACTION_RULE",
-			pos:  position{line: 37, col: 1, offset: 693},
+			pos:  position{line: 37, col: 1, offset: 732},
 			expr: &actionExpr{
-				pos: position{line: 37, col: 16, offset: 708},
+				pos: position{line: 37, col: 16, offset: 747},
 				run: (*parser).callonACTION_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 37, col: 16, offset: 708},
+					pos: position{line: 37, col: 16, offset: 747},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 37, col: 16, offset: 708},
+							pos:   position{line: 37, col: 16, offset: 747},
 							label: "m",
 							expr: &ruleRefExpr{
-								pos:  position{line: 37, col: 19, offset: 711},
+								pos:  position{line: 37, col: 19, offset: 750},
 								name: "METHOD",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 37, col: 27, offset: 719},
+							pos:  position{line: 37, col: 27, offset: 758},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 35, offset: 727},
+							pos:   position{line: 37, col: 35, offset: 766},
 							label: "r",
 							expr: &ruleRefExpr{
-								pos:  position{line: 37, col: 38, offset: 730},
+								pos:  position{line: 37, col: 38, offset: 769},
 								name: "IDENT",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 45, offset: 737},
+							pos:   position{line: 37, col: 45, offset: 776},
 							label: "a",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 37, col: 48, offset: 740},
+								pos: position{line: 37, col: 48, offset: 779},
 								expr: &ruleRefExpr{
-									pos:  position{line: 37, col: 48, offset: 740},
+									pos:  position{line: 37, col: 48, offset: 779},
 									name: "ALIAS",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 56, offset: 748},
+							pos:   position{line: 37, col: 56, offset: 787},
 							label: "i",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 37, col: 59, offset: 751},
+								pos: position{line: 37, col: 59, offset: 790},
 								expr: &ruleRefExpr{
-									pos:  position{line: 37, col: 59, offset: 751},
+									pos:  position{line: 37, col: 59, offset: 790},
 									name: "IN",
 								},
 							},
@@ -389,39 +414,39 @@ var g = &grammar{
 		},
 		{
 			name: "METHOD",
-			pos:  position{line: 41, col: 1, offset: 795},
+			pos:  position{line: 41, col: 1, offset: 834},
 			expr: &actionExpr{
-				pos: position{line: 41, col: 11, offset: 805},
+				pos: position{line: 41, col: 11, offset: 844},
 				run: (*parser).callonMETHOD1,
 				expr: &choiceExpr{
-					pos: position{line: 41, col: 12, offset: 806},
+					pos: position{line: 41, col: 12, offset: 845},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 41, col: 12, offset: 806},
+							pos:        position{line: 41, col: 12, offset: 845},
 							val:        "from",
 							ignoreCase: false,
 							want:       "\"from\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 21, offset: 815},
+							pos:        position{line: 41, col: 21, offset: 854},
 							val:        "to",
 							ignoreCase: false,
 							want:       "\"to\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 28, offset: 822},
+							pos:        position{line: 41, col: 28, offset: 861},
 							val:        "into",
 							ignoreCase: false,
 							want:       "\"into\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 36, offset: 830},
+							pos:        position{line: 41, col: 36, offset: 869},
 							val:        "update",
 							ignoreCase: false,
 							want:       "\"update\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 47, offset: 841},
+							pos:        position{line: 41, col: 47, offset: 880},
 							val:        "delete",
 							ignoreCase: false,
 							want:       "\"delete\"",
@@ -432,32 +457,32 @@ var g = &grammar{
 		},
 		{
 			name: "ALIAS",
-			pos:  position{line: 45, col: 1, offset: 882},
+			pos:  position{line: 45, col: 1, offset: 921},
 			expr: &actionExpr{
-				pos: position{line: 45, col: 10, offset: 891},
+				pos: position{line: 45, col: 10, offset: 930},
 				run: (*parser).callonALIAS1,
 				expr: &seqExpr{
-					pos: position{line: 45, col: 10, offset: 891},
+					pos: position{line: 45, col: 10, offset: 930},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 45, col: 10, offset: 891},
+							pos:  position{line: 45, col: 10, offset: 930},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 45, col: 18, offset: 899},
+							pos:        position{line: 45, col: 18, offset: 938},
 							val:        "as",
 							ignoreCase: false,
 							want:       "\"as\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 45, col: 23, offset: 904},
+							pos:  position{line: 45, col: 23, offset: 943},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 45, col: 31, offset: 912},
+							pos:   position{line: 45, col: 31, offset: 951},
 							label: "a",
 							expr: &ruleRefExpr{
-								pos:  position{line: 45, col: 34, offset: 915},
+								pos:  position{line: 45, col: 34, offset: 954},
 								name: "IDENT",
 							},
 						},
@@ -467,32 +492,32 @@ var g = &grammar{
 		},
 		{
 			name: "IN",
-			pos:  position{line: 49, col: 1, offset: 942},
+			pos:  position{line: 49, col: 1, offset: 981},
 			expr: &actionExpr{
-				pos: position{line: 49, col: 7, offset: 948},
+				pos: position{line: 49, col: 7, offset: 987},
 				run: (*parser).callonIN1,
 				expr: &seqExpr{
-					pos: position{line: 49, col: 7, offset: 948},
+					pos: position{line: 49, col: 7, offset: 987},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 49, col: 7, offset: 948},
+							pos:  position{line: 49, col: 7, offset: 987},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 49, col: 15, offset: 956},
+							pos:        position{line: 49, col: 15, offset: 995},
 							val:        "in",
 							ignoreCase: false,
 							want:       "\"in\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 49, col: 20, offset: 961},
+							pos:  position{line: 49, col: 20, offset: 1000},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 49, col: 28, offset: 969},
+							pos:   position{line: 49, col: 28, offset: 1008},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 49, col: 31, offset: 972},
+								pos:  position{line: 49, col: 31, offset: 1011},
 								name: "IDENT_WITH_DOT",
 							},
 						},
@@ -502,36 +527,36 @@ var g = &grammar{
 		},
 		{
 			name: "MODIFIER_RULE",
-			pos:  position{line: 53, col: 1, offset: 1010},
+			pos:  position{line: 53, col: 1, offset: 1049},
 			expr: &actionExpr{
-				pos: position{line: 53, col: 18, offset: 1027},
+				pos: position{line: 53, col: 18, offset: 1066},
 				run: (*parser).callonMODIFIER_RULE1,
 				expr: &labeledExpr{
-					pos:   position{line: 53, col: 18, offset: 1027},
+					pos:   position{line: 53, col: 18, offset: 1066},
 					label: "m",
 					expr: &oneOrMoreExpr{
-						pos: position{line: 53, col: 20, offset: 1029},
+						pos: position{line: 53, col: 20, offset: 1068},
 						expr: &choiceExpr{
-							pos: position{line: 53, col: 21, offset: 1030},
+							pos: position{line: 53, col: 21, offset: 1069},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 21, offset: 1030},
+									pos:  position{line: 53, col: 21, offset: 1069},
 									name: "HEADERS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 31, offset: 1040},
+									pos:  position{line: 53, col: 31, offset: 1079},
 									name: "TIMEOUT",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 41, offset: 1050},
+									pos:  position{line: 53, col: 41, offset: 1089},
 									name: "MAX_AGE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 51, offset: 1060},
+									pos:  position{line: 53, col: 51, offset: 1099},
 									name: "S_MAX_AGE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 63, offset: 1072},
+									pos:  position{line: 53, col: 63, offset: 1111},
 									name: "DEPENDS_ON",
 								},
 							},
@@ -542,45 +567,45 @@ var g = &grammar{
 		},
 		{
 			name: "WITH_RULE",
-			pos:  position{line: 57, col: 1, offset: 1105},
+			pos:  position{line: 57, col: 1, offset: 1144},
 			expr: &actionExpr{
-				pos: position{line: 57, col: 14, offset: 1118},
+				pos: position{line: 57, col: 14, offset: 1157},
 				run: (*parser).callonWITH_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 57, col: 14, offset: 1118},
+					pos: position{line: 57, col: 14, offset: 1157},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 57, col: 14, offset: 1118},
+							pos:  position{line: 57, col: 14, offset: 1157},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 57, col: 22, offset: 1126},
+							pos:        position{line: 57, col: 22, offset: 1165},
 							val:        "with",
 							ignoreCase: false,
 							want:       "\"with\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 57, col: 29, offset: 1133},
+							pos:  position{line: 57, col: 29, offset: 1172},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 57, col: 37, offset: 1141},
+							pos:   position{line: 57, col: 37, offset: 1180},
 							label: "pb",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 57, col: 40, offset: 1144},
+								pos: position{line: 57, col: 40, offset: 1183},
 								expr: &ruleRefExpr{
-									pos:  position{line: 57, col: 40, offset: 1144},
+									pos:  position{line: 57, col: 40, offset: 1183},
 									name: "PARAMETER_BODY",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 57, col: 56, offset: 1160},
+							pos:   position{line: 57, col: 56, offset: 1199},
 							label: "kvs",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 57, col: 60, offset: 1164},
+								pos: position{line: 57, col: 60, offset: 1203},
 								expr: &ruleRefExpr{
-									pos:  position{line: 57, col: 60, offset: 1164},
+									pos:  position{line: 57, col: 60, offset: 1203},
 									name: "KEY_VALUE_LIST",
 								},
 							},
@@ -591,51 +616,51 @@ var g = &grammar{
 		},
 		{
 			name: "PARAMETER_BODY",
-			pos:  position{line: 61, col: 1, offset: 1210},
+			pos:  position{line: 61, col: 1, offset: 1249},
 			expr: &actionExpr{
-				pos: position{line: 61, col: 19, offset: 1228},
+				pos: position{line: 61, col: 19, offset: 1267},
 				run: (*parser).callonPARAMETER_BODY1,
 				expr: &seqExpr{
-					pos: position{line: 61, col: 19, offset: 1228},
+					pos: position{line: 61, col: 19, offset: 1267},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 61, col: 19, offset: 1228},
+							pos:        position{line: 61, col: 19, offset: 1267},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 61, col: 23, offset: 1232},
+							pos:   position{line: 61, col: 23, offset: 1271},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 61, col: 26, offset: 1235},
+								pos:  position{line: 61, col: 26, offset: 1274},
 								name: "IDENT",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 61, col: 33, offset: 1242},
+							pos:   position{line: 61, col: 33, offset: 1281},
 							label: "fn",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 61, col: 36, offset: 1245},
+								pos: position{line: 61, col: 36, offset: 1284},
 								expr: &ruleRefExpr{
-									pos:  position{line: 61, col: 37, offset: 1246},
+									pos:  position{line: 61, col: 37, offset: 1285},
 									name: "APPLY_FN",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 61, col: 48, offset: 1257},
+							pos:  position{line: 61, col: 48, offset: 1296},
 							name: "WS",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 61, col: 51, offset: 1260},
+							pos: position{line: 61, col: 51, offset: 1299},
 							expr: &ruleRefExpr{
-								pos:  position{line: 61, col: 51, offset: 1260},
+								pos:  position{line: 61, col: 51, offset: 1299},
 								name: "LS",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 61, col: 55, offset: 1264},
+							pos:  position{line: 61, col: 55, offset: 1303},
 							name: "WS",
 						},
 					},
@@ -644,58 +669,58 @@ var g = &grammar{
 		},
 		{
 			name: "KEY_VALUE_LIST",
-			pos:  position{line: 65, col: 1, offset: 1304},
+			pos:  position{line: 65, col: 1, offset: 1343},
 			expr: &actionExpr{
-				pos: position{line: 65, col: 19, offset: 1322},
+				pos: position{line: 65, col: 19, offset: 1361},
 				run: (*parser).callonKEY_VALUE_LIST1,
 				expr: &seqExpr{
-					pos: position{line: 65, col: 19, offset: 1322},
+					pos: position{line: 65, col: 19, offset: 1361},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 65, col: 19, offset: 1322},
+							pos:   position{line: 65, col: 19, offset: 1361},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 65, col: 25, offset: 1328},
+								pos:  position{line: 65, col: 25, offset: 1367},
 								name: "KEY_VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 65, col: 35, offset: 1338},
+							pos:   position{line: 65, col: 35, offset: 1377},
 							label: "others",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 65, col: 42, offset: 1345},
+								pos: position{line: 65, col: 42, offset: 1384},
 								expr: &seqExpr{
-									pos: position{line: 65, col: 43, offset: 1346},
+									pos: position{line: 65, col: 43, offset: 1385},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 43, offset: 1346},
+											pos:  position{line: 65, col: 43, offset: 1385},
 											name: "WS",
 										},
 										&choiceExpr{
-											pos: position{line: 65, col: 47, offset: 1350},
+											pos: position{line: 65, col: 47, offset: 1389},
 											alternatives: []interface{}{
 												&seqExpr{
-													pos: position{line: 65, col: 47, offset: 1350},
+													pos: position{line: 65, col: 47, offset: 1389},
 													exprs: []interface{}{
 														&ruleRefExpr{
-															pos:  position{line: 65, col: 47, offset: 1350},
+															pos:  position{line: 65, col: 47, offset: 1389},
 															name: "LS",
 														},
 														&zeroOrMoreExpr{
-															pos: position{line: 65, col: 50, offset: 1353},
+															pos: position{line: 65, col: 50, offset: 1392},
 															expr: &seqExpr{
-																pos: position{line: 65, col: 51, offset: 1354},
+																pos: position{line: 65, col: 51, offset: 1393},
 																exprs: []interface{}{
 																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 51, offset: 1354},
+																		pos:  position{line: 65, col: 51, offset: 1393},
 																		name: "WS",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 54, offset: 1357},
+																		pos:  position{line: 65, col: 54, offset: 1396},
 																		name: "NL",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 57, offset: 1360},
+																		pos:  position{line: 65, col: 57, offset: 1399},
 																		name: "WS",
 																	},
 																},
@@ -704,17 +729,17 @@ var g = &grammar{
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 65, col: 64, offset: 1367},
+													pos:  position{line: 65, col: 64, offset: 1406},
 													name: "LS",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 68, offset: 1371},
+											pos:  position{line: 65, col: 68, offset: 1410},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 71, offset: 1374},
+											pos:  position{line: 65, col: 71, offset: 1413},
 											name: "KEY_VALUE",
 										},
 									},
@@ -727,50 +752,50 @@ var g = &grammar{
 		},
 		{
 			name: "KEY_VALUE",
-			pos:  position{line: 69, col: 1, offset: 1430},
+			pos:  position{line: 69, col: 1, offset: 1469},
 			expr: &actionExpr{
-				pos: position{line: 69, col: 14, offset: 1443},
+				pos: position{line: 69, col: 14, offset: 1482},
 				run: (*parser).callonKEY_VALUE1,
 				expr: &seqExpr{
-					pos: position{line: 69, col: 14, offset: 1443},
+					pos: position{line: 69, col: 14, offset: 1482},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 69, col: 14, offset: 1443},
+							pos:   position{line: 69, col: 14, offset: 1482},
 							label: "k",
 							expr: &ruleRefExpr{
-								pos:  position{line: 69, col: 17, offset: 1446},
+								pos:  position{line: 69, col: 17, offset: 1485},
 								name: "IDENT_WITH_DOT",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 69, col: 33, offset: 1462},
+							pos:  position{line: 69, col: 33, offset: 1501},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 69, col: 36, offset: 1465},
+							pos:        position{line: 69, col: 36, offset: 1504},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 69, col: 40, offset: 1469},
+							pos:  position{line: 69, col: 40, offset: 1508},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 69, col: 43, offset: 1472},
+							pos:   position{line: 69, col: 43, offset: 1511},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 69, col: 46, offset: 1475},
+								pos:  position{line: 69, col: 46, offset: 1514},
 								name: "VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 69, col: 53, offset: 1482},
+							pos:   position{line: 69, col: 53, offset: 1521},
 							label: "fn",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 69, col: 56, offset: 1485},
+								pos: position{line: 69, col: 56, offset: 1524},
 								expr: &ruleRefExpr{
-									pos:  position{line: 69, col: 57, offset: 1486},
+									pos:  position{line: 69, col: 57, offset: 1525},
 									name: "APPLY_FN",
 								},
 							},
@@ -781,36 +806,45 @@ var g = &grammar{
 		},
 		{
 			name: "APPLY_FN",
-			pos:  position{line: 73, col: 1, offset: 1532},
+			pos:  position{line: 73, col: 1, offset: 1571},
 			expr: &actionExpr{
-				pos: position{line: 73, col: 13, offset: 1544},
+				pos: position{line: 73, col: 13, offset: 1583},
 				run: (*parser).callonAPPLY_FN1,
 				expr: &seqExpr{
-					pos: position{line: 73, col: 13, offset: 1544},
+					pos: position{line: 73, col: 13, offset: 1583},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 73, col: 13, offset: 1544},
+							pos:  position{line: 73, col: 13, offset: 1583},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 73, col: 16, offset: 1547},
+							pos:        position{line: 73, col: 16, offset: 1586},
 							val:        "->",
 							ignoreCase: false,
 							want:       "\"->\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 73, col: 21, offset: 1552},
+							pos: position{line: 73, col: 21, offset: 1591},
 							expr: &ruleRefExpr{
-								pos:  position{line: 73, col: 21, offset: 1552},
+								pos:  position{line: 73, col: 21, offset: 1591},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 73, col: 25, offset: 1556},
+							pos:   position{line: 73, col: 25, offset: 1595},
 							label: "fn",
-							expr: &ruleRefExpr{
-								pos:  position{line: 73, col: 29, offset: 1560},
-								name: "FUNCTION",
+							expr: &choiceExpr{
+								pos: position{line: 73, col: 29, offset: 1599},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 73, col: 29, offset: 1599},
+										name: "FUNCTION",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 73, col: 40, offset: 1610},
+										name: "FUNCTION_CALL",
+									},
+								},
 							},
 						},
 					},
@@ -819,54 +853,274 @@ var g = &grammar{
 		},
 		{
 			name: "FUNCTION",
-			pos:  position{line: 77, col: 1, offset: 1591},
+			pos:  position{line: 77, col: 1, offset: 1646},
 			expr: &actionExpr{
-				pos: position{line: 77, col: 13, offset: 1603},
+				pos: position{line: 77, col: 13, offset: 1658},
 				run: (*parser).callonFUNCTION1,
-				expr: &choiceExpr{
-					pos: position{line: 77, col: 14, offset: 1604},
-					alternatives: []interface{}{
-						&litMatcher{
-							pos:        position{line: 77, col: 14, offset: 1604},
-							val:        "no-multiplex",
-							ignoreCase: false,
-							want:       "\"no-multiplex\"",
+				expr: &seqExpr{
+					pos: position{line: 77, col: 13, offset: 1658},
+					exprs: []interface{}{
+						&choiceExpr{
+							pos: position{line: 77, col: 14, offset: 1659},
+							alternatives: []interface{}{
+								&litMatcher{
+									pos:        position{line: 77, col: 14, offset: 1659},
+									val:        "no-multiplex",
+									ignoreCase: false,
+									want:       "\"no-multiplex\"",
+								},
+								&litMatcher{
+									pos:        position{line: 77, col: 31, offset: 1676},
+									val:        "no-explode",
+									ignoreCase: false,
+									want:       "\"no-explode\"",
+								},
+								&litMatcher{
+									pos:        position{line: 77, col: 46, offset: 1691},
+									val:        "base64",
+									ignoreCase: false,
+									want:       "\"base64\"",
+								},
+								&litMatcher{
+									pos:        position{line: 77, col: 57, offset: 1702},
+									val:        "json",
+									ignoreCase: false,
+									want:       "\"json\"",
+								},
+								&litMatcher{
+									pos:        position{line: 77, col: 65, offset: 1710},
+									val:        "as-body",
+									ignoreCase: false,
+									want:       "\"as-body\"",
+								},
+								&litMatcher{
+									pos:        position{line: 77, col: 77, offset: 1722},
+									val:        "as-query",
+									ignoreCase: false,
+									want:       "\"as-query\"",
+								},
+								&litMatcher{
+									pos:        position{line: 77, col: 90, offset: 1735},
+									val:        "flatten",
+									ignoreCase: false,
+									want:       "\"flatten\"",
+								},
+							},
 						},
-						&litMatcher{
-							pos:        position{line: 77, col: 31, offset: 1621},
-							val:        "no-explode",
-							ignoreCase: false,
-							want:       "\"no-explode\"",
+						&notExpr{
+							pos: position{line: 77, col: 101, offset: 1746},
+							expr: &ruleRefExpr{
+								pos:  position{line: 77, col: 102, offset: 1747},
+								name: "FUNCTION_NAME_CHAR",
+							},
 						},
-						&litMatcher{
-							pos:        position{line: 77, col: 46, offset: 1636},
-							val:        "base64",
-							ignoreCase: false,
-							want:       "\"base64\"",
+					},
+				},
+			},
+		},
+		{
+			name: "FUNCTION_CALL",
+			pos:  position{line: 81, col: 1, offset: 1797},
+			expr: &actionExpr{
+				pos: position{line: 81, col: 18, offset: 1814},
+				run: (*parser).callonFUNCTION_CALL1,
+				expr: &seqExpr{
+					pos: position{line: 81, col: 18, offset: 1814},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 81, col: 18, offset: 1814},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 81, col: 24, offset: 1820},
+								name: "FUNCTION_NAME",
+							},
 						},
-						&litMatcher{
-							pos:        position{line: 77, col: 57, offset: 1647},
-							val:        "json",
-							ignoreCase: false,
-							want:       "\"json\"",
+						&labeledExpr{
+							pos:   position{line: 81, col: 39, offset: 1835},
+							label: "args",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 81, col: 45, offset: 1841},
+								expr: &ruleRefExpr{
+									pos:  position{line: 81, col: 45, offset: 1841},
+									name: "FUNCTION_ARGS",
+								},
+							},
 						},
-						&litMatcher{
-							pos:        position{line: 77, col: 65, offset: 1655},
-							val:        "as-body",
+					},
+				},
+			},
+		},
+		{
+			name: "FUNCTION_NAME",
+			pos:  position{line: 85, col: 1, offset: 1898},
+			expr: &actionExpr{
+				pos: position{line: 85, col: 18, offset: 1915},
+				run: (*parser).callonFUNCTION_NAME1,
+				expr: &seqExpr{
+					pos: position{line: 85, col: 18, offset: 1915},
+					exprs: []interface{}{
+						&charClassMatcher{
+							pos:        position{line: 85, col: 18, offset: 1915},
+							val:        "[A-Za-z_]",
+							chars:      []rune{'_'},
+							ranges:     []rune{'A', 'Z', 'a', 'z'},
 							ignoreCase: false,
-							want:       "\"as-body\"",
+							inverted:   false,
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 85, col: 28, offset: 1925},
+							expr: &ruleRefExpr{
+								pos:  position{line: 85, col: 28, offset: 1925},
+								name: "FUNCTION_NAME_CHAR",
+							},
 						},
+					},
+				},
+			},
+		},
+		{
+			name: "FUNCTION_NAME_CHAR",
+			pos:  position{line: 89, col: 1, offset: 1976},
+			expr: &charClassMatcher{
+				pos:        position{line: 89, col: 23, offset: 1998},
+				val:        "[A-Za-z0-9_-]",
+				chars:      []rune{'_', '-'},
+				ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
+				ignoreCase: false,
+				inverted:   false,
+			},
+		},
+		{
+			name: "FUNCTION_ARGS",
+			pos:  position{line: 91, col: 1, offset: 2013},
+			expr: &actionExpr{
+				pos: position{line: 91, col: 18, offset: 2030},
+				run: (*parser).callonFUNCTION_ARGS1,
+				expr: &seqExpr{
+					pos: position{line: 91, col: 18, offset: 2030},
+					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 77, col: 77, offset: 1667},
-							val:        "as-query",
+							pos:        position{line: 91, col: 18, offset: 2030},
+							val:        "(",
 							ignoreCase: false,
-							want:       "\"as-query\"",
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 91, col: 22, offset: 2034},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 91, col: 25, offset: 2037},
+							label: "args",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 91, col: 31, offset: 2043},
+								expr: &ruleRefExpr{
+									pos:  position{line: 91, col: 31, offset: 2043},
+									name: "FUNCTION_ARG_LIST",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 91, col: 51, offset: 2063},
+							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 90, offset: 1680},
-							val:        "flatten",
+							pos:        position{line: 91, col: 54, offset: 2066},
+							val:        ")",
 							ignoreCase: false,
-							want:       "\"flatten\"",
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FUNCTION_ARG_LIST",
+			pos:  position{line: 95, col: 1, offset: 2105},
+			expr: &actionExpr{
+				pos: position{line: 95, col: 22, offset: 2126},
+				run: (*parser).callonFUNCTION_ARG_LIST1,
+				expr: &seqExpr{
+					pos: position{line: 95, col: 22, offset: 2126},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 95, col: 22, offset: 2126},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 95, col: 29, offset: 2133},
+								name: "FUNCTION_ARG",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 95, col: 43, offset: 2147},
+							label: "others",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 95, col: 50, offset: 2154},
+								expr: &seqExpr{
+									pos: position{line: 95, col: 51, offset: 2155},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 95, col: 51, offset: 2155},
+											name: "WS",
+										},
+										&litMatcher{
+											pos:        position{line: 95, col: 54, offset: 2158},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 95, col: 58, offset: 2162},
+											name: "WS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 95, col: 61, offset: 2165},
+											name: "FUNCTION_ARG",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FUNCTION_ARG",
+			pos:  position{line: 99, col: 1, offset: 2227},
+			expr: &actionExpr{
+				pos: position{line: 99, col: 17, offset: 2243},
+				run: (*parser).callonFUNCTION_ARG1,
+				expr: &labeledExpr{
+					pos:   position{line: 99, col: 17, offset: 2243},
+					label: "v",
+					expr: &choiceExpr{
+						pos: position{line: 99, col: 20, offset: 2246},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 99, col: 20, offset: 2246},
+								name: "VARIABLE",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 99, col: 31, offset: 2257},
+								name: "Null",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 99, col: 38, offset: 2264},
+								name: "Boolean",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 99, col: 48, offset: 2274},
+								name: "String",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 99, col: 57, offset: 2283},
+								name: "Float",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 99, col: 65, offset: 2291},
+								name: "Integer",
+							},
 						},
 					},
 				},
@@ -874,30 +1128,30 @@ var g = &grammar{
 		},
 		{
 			name: "VALUE",
-			pos:  position{line: 81, col: 1, offset: 1722},
+			pos:  position{line: 103, col: 1, offset: 2331},
 			expr: &actionExpr{
-				pos: position{line: 81, col: 10, offset: 1731},
+				pos: position{line: 103, col: 10, offset: 2340},
 				run: (*parser).callonVALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 81, col: 10, offset: 1731},
+					pos:   position{line: 103, col: 10, offset: 2340},
 					label: "v",
 					expr: &choiceExpr{
-						pos: position{line: 81, col: 13, offset: 1734},
+						pos: position{line: 103, col: 13, offset: 2343},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 13, offset: 1734},
+								pos:  position{line: 103, col: 13, offset: 2343},
 								name: "LIST",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 20, offset: 1741},
+								pos:  position{line: 103, col: 20, offset: 2350},
 								name: "OBJECT",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 29, offset: 1750},
+								pos:  position{line: 103, col: 29, offset: 2359},
 								name: "VARIABLE",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 40, offset: 1761},
+								pos:  position{line: 103, col: 40, offset: 2370},
 								name: "PRIMITIVE",
 							},
 						},
@@ -907,22 +1161,22 @@ var g = &grammar{
 		},
 		{
 			name: "LIST",
-			pos:  position{line: 85, col: 1, offset: 1797},
+			pos:  position{line: 107, col: 1, offset: 2406},
 			expr: &actionExpr{
-				pos: position{line: 85, col: 9, offset: 1805},
+				pos: position{line: 107, col: 9, offset: 2414},
 				run: (*parser).callonLIST1,
 				expr: &labeledExpr{
-					pos:   position{line: 85, col: 9, offset: 1805},
+					pos:   position{line: 107, col: 9, offset: 2414},
 					label: "l",
 					expr: &choiceExpr{
-						pos: position{line: 85, col: 12, offset: 1808},
+						pos: position{line: 107, col: 12, offset: 2417},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 85, col: 12, offset: 1808},
+								pos:  position{line: 107, col: 12, offset: 2417},
 								name: "EMPTY_LIST",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 85, col: 25, offset: 1821},
+								pos:  position{line: 107, col: 25, offset: 2430},
 								name: "POPULATED_LIST",
 							},
 						},
@@ -932,25 +1186,25 @@ var g = &grammar{
 		},
 		{
 			name: "EMPTY_LIST",
-			pos:  position{line: 89, col: 1, offset: 1857},
+			pos:  position{line: 111, col: 1, offset: 2466},
 			expr: &actionExpr{
-				pos: position{line: 89, col: 15, offset: 1871},
+				pos: position{line: 111, col: 15, offset: 2480},
 				run: (*parser).callonEMPTY_LIST1,
 				expr: &seqExpr{
-					pos: position{line: 89, col: 15, offset: 1871},
+					pos: position{line: 111, col: 15, offset: 2480},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 89, col: 15, offset: 1871},
+							pos:        position{line: 111, col: 15, offset: 2480},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 89, col: 19, offset: 1875},
+							pos:  position{line: 111, col: 19, offset: 2484},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 89, col: 22, offset: 1878},
+							pos:        position{line: 111, col: 22, offset: 2487},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -961,56 +1215,56 @@ var g = &grammar{
 		},
 		{
 			name: "POPULATED_LIST",
-			pos:  position{line: 93, col: 1, offset: 1910},
+			pos:  position{line: 115, col: 1, offset: 2519},
 			expr: &actionExpr{
-				pos: position{line: 93, col: 19, offset: 1928},
+				pos: position{line: 115, col: 19, offset: 2537},
 				run: (*parser).callonPOPULATED_LIST1,
 				expr: &seqExpr{
-					pos: position{line: 93, col: 19, offset: 1928},
+					pos: position{line: 115, col: 19, offset: 2537},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 93, col: 19, offset: 1928},
+							pos:        position{line: 115, col: 19, offset: 2537},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 93, col: 23, offset: 1932},
+							pos:  position{line: 115, col: 23, offset: 2541},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 93, col: 26, offset: 1935},
+							pos:   position{line: 115, col: 26, offset: 2544},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 93, col: 28, offset: 1937},
+								pos:  position{line: 115, col: 28, offset: 2546},
 								name: "VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 93, col: 34, offset: 1943},
+							pos:   position{line: 115, col: 34, offset: 2552},
 							label: "ii",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 93, col: 37, offset: 1946},
+								pos: position{line: 115, col: 37, offset: 2555},
 								expr: &seqExpr{
-									pos: position{line: 93, col: 38, offset: 1947},
+									pos: position{line: 115, col: 38, offset: 2556},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 38, offset: 1947},
+											pos:  position{line: 115, col: 38, offset: 2556},
 											name: "WS",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 93, col: 41, offset: 1950},
+											pos: position{line: 115, col: 41, offset: 2559},
 											expr: &ruleRefExpr{
-												pos:  position{line: 93, col: 41, offset: 1950},
+												pos:  position{line: 115, col: 41, offset: 2559},
 												name: "LS",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 45, offset: 1954},
+											pos:  position{line: 115, col: 45, offset: 2563},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 48, offset: 1957},
+											pos:  position{line: 115, col: 48, offset: 2566},
 											name: "VALUE",
 										},
 									},
@@ -1018,11 +1272,11 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 93, col: 56, offset: 1965},
+							pos:  position{line: 115, col: 56, offset: 2574},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 93, col: 59, offset: 1968},
+							pos:        position{line: 115, col: 59, offset: 2577},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -1033,22 +1287,22 @@ var g = &grammar{
 		},
 		{
 			name: "OBJECT",
-			pos:  position{line: 97, col: 1, offset: 2000},
+			pos:  position{line: 119, col: 1, offset: 2609},
 			expr: &actionExpr{
-				pos: position{line: 97, col: 11, offset: 2010},
+				pos: position{line: 119, col: 11, offset: 2619},
 				run: (*parser).callonOBJECT1,
 				expr: &labeledExpr{
-					pos:   position{line: 97, col: 11, offset: 2010},
+					pos:   position{line: 119, col: 11, offset: 2619},
 					label: "o",
 					expr: &choiceExpr{
-						pos: position{line: 97, col: 14, offset: 2013},
+						pos: position{line: 119, col: 14, offset: 2622},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 97, col: 14, offset: 2013},
+								pos:  position{line: 119, col: 14, offset: 2622},
 								name: "EMPTY_OBJ",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 97, col: 26, offset: 2025},
+								pos:  position{line: 119, col: 26, offset: 2634},
 								name: "POPULATED_OBJ",
 							},
 						},
@@ -1058,36 +1312,36 @@ var g = &grammar{
 		},
 		{
 			name: "EMPTY_OBJ",
-			pos:  position{line: 101, col: 1, offset: 2060},
+			pos:  position{line: 123, col: 1, offset: 2669},
 			expr: &actionExpr{
-				pos: position{line: 101, col: 14, offset: 2073},
+				pos: position{line: 123, col: 14, offset: 2682},
 				run: (*parser).callonEMPTY_OBJ1,
 				expr: &seqExpr{
-					pos: position{line: 101, col: 14, offset: 2073},
+					pos: position{line: 123, col: 14, offset: 2682},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 101, col: 14, offset: 2073},
+							pos:        position{line: 123, col: 14, offset: 2682},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 101, col: 18, offset: 2077},
+							pos:  position{line: 123, col: 18, offset: 2686},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 101, col: 21, offset: 2080},
+							pos: position{line: 123, col: 21, offset: 2689},
 							expr: &ruleRefExpr{
-								pos:  position{line: 101, col: 21, offset: 2080},
+								pos:  position{line: 123, col: 21, offset: 2689},
 								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 101, col: 25, offset: 2084},
+							pos:  position{line: 123, col: 25, offset: 2693},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 101, col: 28, offset: 2087},
+							pos:        position{line: 123, col: 28, offset: 2696},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -1098,77 +1352,77 @@ var g = &grammar{
 		},
 		{
 			name: "POPULATED_OBJ",
-			pos:  position{line: 105, col: 1, offset: 2121},
+			pos:  position{line: 127, col: 1, offset: 2730},
 			expr: &actionExpr{
-				pos: position{line: 105, col: 18, offset: 2138},
+				pos: position{line: 127, col: 18, offset: 2747},
 				run: (*parser).callonPOPULATED_OBJ1,
 				expr: &seqExpr{
-					pos: position{line: 105, col: 18, offset: 2138},
+					pos: position{line: 127, col: 18, offset: 2747},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 105, col: 18, offset: 2138},
+							pos:        position{line: 127, col: 18, offset: 2747},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 22, offset: 2142},
+							pos:  position{line: 127, col: 22, offset: 2751},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 105, col: 25, offset: 2145},
+							pos: position{line: 127, col: 25, offset: 2754},
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 25, offset: 2145},
+								pos:  position{line: 127, col: 25, offset: 2754},
 								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 29, offset: 2149},
+							pos:  position{line: 127, col: 29, offset: 2758},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 105, col: 32, offset: 2152},
+							pos:   position{line: 127, col: 32, offset: 2761},
 							label: "oe",
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 36, offset: 2156},
+								pos:  position{line: 127, col: 36, offset: 2765},
 								name: "OBJ_ENTRY",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 105, col: 47, offset: 2167},
+							pos:   position{line: 127, col: 47, offset: 2776},
 							label: "oes",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 105, col: 51, offset: 2171},
+								pos: position{line: 127, col: 51, offset: 2780},
 								expr: &seqExpr{
-									pos: position{line: 105, col: 52, offset: 2172},
+									pos: position{line: 127, col: 52, offset: 2781},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 52, offset: 2172},
+											pos:  position{line: 127, col: 52, offset: 2781},
 											name: "WS",
 										},
 										&litMatcher{
-											pos:        position{line: 105, col: 55, offset: 2175},
+											pos:        position{line: 127, col: 55, offset: 2784},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 59, offset: 2179},
+											pos:  position{line: 127, col: 59, offset: 2788},
 											name: "WS",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 105, col: 62, offset: 2182},
+											pos: position{line: 127, col: 62, offset: 2791},
 											expr: &ruleRefExpr{
-												pos:  position{line: 105, col: 62, offset: 2182},
+												pos:  position{line: 127, col: 62, offset: 2791},
 												name: "NL",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 66, offset: 2186},
+											pos:  position{line: 127, col: 66, offset: 2795},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 69, offset: 2189},
+											pos:  position{line: 127, col: 69, offset: 2798},
 											name: "OBJ_ENTRY",
 										},
 									},
@@ -1176,22 +1430,22 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 81, offset: 2201},
+							pos:  position{line: 127, col: 81, offset: 2810},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 105, col: 84, offset: 2204},
+							pos: position{line: 127, col: 84, offset: 2813},
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 84, offset: 2204},
+								pos:  position{line: 127, col: 84, offset: 2813},
 								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 88, offset: 2208},
+							pos:  position{line: 127, col: 88, offset: 2817},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 105, col: 91, offset: 2211},
+							pos:        position{line: 127, col: 91, offset: 2820},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -1202,49 +1456,49 @@ var g = &grammar{
 		},
 		{
 			name: "OBJ_ENTRY",
-			pos:  position{line: 109, col: 1, offset: 2256},
+			pos:  position{line: 131, col: 1, offset: 2865},
 			expr: &actionExpr{
-				pos: position{line: 109, col: 14, offset: 2269},
+				pos: position{line: 131, col: 14, offset: 2878},
 				run: (*parser).callonOBJ_ENTRY1,
 				expr: &seqExpr{
-					pos: position{line: 109, col: 14, offset: 2269},
+					pos: position{line: 131, col: 14, offset: 2878},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 109, col: 14, offset: 2269},
+							pos:   position{line: 131, col: 14, offset: 2878},
 							label: "k",
 							expr: &choiceExpr{
-								pos: position{line: 109, col: 17, offset: 2272},
+								pos: position{line: 131, col: 17, offset: 2881},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 109, col: 17, offset: 2272},
+										pos:  position{line: 131, col: 17, offset: 2881},
 										name: "String",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 109, col: 26, offset: 2281},
+										pos:  position{line: 131, col: 26, offset: 2890},
 										name: "IDENT_WITHOUT_COLLON",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 109, col: 48, offset: 2303},
+							pos:  position{line: 131, col: 48, offset: 2912},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 109, col: 51, offset: 2306},
+							pos:        position{line: 131, col: 51, offset: 2915},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 109, col: 55, offset: 2310},
+							pos:  position{line: 131, col: 55, offset: 2919},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 109, col: 58, offset: 2313},
+							pos:   position{line: 131, col: 58, offset: 2922},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 109, col: 61, offset: 2316},
+								pos:  position{line: 131, col: 61, offset: 2925},
 								name: "VALUE",
 							},
 						},
@@ -1254,38 +1508,38 @@ var g = &grammar{
 		},
 		{
 			name: "PRIMITIVE",
-			pos:  position{line: 113, col: 1, offset: 2357},
+			pos:  position{line: 135, col: 1, offset: 2966},
 			expr: &actionExpr{
-				pos: position{line: 113, col: 14, offset: 2370},
+				pos: position{line: 135, col: 14, offset: 2979},
 				run: (*parser).callonPRIMITIVE1,
 				expr: &labeledExpr{
-					pos:   position{line: 113, col: 14, offset: 2370},
+					pos:   position{line: 135, col: 14, offset: 2979},
 					label: "p",
 					expr: &choiceExpr{
-						pos: position{line: 113, col: 17, offset: 2373},
+						pos: position{line: 135, col: 17, offset: 2982},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 17, offset: 2373},
+								pos:  position{line: 135, col: 17, offset: 2982},
 								name: "Null",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 24, offset: 2380},
+								pos:  position{line: 135, col: 24, offset: 2989},
 								name: "Boolean",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 34, offset: 2390},
+								pos:  position{line: 135, col: 34, offset: 2999},
 								name: "String",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 43, offset: 2399},
+								pos:  position{line: 135, col: 43, offset: 3008},
 								name: "Float",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 51, offset: 2407},
+								pos:  position{line: 135, col: 51, offset: 3016},
 								name: "Integer",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 61, offset: 2417},
+								pos:  position{line: 135, col: 61, offset: 3026},
 								name: "CHAIN",
 							},
 						},
@@ -1295,65 +1549,65 @@ var g = &grammar{
 		},
 		{
 			name: "ONLY_RULE",
-			pos:  position{line: 119, col: 1, offset: 2455},
+			pos:  position{line: 141, col: 1, offset: 3064},
 			expr: &actionExpr{
-				pos: position{line: 119, col: 14, offset: 2468},
+				pos: position{line: 141, col: 14, offset: 3077},
 				run: (*parser).callonONLY_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 119, col: 14, offset: 2468},
+					pos: position{line: 141, col: 14, offset: 3077},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 119, col: 14, offset: 2468},
+							pos:  position{line: 141, col: 14, offset: 3077},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 119, col: 22, offset: 2476},
+							pos:        position{line: 141, col: 22, offset: 3085},
 							val:        "only",
 							ignoreCase: false,
 							want:       "\"only\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 119, col: 29, offset: 2483},
+							pos:  position{line: 141, col: 29, offset: 3092},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 119, col: 37, offset: 2491},
+							pos:   position{line: 141, col: 37, offset: 3100},
 							label: "f",
 							expr: &ruleRefExpr{
-								pos:  position{line: 119, col: 40, offset: 2494},
+								pos:  position{line: 141, col: 40, offset: 3103},
 								name: "FILTER",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 119, col: 48, offset: 2502},
+							pos:   position{line: 141, col: 48, offset: 3111},
 							label: "fs",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 119, col: 51, offset: 2505},
+								pos: position{line: 141, col: 51, offset: 3114},
 								expr: &seqExpr{
-									pos: position{line: 119, col: 52, offset: 2506},
+									pos: position{line: 141, col: 52, offset: 3115},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 52, offset: 2506},
+											pos:  position{line: 141, col: 52, offset: 3115},
 											name: "WS",
 										},
 										&notExpr{
-											pos: position{line: 119, col: 55, offset: 2509},
+											pos: position{line: 141, col: 55, offset: 3118},
 											expr: &choiceExpr{
-												pos: position{line: 119, col: 57, offset: 2511},
+												pos: position{line: 141, col: 57, offset: 3120},
 												alternatives: []interface{}{
 													&ruleRefExpr{
-														pos:  position{line: 119, col: 57, offset: 2511},
+														pos:  position{line: 141, col: 57, offset: 3120},
 														name: "FLAGS_RULE",
 													},
 													&seqExpr{
-														pos: position{line: 119, col: 70, offset: 2524},
+														pos: position{line: 141, col: 70, offset: 3133},
 														exprs: []interface{}{
 															&ruleRefExpr{
-																pos:  position{line: 119, col: 70, offset: 2524},
+																pos:  position{line: 141, col: 70, offset: 3133},
 																name: "BS",
 															},
 															&ruleRefExpr{
-																pos:  position{line: 119, col: 73, offset: 2527},
+																pos:  position{line: 141, col: 73, offset: 3136},
 																name: "BLOCK",
 															},
 														},
@@ -1362,30 +1616,30 @@ var g = &grammar{
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 119, col: 81, offset: 2535},
+											pos: position{line: 141, col: 81, offset: 3144},
 											alternatives: []interface{}{
 												&seqExpr{
-													pos: position{line: 119, col: 81, offset: 2535},
+													pos: position{line: 141, col: 81, offset: 3144},
 													exprs: []interface{}{
 														&ruleRefExpr{
-															pos:  position{line: 119, col: 81, offset: 2535},
+															pos:  position{line: 141, col: 81, offset: 3144},
 															name: "LS",
 														},
 														&zeroOrMoreExpr{
-															pos: position{line: 119, col: 84, offset: 2538},
+															pos: position{line: 141, col: 84, offset: 3147},
 															expr: &seqExpr{
-																pos: position{line: 119, col: 85, offset: 2539},
+																pos: position{line: 141, col: 85, offset: 3148},
 																exprs: []interface{}{
 																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 85, offset: 2539},
+																		pos:  position{line: 141, col: 85, offset: 3148},
 																		name: "WS",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 88, offset: 2542},
+																		pos:  position{line: 141, col: 88, offset: 3151},
 																		name: "NL",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 91, offset: 2545},
+																		pos:  position{line: 141, col: 91, offset: 3154},
 																		name: "WS",
 																	},
 																},
@@ -1394,17 +1648,17 @@ var g = &grammar{
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 119, col: 98, offset: 2552},
+													pos:  position{line: 141, col: 98, offset: 3161},
 													name: "LS",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 102, offset: 2556},
+											pos:  position{line: 141, col: 102, offset: 3165},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 105, offset: 2559},
+											pos:  position{line: 141, col: 105, offset: 3168},
 											name: "FILTER",
 										},
 									},
@@ -1417,28 +1671,28 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER",
-			pos:  position{line: 123, col: 1, offset: 2596},
+			pos:  position{line: 145, col: 1, offset: 3205},
 			expr: &actionExpr{
-				pos: position{line: 123, col: 11, offset: 2606},
+				pos: position{line: 145, col: 11, offset: 3215},
 				run: (*parser).callonFILTER1,
 				expr: &seqExpr{
-					pos: position{line: 123, col: 11, offset: 2606},
+					pos: position{line: 145, col: 11, offset: 3215},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 123, col: 11, offset: 2606},
+							pos:   position{line: 145, col: 11, offset: 3215},
 							label: "f",
 							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 14, offset: 2609},
+								pos:  position{line: 145, col: 14, offset: 3218},
 								name: "FILTER_VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 123, col: 28, offset: 2623},
+							pos:   position{line: 145, col: 28, offset: 3232},
 							label: "fns",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 123, col: 32, offset: 2627},
+								pos: position{line: 145, col: 32, offset: 3236},
 								expr: &ruleRefExpr{
-									pos:  position{line: 123, col: 33, offset: 2628},
+									pos:  position{line: 145, col: 33, offset: 3237},
 									name: "APPLY_FILTER_FN",
 								},
 							},
@@ -1449,22 +1703,22 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER_VALUE",
-			pos:  position{line: 127, col: 1, offset: 2677},
+			pos:  position{line: 149, col: 1, offset: 3286},
 			expr: &actionExpr{
-				pos: position{line: 127, col: 17, offset: 2693},
+				pos: position{line: 149, col: 17, offset: 3302},
 				run: (*parser).callonFILTER_VALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 127, col: 17, offset: 2693},
+					pos:   position{line: 149, col: 17, offset: 3302},
 					label: "fv",
 					expr: &choiceExpr{
-						pos: position{line: 127, col: 21, offset: 2697},
+						pos: position{line: 149, col: 21, offset: 3306},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 127, col: 21, offset: 2697},
+								pos:  position{line: 149, col: 21, offset: 3306},
 								name: "IDENT_WITH_DOT",
 							},
 							&litMatcher{
-								pos:        position{line: 127, col: 38, offset: 2714},
+								pos:        position{line: 149, col: 38, offset: 3323},
 								val:        "*",
 								ignoreCase: false,
 								want:       "\"*\"",
@@ -1476,35 +1730,35 @@ var g = &grammar{
 		},
 		{
 			name: "APPLY_FILTER_FN",
-			pos:  position{line: 131, col: 1, offset: 2751},
+			pos:  position{line: 153, col: 1, offset: 3360},
 			expr: &actionExpr{
-				pos: position{line: 131, col: 20, offset: 2770},
+				pos: position{line: 153, col: 20, offset: 3379},
 				run: (*parser).callonAPPLY_FILTER_FN1,
 				expr: &seqExpr{
-					pos: position{line: 131, col: 20, offset: 2770},
+					pos: position{line: 153, col: 20, offset: 3379},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 131, col: 20, offset: 2770},
+							pos:  position{line: 153, col: 20, offset: 3379},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 131, col: 23, offset: 2773},
+							pos:        position{line: 153, col: 23, offset: 3382},
 							val:        "->",
 							ignoreCase: false,
 							want:       "\"->\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 131, col: 28, offset: 2778},
+							pos: position{line: 153, col: 28, offset: 3387},
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 28, offset: 2778},
+								pos:  position{line: 153, col: 28, offset: 3387},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 131, col: 32, offset: 2782},
+							pos:   position{line: 153, col: 32, offset: 3391},
 							label: "fn",
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 36, offset: 2786},
+								pos:  position{line: 153, col: 36, offset: 3395},
 								name: "FILTER_FUNCTION",
 							},
 						},
@@ -1514,24 +1768,28 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER_FUNCTION",
-			pos:  position{line: 135, col: 1, offset: 2824},
+			pos:  position{line: 157, col: 1, offset: 3433},
 			expr: &actionExpr{
-				pos: position{line: 135, col: 20, offset: 2843},
+				pos: position{line: 157, col: 20, offset: 3452},
 				run: (*parser).callonFILTER_FUNCTION1,
 				expr: &labeledExpr{
-					pos:   position{line: 135, col: 20, offset: 2843},
+					pos:   position{line: 157, col: 20, offset: 3452},
 					label: "f",
 					expr: &choiceExpr{
-						pos: position{line: 135, col: 23, offset: 2846},
+						pos: position{line: 157, col: 23, offset: 3455},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 135, col: 23, offset: 2846},
+								pos:  position{line: 157, col: 23, offset: 3455},
 								name: "MATCHES",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 135, col: 33, offset: 2856},
+								pos:  position{line: 157, col: 33, offset: 3465},
 								name: "FILTER_BY_REGEX",
 							},
+							&ruleRefExpr{
+								pos:  position{line: 157, col: 51, offset: 3483},
+								name: "FUNCTION_CALL",
+							},
 						},
 					},
 				},
@@ -1539,44 +1797,44 @@ var g = &grammar{
 		},
 		{
 			name: "MATCHES",
-			pos:  position{line: 139, col: 1, offset: 2893},
+			pos:  position{line: 161, col: 1, offset: 3518},
 			expr: &actionExpr{
-				pos: position{line: 139, col: 12, offset: 2904},
+				pos: position{line: 161, col: 12, offset: 3529},
 				run: (*parser).callonMATCHES1,
 				expr: &seqExpr{
-					pos: position{line: 139, col: 12, offset: 2904},
+					pos: position{line: 161, col: 12, offset: 3529},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 139, col: 12, offset: 2904},
+							pos:        position{line: 161, col: 12, offset: 3529},
 							val:        "matches",
 							ignoreCase: false,
 							want:       "\"matches\"",
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 22, offset: 2914},
+							pos:        position{line: 161, col: 22, offset: 3539},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 139, col: 26, offset: 2918},
+							pos:   position{line: 161, col: 26, offset: 3543},
 							label: "arg",
 							expr: &choiceExpr{
-								pos: position{line: 139, col: 31, offset: 2923},
+								pos: position{line: 161, col: 31, offset: 3548},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 139, col: 31, offset: 2923},
+										pos:  position{line: 161, col: 31, offset: 3548},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 139, col: 42, offset: 2934},
+										pos:  position{line: 161, col: 42, offset: 3559},
 										name: "String",
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 50, offset: 2942},
+							pos:        position{line: 161, col: 50, offset: 3567},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1587,95 +1845,95 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER_BY_REGEX",
-			pos:  position{line: 143, col: 1, offset: 2979},
+			pos:  position{line: 165, col: 1, offset: 3604},
 			expr: &actionExpr{
-				pos: position{line: 143, col: 20, offset: 2998},
+				pos: position{line: 165, col: 20, offset: 3623},
 				run: (*parser).callonFILTER_BY_REGEX1,
 				expr: &seqExpr{
-					pos: position{line: 143, col: 20, offset: 2998},
+					pos: position{line: 165, col: 20, offset: 3623},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 143, col: 20, offset: 2998},
+							pos:        position{line: 165, col: 20, offset: 3623},
 							val:        "filterByRegex",
 							ignoreCase: false,
 							want:       "\"filterByRegex\"",
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 36, offset: 3014},
+							pos:        position{line: 165, col: 36, offset: 3639},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 40, offset: 3018},
+							pos: position{line: 165, col: 40, offset: 3643},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 40, offset: 3018},
+								pos:  position{line: 165, col: 40, offset: 3643},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 143, col: 44, offset: 3022},
+							pos:   position{line: 165, col: 44, offset: 3647},
 							label: "path",
 							expr: &choiceExpr{
-								pos: position{line: 143, col: 50, offset: 3028},
+								pos: position{line: 165, col: 50, offset: 3653},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 50, offset: 3028},
+										pos:  position{line: 165, col: 50, offset: 3653},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 61, offset: 3039},
+										pos:  position{line: 165, col: 61, offset: 3664},
 										name: "String",
 									},
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 69, offset: 3047},
+							pos: position{line: 165, col: 69, offset: 3672},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 69, offset: 3047},
+								pos:  position{line: 165, col: 69, offset: 3672},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 73, offset: 3051},
+							pos:        position{line: 165, col: 73, offset: 3676},
 							val:        ",",
 							ignoreCase: false,
 							want:       "\",\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 77, offset: 3055},
+							pos: position{line: 165, col: 77, offset: 3680},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 77, offset: 3055},
+								pos:  position{line: 165, col: 77, offset: 3680},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 143, col: 81, offset: 3059},
+							pos:   position{line: 165, col: 81, offset: 3684},
 							label: "regex",
 							expr: &choiceExpr{
-								pos: position{line: 143, col: 88, offset: 3066},
+								pos: position{line: 165, col: 88, offset: 3691},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 88, offset: 3066},
+										pos:  position{line: 165, col: 88, offset: 3691},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 99, offset: 3077},
+										pos:  position{line: 165, col: 99, offset: 3702},
 										name: "String",
 									},
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 107, offset: 3085},
+							pos: position{line: 165, col: 107, offset: 3710},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 107, offset: 3085},
+								pos:  position{line: 165, col: 107, offset: 3710},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 112, offset: 3090},
+							pos:        position{line: 165, col: 112, offset: 3715},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1686,57 +1944,57 @@ var g = &grammar{
 		},
 		{
 			name: "HEADERS",
-			pos:  position{line: 147, col: 1, offset: 3137},
+			pos:  position{line: 169, col: 1, offset: 3762},
 			expr: &actionExpr{
-				pos: position{line: 147, col: 12, offset: 3148},
+				pos: position{line: 169, col: 12, offset: 3773},
 				run: (*parser).callonHEADERS1,
 				expr: &seqExpr{
-					pos: position{line: 147, col: 12, offset: 3148},
+					pos: position{line: 169, col: 12, offset: 3773},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 147, col: 12, offset: 3148},
+							pos:  position{line: 169, col: 12, offset: 3773},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 147, col: 20, offset: 3156},
+							pos:        position{line: 169, col: 20, offset: 3781},
 							val:        "headers",
 							ignoreCase: false,
 							want:       "\"headers\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 147, col: 30, offset: 3166},
+							pos:  position{line: 169, col: 30, offset: 3791},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 147, col: 38, offset: 3174},
+							pos:   position{line: 169, col: 38, offset: 3799},
 							label: "h",
 							expr: &ruleRefExpr{
-								pos:  position{line: 147, col: 41, offset: 3177},
+								pos:  position{line: 169, col: 41, offset: 3802},
 								name: "HEADER",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 147, col: 49, offset: 3185},
+							pos:   position{line: 169, col: 49, offset: 3810},
 							label: "hs",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 147, col: 52, offset: 3188},
+								pos: position{line: 169, col: 52, offset: 3813},
 								expr: &seqExpr{
-									pos: position{line: 147, col: 53, offset: 3189},
+									pos: position{line: 169, col: 53, offset: 3814},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 53, offset: 3189},
+											pos:  position{line: 169, col: 53, offset: 3814},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 56, offset: 3192},
+											pos:  position{line: 169, col: 56, offset: 3817},
 											name: "LS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 59, offset: 3195},
+											pos:  position{line: 169, col: 59, offset: 3820},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 62, offset: 3198},
+											pos:  position{line: 169, col: 62, offset: 3823},
 											name: "HEADER",
 										},
 									},
@@ -1749,51 +2007,51 @@ var g = &grammar{
 		},
 		{
 			name: "HEADER",
-			pos:  position{line: 151, col: 1, offset: 3238},
+			pos:  position{line: 173, col: 1, offset: 3863},
 			expr: &actionExpr{
-				pos: position{line: 151, col: 11, offset: 3248},
+				pos: position{line: 173, col: 11, offset: 3873},
 				run: (*parser).callonHEADER1,
 				expr: &seqExpr{
-					pos: position{line: 151, col: 11, offset: 3248},
+					pos: position{line: 173, col: 11, offset: 3873},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 151, col: 11, offset: 3248},
+							pos:   position{line: 173, col: 11, offset: 3873},
 							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 151, col: 14, offset: 3251},
+								pos:  position{line: 173, col: 14, offset: 3876},
 								name: "IDENT",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 151, col: 21, offset: 3258},
+							pos:  position{line: 173, col: 21, offset: 3883},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 151, col: 24, offset: 3261},
+							pos:        position{line: 173, col: 24, offset: 3886},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 151, col: 28, offset: 3265},
+							pos:  position{line: 173, col: 28, offset: 3890},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 151, col: 31, offset: 3268},
+							pos:   position{line: 173, col: 31, offset: 3893},
 							label: "v",
 							expr: &choiceExpr{
-								pos: position{line: 151, col: 34, offset: 3271},
+								pos: position{line: 173, col: 34, offset: 3896},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 34, offset: 3271},
+										pos:  position{line: 173, col: 34, offset: 3896},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 45, offset: 3282},
+										pos:  position{line: 173, col: 45, offset: 3907},
 										name: "CHAIN",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 53, offset: 3290},
+										pos:  position{line: 173, col: 53, offset: 3915},
 										name: "String",
 									},
 								},
@@ -1805,19 +2063,19 @@ var g = &grammar{
 		},
 		{
 			name: "HIDDEN_RULE",
-			pos:  position{line: 155, col: 1, offset: 3327},
+			pos:  position{line: 177, col: 1, offset: 3952},
 			expr: &actionExpr{
-				pos: position{line: 155, col: 16, offset: 3342},
+				pos: position{line: 177, col: 16, offset: 3967},
 				run: (*parser).callonHIDDEN_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 155, col: 16, offset: 3342},
+					pos: position{line: 177, col: 16, offset: 3967},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 155, col: 16, offset: 3342},
+							pos:  position{line: 177, col: 16, offset: 3967},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 155, col: 24, offset: 3350},
+							pos:        position{line: 177, col: 24, offset: 3975},
 							val:        "hidden",
 							ignoreCase: false,
 							want:       "\"hidden\"",
@@ -1828,39 +2086,39 @@ var g = &grammar{
 		},
 		{
 			name: "TIMEOUT",
-			pos:  position{line: 159, col: 1, offset: 3384},
+			pos:  position{line: 181, col: 1, offset: 4009},
 			expr: &actionExpr{
-				pos: position{line: 159, col: 12, offset: 3395},
+				pos: position{line: 181, col: 12, offset: 4020},
 				run: (*parser).callonTIMEOUT1,
 				expr: &seqExpr{
-					pos: position{line: 159, col: 12, offset: 3395},
+					pos: position{line: 181, col: 12, offset: 4020},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 159, col: 12, offset: 3395},
+							pos:  position{line: 181, col: 12, offset: 4020},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 159, col: 20, offset: 3403},
+							pos:        position{line: 181, col: 20, offset: 4028},
 							val:        "timeout",
 							ignoreCase: false,
 							want:       "\"timeout\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 159, col: 30, offset: 3413},
+							pos:  position{line: 181, col: 30, offset: 4038},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 159, col: 38, offset: 3421},
+							pos:   position{line: 181, col: 38, offset: 4046},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 159, col: 41, offset: 3424},
+								pos: position{line: 181, col: 41, offset: 4049},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 159, col: 41, offset: 3424},
+										pos:  position{line: 181, col: 41, offset: 4049},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 159, col: 52, offset: 3435},
+										pos:  position{line: 181, col: 52, offset: 4060},
 										name: "Integer",
 									},
 								},
@@ -1872,39 +2130,39 @@ var g = &grammar{
 		},
 		{
 			name: "MAX_AGE",
-			pos:  position{line: 163, col: 1, offset: 3471},
+			pos:  position{line: 185, col: 1, offset: 4096},
 			expr: &actionExpr{
-				pos: position{line: 163, col: 12, offset: 3482},
+				pos: position{line: 185, col: 12, offset: 4107},
 				run: (*parser).callonMAX_AGE1,
 				expr: &seqExpr{
-					pos: position{line: 163, col: 12, offset: 3482},
+					pos: position{line: 185, col: 12, offset: 4107},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 163, col: 12, offset: 3482},
+							pos:  position{line: 185, col: 12, offset: 4107},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 163, col: 20, offset: 3490},
+							pos:        position{line: 185, col: 20, offset: 4115},
 							val:        "max-age",
 							ignoreCase: false,
 							want:       "\"max-age\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 163, col: 30, offset: 3500},
+							pos:  position{line: 185, col: 30, offset: 4125},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 163, col: 38, offset: 3508},
+							pos:   position{line: 185, col: 38, offset: 4133},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 163, col: 41, offset: 3511},
+								pos: position{line: 185, col: 41, offset: 4136},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 163, col: 41, offset: 3511},
+										pos:  position{line: 185, col: 41, offset: 4136},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 163, col: 52, offset: 3522},
+										pos:  position{line: 185, col: 52, offset: 4147},
 										name: "Integer",
 									},
 								},
@@ -1916,39 +2174,39 @@ var g = &grammar{
 		},
 		{
 			name: "S_MAX_AGE",
-			pos:  position{line: 167, col: 1, offset: 3557},
+			pos:  position{line: 189, col: 1, offset: 4182},
 			expr: &actionExpr{
-				pos: position{line: 167, col: 14, offset: 3570},
+				pos: position{line: 189, col: 14, offset: 4195},
 				run: (*parser).callonS_MAX_AGE1,
 				expr: &seqExpr{
-					pos: position{line: 167, col: 14, offset: 3570},
+					pos: position{line: 189, col: 14, offset: 4195},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 167, col: 14, offset: 3570},
+							pos:  position{line: 189, col: 14, offset: 4195},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 167, col: 22, offset: 3578},
+							pos:        position{line: 189, col: 22, offset: 4203},
 							val:        "s-max-age",
 							ignoreCase: false,
 							want:       "\"s-max-age\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 167, col: 34, offset: 3590},
+							pos:  position{line: 189, col: 34, offset: 4215},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 167, col: 42, offset: 3598},
+							pos:   position{line: 189, col: 42, offset: 4223},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 167, col: 45, offset: 3601},
+								pos: position{line: 189, col: 45, offset: 4226},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 167, col: 45, offset: 3601},
+										pos:  position{line: 189, col: 45, offset: 4226},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 167, col: 56, offset: 3612},
+										pos:  position{line: 189, col: 56, offset: 4237},
 										name: "Integer",
 									},
 								},
@@ -1960,32 +2218,32 @@ var g = &grammar{
 		},
 		{
 			name: "DEPENDS_ON",
-			pos:  position{line: 172, col: 1, offset: 3649},
+			pos:  position{line: 194, col: 1, offset: 4274},
 			expr: &actionExpr{
-				pos: position{line: 172, col: 15, offset: 3663},
+				pos: position{line: 194, col: 15, offset: 4288},
 				run: (*parser).callonDEPENDS_ON1,
 				expr: &seqExpr{
-					pos: position{line: 172, col: 15, offset: 3663},
+					pos: position{line: 194, col: 15, offset: 4288},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 172, col: 15, offset: 3663},
+							pos:  position{line: 194, col: 15, offset: 4288},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 172, col: 23, offset: 3671},
+							pos:        position{line: 194, col: 23, offset: 4296},
 							val:        "depends-on",
 							ignoreCase: false,
 							want:       "\"depends-on\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 172, col: 36, offset: 3684},
+							pos:  position{line: 194, col: 36, offset: 4309},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 172, col: 44, offset: 3692},
+							pos:   position{line: 194, col: 44, offset: 4317},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 172, col: 47, offset: 3695},
+								pos:  position{line: 194, col: 47, offset: 4320},
 								name: "IDENT",
 							},
 						},
@@ -1995,48 +2253,48 @@ var g = &grammar{
 		},
 		{
 			name: "FLAGS_RULE",
-			pos:  position{line: 176, col: 1, offset: 3731},
+			pos:  position{line: 198, col: 1, offset: 4356},
 			expr: &actionExpr{
-				pos: position{line: 176, col: 15, offset: 3745},
+				pos: position{line: 198, col: 15, offset: 4370},
 				run: (*parser).callonFLAGS_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 176, col: 15, offset: 3745},
+					pos: position{line: 198, col: 15, offset: 4370},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 176, col: 15, offset: 3745},
+							pos:  position{line: 198, col: 15, offset: 4370},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 176, col: 23, offset: 3753},
+							pos:   position{line: 198, col: 23, offset: 4378},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 176, col: 25, offset: 3755},
-								name: "IGNORE_FLAG",
+								pos:  position{line: 198, col: 25, offset: 4380},
+								name: "FLAG",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 176, col: 37, offset: 3767},
+							pos:   position{line: 198, col: 30, offset: 4385},
 							label: "is",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 176, col: 40, offset: 3770},
+								pos: position{line: 198, col: 33, offset: 4388},
 								expr: &seqExpr{
-									pos: position{line: 176, col: 41, offset: 3771},
+									pos: position{line: 198, col: 34, offset: 4389},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 41, offset: 3771},
+											pos:  position{line: 198, col: 34, offset: 4389},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 44, offset: 3774},
+											pos:  position{line: 198, col: 37, offset: 4392},
 											name: "LS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 47, offset: 3777},
+											pos:  position{line: 198, col: 40, offset: 4395},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 50, offset: 3780},
-											name: "IGNORE_FLAG",
+											pos:  position{line: 198, col: 43, offset: 4398},
+											name: "FLAG",
 										},
 									},
 								},
@@ -2046,56 +2304,105 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "FLAG",
+			pos:  position{line: 202, col: 1, offset: 4434},
+			expr: &choiceExpr{
+				pos: position{line: 202, col: 9, offset: 4442},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 202, col: 9, offset: 4442},
+						name: "IGNORE_FLAG",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 202, col: 23, offset: 4456},
+						name: "CRITICAL_FLAG",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 202, col: 39, offset: 4472},
+						name: "OPTIONAL_FLAG",
+					},
+				},
+			},
+		},
 		{
 			name: "IGNORE_FLAG",
-			pos:  position{line: 180, col: 1, offset: 3823},
+			pos:  position{line: 204, col: 1, offset: 4487},
 			expr: &actionExpr{
-				pos: position{line: 180, col: 16, offset: 3838},
+				pos: position{line: 204, col: 16, offset: 4502},
 				run: (*parser).callonIGNORE_FLAG1,
 				expr: &litMatcher{
-					pos:        position{line: 180, col: 16, offset: 3838},
+					pos:        position{line: 204, col: 16, offset: 4502},
 					val:        "ignore-errors",
 					ignoreCase: false,
 					want:       "\"ignore-errors\"",
 				},
 			},
 		},
+		{
+			name: "CRITICAL_FLAG",
+			pos:  position{line: 208, col: 1, offset: 4549},
+			expr: &actionExpr{
+				pos: position{line: 208, col: 18, offset: 4566},
+				run: (*parser).callonCRITICAL_FLAG1,
+				expr: &litMatcher{
+					pos:        position{line: 208, col: 18, offset: 4566},
+					val:        "critical",
+					ignoreCase: false,
+					want:       "\"critical\"",
+				},
+			},
+		},
+		{
+			name: "OPTIONAL_FLAG",
+			pos:  position{line: 212, col: 1, offset: 4613},
+			expr: &actionExpr{
+				pos: position{line: 212, col: 18, offset: 4630},
+				run: (*parser).callonOPTIONAL_FLAG1,
+				expr: &litMatcher{
+					pos:        position{line: 212, col: 18, offset: 4630},
+					val:        "optional",
+					ignoreCase: false,
+					want:       "\"optional\"",
+				},
+			},
+		},
 		{
 			name: "CHAIN",
-			pos:  position{line: 184, col: 1, offset: 3885},
+			pos:  position{line: 216, col: 1, offset: 4677},
 			expr: &actionExpr{
-				pos: position{line: 184, col: 10, offset: 3894},
+				pos: position{line: 216, col: 10, offset: 4686},
 				run: (*parser).callonCHAIN1,
 				expr: &seqExpr{
-					pos: position{line: 184, col: 10, offset: 3894},
+					pos: position{line: 216, col: 10, offset: 4686},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 184, col: 10, offset: 3894},
+							pos:   position{line: 216, col: 10, offset: 4686},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 184, col: 13, offset: 3897},
+								pos:  position{line: 216, col: 13, offset: 4689},
 								name: "CHAINED_ITEM",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 184, col: 27, offset: 3911},
+							pos:   position{line: 216, col: 27, offset: 4703},
 							label: "ii",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 184, col: 30, offset: 3914},
+								pos: position{line: 216, col: 30, offset: 4706},
 								expr: &seqExpr{
-									pos: position{line: 184, col: 31, offset: 3915},
+									pos: position{line: 216, col: 31, offset: 4707},
 									exprs: []interface{}{
 										&zeroOrOneExpr{
-											pos: position{line: 184, col: 31, offset: 3915},
+											pos: position{line: 216, col: 31, offset: 4707},
 											expr: &litMatcher{
-												pos:        position{line: 184, col: 31, offset: 3915},
+												pos:        position{line: 216, col: 31, offset: 4707},
 												val:        ".",
 												ignoreCase: false,
 												want:       "\".\"",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 184, col: 36, offset: 3920},
+											pos:  position{line: 216, col: 36, offset: 4712},
 											name: "CHAINED_ITEM",
 										},
 									},
@@ -2108,22 +2415,22 @@ var g = &grammar{
 		},
 		{
 			name: "CHAINED_ITEM",
-			pos:  position{line: 188, col: 1, offset: 3964},
+			pos:  position{line: 220, col: 1, offset: 4756},
 			expr: &actionExpr{
-				pos: position{line: 188, col: 17, offset: 3980},
+				pos: position{line: 220, col: 17, offset: 4772},
 				run: (*parser).callonCHAINED_ITEM1,
 				expr: &labeledExpr{
-					pos:   position{line: 188, col: 17, offset: 3980},
+					pos:   position{line: 220, col: 17, offset: 4772},
 					label: "ci",
 					expr: &choiceExpr{
-						pos: position{line: 188, col: 21, offset: 3984},
+						pos: position{line: 220, col: 21, offset: 4776},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 188, col: 21, offset: 3984},
+								pos:  position{line: 220, col: 21, offset: 4776},
 								name: "PATH_VARIABLE",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 188, col: 37, offset: 4000},
+								pos:  position{line: 220, col: 37, offset: 4792},
 								name: "IDENT",
 							},
 						},
@@ -2133,40 +2440,40 @@ var g = &grammar{
 		},
 		{
 			name: "PATH_VARIABLE",
-			pos:  position{line: 192, col: 1, offset: 4035},
+			pos:  position{line: 224, col: 1, offset: 4827},
 			expr: &actionExpr{
-				pos: position{line: 192, col: 18, offset: 4052},
+				pos: position{line: 224, col: 18, offset: 4844},
 				run: (*parser).callonPATH_VARIABLE1,
 				expr: &seqExpr{
-					pos: position{line: 192, col: 18, offset: 4052},
+					pos: position{line: 224, col: 18, offset: 4844},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 192, col: 18, offset: 4052},
+							pos: position{line: 224, col: 18, offset: 4844},
 							expr: &litMatcher{
-								pos:        position{line: 192, col: 18, offset: 4052},
+								pos:        position{line: 224, col: 18, offset: 4844},
 								val:        "[",
 								ignoreCase: false,
 								want:       "\"[\"",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 192, col: 23, offset: 4057},
+							pos:        position{line: 224, col: 23, offset: 4849},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 192, col: 27, offset: 4061},
+							pos:   position{line: 224, col: 27, offset: 4853},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 192, col: 30, offset: 4064},
+								pos:  position{line: 224, col: 30, offset: 4856},
 								name: "IDENT",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 192, col: 37, offset: 4071},
+							pos: position{line: 224, col: 37, offset: 4863},
 							expr: &litMatcher{
-								pos:        position{line: 192, col: 37, offset: 4071},
+								pos:        position{line: 224, col: 37, offset: 4863},
 								val:        "]",
 								ignoreCase: false,
 								want:       "\"]\"",
@@ -2178,24 +2485,24 @@ var g = &grammar{
 		},
 		{
 			name: "VARIABLE",
-			pos:  position{line: 196, col: 1, offset: 4113},
+			pos:  position{line: 228, col: 1, offset: 4905},
 			expr: &actionExpr{
-				pos: position{line: 196, col: 13, offset: 4125},
+				pos: position{line: 228, col: 13, offset: 4917},
 				run: (*parser).callonVARIABLE1,
 				expr: &seqExpr{
-					pos: position{line: 196, col: 13, offset: 4125},
+					pos: position{line: 228, col: 13, offset: 4917},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 196, col: 13, offset: 4125},
+							pos:        position{line: 228, col: 13, offset: 4917},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 196, col: 17, offset: 4129},
+							pos:   position{line: 228, col: 17, offset: 4921},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 196, col: 20, offset: 4132},
+								pos:  position{line: 228, col: 20, offset: 4924},
 								name: "IDENT_WITH_DOT",
 							},
 						},
@@ -2205,14 +2512,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT",
-			pos:  position{line: 200, col: 1, offset: 4176},
+			pos:  position{line: 232, col: 1, offset: 4968},
 			expr: &actionExpr{
-				pos: position{line: 200, col: 10, offset: 4185},
+				pos: position{line: 232, col: 10, offset: 4977},
 				run: (*parser).callonIDENT1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 200, col: 10, offset: 4185},
+					pos: position{line: 232, col: 10, offset: 4977},
 					expr: &charClassMatcher{
-						pos:        position{line: 200, col: 10, offset: 4185},
+						pos:        position{line: 232, col: 10, offset: 4977},
 						val:        "[A-Za-z0-9:_-]",
 						chars:      []rune{':', '_', '-'},
 						ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
@@ -2224,14 +2531,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT_WITHOUT_COLLON",
-			pos:  position{line: 204, col: 1, offset: 4232},
+			pos:  position{line: 236, col: 1, offset: 5024},
 			expr: &actionExpr{
-				pos: position{line: 204, col: 25, offset: 4256},
+				pos: position{line: 236, col: 25, offset: 5048},
 				run: (*parser).callonIDENT_WITHOUT_COLLON1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 204, col: 25, offset: 4256},
+					pos: position{line: 236, col: 25, offset: 5048},
 					expr: &charClassMatcher{
-						pos:        position{line: 204, col: 25, offset: 4256},
+						pos:        position{line: 236, col: 25, offset: 5048},
 						val:        "[A-Za-z0-9_-]",
 						chars:      []rune{'_', '-'},
 						ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
@@ -2243,14 +2550,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT_WITH_DOT",
-			pos:  position{line: 208, col: 1, offset: 4302},
+			pos:  position{line: 240, col: 1, offset: 5094},
 			expr: &actionExpr{
-				pos: position{line: 208, col: 19, offset: 4320},
+				pos: position{line: 240, col: 19, offset: 5112},
 				run: (*parser).callonIDENT_WITH_DOT1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 208, col: 19, offset: 4320},
+					pos: position{line: 240, col: 19, offset: 5112},
 					expr: &charClassMatcher{
-						pos:        position{line: 208, col: 19, offset: 4320},
+						pos:        position{line: 240, col: 19, offset: 5112},
 						val:        "[a-zA-Z0-9-:_.]",
 						chars:      []rune{'-', ':', '_', '.'},
 						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -2262,12 +2569,12 @@ var g = &grammar{
 		},
 		{
 			name: "Null",
-			pos:  position{line: 212, col: 1, offset: 4368},
+			pos:  position{line: 244, col: 1, offset: 5160},
 			expr: &actionExpr{
-				pos: position{line: 212, col: 9, offset: 4376},
+				pos: position{line: 244, col: 9, offset: 5168},
 				run: (*parser).callonNull1,
 				expr: &litMatcher{
-					pos:        position{line: 212, col: 9, offset: 4376},
+					pos:        position{line: 244, col: 9, offset: 5168},
 					val:        "null",
 					ignoreCase: false,
 					want:       "\"null\"",
@@ -2276,21 +2583,21 @@ var g = &grammar{
 		},
 		{
 			name: "Boolean",
-			pos:  position{line: 216, col: 1, offset: 4406},
+			pos:  position{line: 248, col: 1, offset: 5198},
 			expr: &actionExpr{
-				pos: position{line: 216, col: 12, offset: 4417},
+				pos: position{line: 248, col: 12, offset: 5209},
 				run: (*parser).callonBoolean1,
 				expr: &choiceExpr{
-					pos: position{line: 216, col: 13, offset: 4418},
+					pos: position{line: 248, col: 13, offset: 5210},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 216, col: 13, offset: 4418},
+							pos:        position{line: 248, col: 13, offset: 5210},
 							val:        "true",
 							ignoreCase: false,
 							want:       "\"true\"",
 						},
 						&litMatcher{
-							pos:        position{line: 216, col: 22, offset: 4427},
+							pos:        position{line: 248, col: 22, offset: 5219},
 							val:        "false",
 							ignoreCase: false,
 							want:       "\"false\"",
@@ -2301,41 +2608,41 @@ var g = &grammar{
 		},
 		{
 			name: "String",
-			pos:  position{line: 220, col: 1, offset: 4468},
+			pos:  position{line: 252, col: 1, offset: 5260},
 			expr: &actionExpr{
-				pos: position{line: 220, col: 11, offset: 4478},
+				pos: position{line: 252, col: 11, offset: 5270},
 				run: (*parser).callonString1,
 				expr: &seqExpr{
-					pos: position{line: 220, col: 11, offset: 4478},
+					pos: position{line: 252, col: 11, offset: 5270},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 220, col: 11, offset: 4478},
+							pos:        position{line: 252, col: 11, offset: 5270},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 220, col: 15, offset: 4482},
+							pos: position{line: 252, col: 15, offset: 5274},
 							expr: &seqExpr{
-								pos: position{line: 220, col: 17, offset: 4484},
+								pos: position{line: 252, col: 17, offset: 5276},
 								exprs: []interface{}{
 									&notExpr{
-										pos: position{line: 220, col: 17, offset: 4484},
+										pos: position{line: 252, col: 17, offset: 5276},
 										expr: &litMatcher{
-											pos:        position{line: 220, col: 18, offset: 4485},
+											pos:        position{line: 252, col: 18, offset: 5277},
 											val:        "\"",
 											ignoreCase: false,
 											want:       "\"\\\"\"",
 										},
 									},
 									&anyMatcher{
-										line: 220, col: 22, offset: 4489,
+										line: 252, col: 22, offset: 5281,
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 220, col: 27, offset: 4494},
+							pos:        position{line: 252, col: 27, offset: 5286},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
@@ -2346,26 +2653,26 @@ var g = &grammar{
 		},
 		{
 			name: "Float",
-			pos:  position{line: 224, col: 1, offset: 4529},
+			pos:  position{line: 256, col: 1, offset: 5321},
 			expr: &actionExpr{
-				pos: position{line: 224, col: 10, offset: 4538},
+				pos: position{line: 256, col: 10, offset: 5330},
 				run: (*parser).callonFloat1,
 				expr: &seqExpr{
-					pos: position{line: 224, col: 10, offset: 4538},
+					pos: position{line: 256, col: 10, offset: 5330},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 224, col: 10, offset: 4538},
+							pos: position{line: 256, col: 10, offset: 5330},
 							expr: &choiceExpr{
-								pos: position{line: 224, col: 11, offset: 4539},
+								pos: position{line: 256, col: 11, offset: 5331},
 								alternatives: []interface{}{
 									&litMatcher{
-										pos:        position{line: 224, col: 11, offset: 4539},
+										pos:        position{line: 256, col: 11, offset: 5331},
 										val:        "+",
 										ignoreCase: false,
 										want:       "\"+\"",
 									},
 									&litMatcher{
-										pos:        position{line: 224, col: 17, offset: 4545},
+										pos:        position{line: 256, col: 17, offset: 5337},
 										val:        "-",
 										ignoreCase: false,
 										want:       "\"-\"",
@@ -2374,17 +2681,17 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 224, col: 23, offset: 4551},
+							pos:  position{line: 256, col: 23, offset: 5343},
 							name: "Natural",
 						},
 						&litMatcher{
-							pos:        position{line: 224, col: 31, offset: 4559},
+							pos:        position{line: 256, col: 31, offset: 5351},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 224, col: 35, offset: 4563},
+							pos:  position{line: 256, col: 35, offset: 5355},
 							name: "Natural",
 						},
 					},
@@ -2393,26 +2700,26 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 228, col: 1, offset: 4601},
+			pos:  position{line: 260, col: 1, offset: 5393},
 			expr: &actionExpr{
-				pos: position{line: 228, col: 12, offset: 4612},
+				pos: position{line: 260, col: 12, offset: 5404},
 				run: (*parser).callonInteger1,
 				expr: &seqExpr{
-					pos: position{line: 228, col: 12, offset: 4612},
+					pos: position{line: 260, col: 12, offset: 5404},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 228, col: 12, offset: 4612},
+							pos: position{line: 260, col: 12, offset: 5404},
 							expr: &choiceExpr{
-								pos: position{line: 228, col: 13, offset: 4613},
+								pos: position{line: 260, col: 13, offset: 5405},
 								alternatives: []interface{}{
 									&litMatcher{
-										pos:        position{line: 228, col: 13, offset: 4613},
+										pos:        position{line: 260, col: 13, offset: 5405},
 										val:        "+",
 										ignoreCase: false,
 										want:       "\"+\"",
 									},
 									&litMatcher{
-										pos:        position{line: 228, col: 19, offset: 4619},
+										pos:        position{line: 260, col: 19, offset: 5411},
 										val:        "-",
 										ignoreCase: false,
 										want:       "\"-\"",
@@ -2421,7 +2728,7 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 228, col: 25, offset: 4625},
+							pos:  position{line: 260, col: 25, offset: 5417},
 							name: "Natural",
 						},
 					},
@@ -2430,27 +2737,27 @@ var g = &grammar{
 		},
 		{
 			name: "Natural",
-			pos:  position{line: 232, col: 1, offset: 4665},
+			pos:  position{line: 264, col: 1, offset: 5457},
 			expr: &choiceExpr{
-				pos: position{line: 232, col: 11, offset: 4677},
+				pos: position{line: 264, col: 11, offset: 5469},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 232, col: 11, offset: 4677},
+						pos:        position{line: 264, col: 11, offset: 5469},
 						val:        "0",
 						ignoreCase: false,
 						want:       "\"0\"",
 					},
 					&seqExpr{
-						pos: position{line: 232, col: 17, offset: 4683},
+						pos: position{line: 264, col: 17, offset: 5475},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 232, col: 17, offset: 4683},
+								pos:  position{line: 264, col: 17, offset: 5475},
 								name: "NonZeroDecimalDigit",
 							},
 							&zeroOrMoreExpr{
-								pos: position{line: 232, col: 37, offset: 4703},
+								pos: position{line: 264, col: 37, offset: 5495},
 								expr: &ruleRefExpr{
-									pos:  position{line: 232, col: 37, offset: 4703},
+									pos:  position{line: 264, col: 37, offset: 5495},
 									name: "DecimalDigit",
 								},
 							},
@@ -2461,9 +2768,9 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalDigit",
-			pos:  position{line: 234, col: 1, offset: 4718},
+			pos:  position{line: 266, col: 1, offset: 5510},
 			expr: &charClassMatcher{
-				pos:        position{line: 234, col: 16, offset: 4735},
+				pos:        position{line: 266, col: 16, offset: 5527},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -2472,9 +2779,9 @@ var g = &grammar{
 		},
 		{
 			name: "NonZeroDecimalDigit",
-			pos:  position{line: 235, col: 1, offset: 4741},
+			pos:  position{line: 267, col: 1, offset: 5533},
 			expr: &charClassMatcher{
-				pos:        position{line: 235, col: 23, offset: 4765},
+				pos:        position{line: 267, col: 23, offset: 5557},
 				val:        "[1-9]",
 				ranges:     []rune{'1', '9'},
 				ignoreCase: false,
@@ -2483,9 +2790,9 @@ var g = &grammar{
 		},
 		{
 			name: "SPACE",
-			pos:  position{line: 237, col: 1, offset: 4772},
+			pos:  position{line: 269, col: 1, offset: 5564},
 			expr: &charClassMatcher{
-				pos:        position{line: 237, col: 10, offset: 4781},
+				pos:        position{line: 269, col: 10, offset: 5573},
 				val:        "[ \\t]",
 				chars:      []rune{' ', '\t'},
 				ignoreCase: false,
@@ -2495,22 +2802,22 @@ var g = &grammar{
 		{
 			name:        "WS_MAND",
 			displayName: "\"mandatory-whitespace\"",
-			pos:         position{line: 238, col: 1, offset: 4787},
+			pos:         position{line: 270, col: 1, offset: 5579},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 238, col: 35, offset: 4821},
+				pos: position{line: 270, col: 35, offset: 5613},
 				expr: &choiceExpr{
-					pos: position{line: 238, col: 36, offset: 4822},
+					pos: position{line: 270, col: 36, offset: 5614},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 36, offset: 4822},
+							pos:  position{line: 270, col: 36, offset: 5614},
 							name: "SPACE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 44, offset: 4830},
+							pos:  position{line: 270, col: 44, offset: 5622},
 							name: "COMMENT",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 54, offset: 4840},
+							pos:  position{line: 270, col: 54, offset: 5632},
 							name: "NL",
 						},
 					},
@@ -2520,18 +2827,18 @@ var g = &grammar{
 		{
 			name:        "WS",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 239, col: 1, offset: 4845},
+			pos:         position{line: 271, col: 1, offset: 5637},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 239, col: 20, offset: 4864},
+				pos: position{line: 271, col: 20, offset: 5656},
 				expr: &choiceExpr{
-					pos: position{line: 239, col: 21, offset: 4865},
+					pos: position{line: 271, col: 21, offset: 5657},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 239, col: 21, offset: 4865},
+							pos:  position{line: 271, col: 21, offset: 5657},
 							name: "SPACE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 239, col: 29, offset: 4873},
+							pos:  position{line: 271, col: 29, offset: 5665},
 							name: "COMMENT",
 						},
 					},
@@ -2541,22 +2848,22 @@ var g = &grammar{
 		{
 			name:        "LS",
 			displayName: "\"line-separator\"",
-			pos:         position{line: 240, col: 1, offset: 4883},
+			pos:         position{line: 272, col: 1, offset: 5675},
 			expr: &choiceExpr{
-				pos: position{line: 240, col: 25, offset: 4907},
+				pos: position{line: 272, col: 25, offset: 5699},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 240, col: 25, offset: 4907},
+						pos:  position{line: 272, col: 25, offset: 5699},
 						name: "NL",
 					},
 					&litMatcher{
-						pos:        position{line: 240, col: 30, offset: 4912},
+						pos:        position{line: 272, col: 30, offset: 5704},
 						val:        ",",
 						ignoreCase: false,
 						want:       "\",\"",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 240, col: 36, offset: 4918},
+						pos:  position{line: 272, col: 36, offset: 5710},
 						name: "COMMENT",
 					},
 				},
@@ -2565,31 +2872,31 @@ var g = &grammar{
 		{
 			name:        "BS",
 			displayName: "\"block-separator\"",
-			pos:         position{line: 241, col: 1, offset: 4927},
+			pos:         position{line: 273, col: 1, offset: 5719},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 241, col: 25, offset: 4951},
+				pos: position{line: 273, col: 25, offset: 5743},
 				expr: &seqExpr{
-					pos: position{line: 241, col: 26, offset: 4952},
+					pos: position{line: 273, col: 26, offset: 5744},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 241, col: 26, offset: 4952},
+							pos:  position{line: 273, col: 26, offset: 5744},
 							name: "WS",
 						},
 						&choiceExpr{
-							pos: position{line: 241, col: 30, offset: 4956},
+							pos: position{line: 273, col: 30, offset: 5748},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 241, col: 30, offset: 4956},
+									pos:  position{line: 273, col: 30, offset: 5748},
 									name: "NL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 241, col: 35, offset: 4961},
+									pos:  position{line: 273, col: 35, offset: 5753},
 									name: "COMMENT",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 241, col: 44, offset: 4970},
+							pos:  position{line: 273, col: 44, offset: 5762},
 							name: "WS",
 						},
 					},
@@ -2599,9 +2906,9 @@ var g = &grammar{
 		{
 			name:        "NL",
 			displayName: "\"new-line\"",
-			pos:         position{line: 242, col: 1, offset: 4975},
+			pos:         position{line: 274, col: 1, offset: 5767},
 			expr: &litMatcher{
-				pos:        position{line: 242, col: 18, offset: 4992},
+				pos:        position{line: 274, col: 18, offset: 5784},
 				val:        "\n",
 				ignoreCase: false,
 				want:       "\"\\n\"",
@@ -2609,47 +2916,47 @@ var g = &grammar{
 		},
 		{
 			name: "COMMENT",
-			pos:  position{line: 244, col: 1, offset: 4998},
+			pos:  position{line: 276, col: 1, offset: 5790},
 			expr: &seqExpr{
-				pos: position{line: 244, col: 12, offset: 5009},
+				pos: position{line: 276, col: 12, offset: 5801},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 244, col: 12, offset: 5009},
+						pos:        position{line: 276, col: 12, offset: 5801},
 						val:        "//",
 						ignoreCase: false,
 						want:       "\"//\"",
 					},
 					&zeroOrMoreExpr{
-						pos: position{line: 244, col: 17, offset: 5014},
+						pos: position{line: 276, col: 17, offset: 5806},
 						expr: &seqExpr{
-							pos: position{line: 244, col: 19, offset: 5016},
+							pos: position{line: 276, col: 19, offset: 5808},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 244, col: 19, offset: 5016},
+									pos: position{line: 276, col: 19, offset: 5808},
 									expr: &litMatcher{
-										pos:        position{line: 244, col: 20, offset: 5017},
+										pos:        position{line: 276, col: 20, offset: 5809},
 										val:        "\n",
 										ignoreCase: false,
 										want:       "\"\\n\"",
 									},
 								},
 								&anyMatcher{
-									line: 244, col: 25, offset: 5022,
+									line: 276, col: 25, offset: 5814,
 								},
 							},
 						},
 					},
 					&choiceExpr{
-						pos: position{line: 244, col: 31, offset: 5028},
+						pos: position{line: 276, col: 31, offset: 5820},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 244, col: 31, offset: 5028},
+								pos:        position{line: 276, col: 31, offset: 5820},
 								val:        "\n",
 								ignoreCase: false,
 								want:       "\"\\n\"",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 244, col: 38, offset: 5035},
+								pos:  position{line: 276, col: 38, offset: 5827},
 								name: "EOF",
 							},
 						},
@@ -2659,11 +2966,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 246, col: 1, offset: 5041},
+			pos:  position{line: 278, col: 1, offset: 5833},
 			expr: &notExpr{
-				pos: position{line: 246, col: 8, offset: 5048},
+				pos: position{line: 278, col: 8, offset: 5840},
 				expr: &anyMatcher{
-					line: 246, col: 9, offset: 5049,
+					line: 278, col: 9, offset: 5841,
 				},
 			},
 		},
@@ -2830,6 +3137,56 @@ func (p *parser) callonFUNCTION1() (interface{}, error) {
 	return p.cur.onFUNCTION1()
 }
 
+func (c *current) onFUNCTION_CALL1(name, args interface{}) (interface{}, error) {
+	return newFunctionCall(name, args)
+}
+
+func (p *parser) callonFUNCTION_CALL1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFUNCTION_CALL1(stack["name"], stack["args"])
+}
+
+func (c *current) onFUNCTION_NAME1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonFUNCTION_NAME1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFUNCTION_NAME1()
+}
+
+func (c *current) onFUNCTION_ARGS1(args interface{}) (interface{}, error) {
+	return newFunctionArgs(args)
+}
+
+func (p *parser) callonFUNCTION_ARGS1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFUNCTION_ARGS1(stack["args"])
+}
+
+func (c *current) onFUNCTION_ARG_LIST1(first, others interface{}) (interface{}, error) {
+	return newFunctionArgList(first, others)
+}
+
+func (p *parser) callonFUNCTION_ARG_LIST1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFUNCTION_ARG_LIST1(stack["first"], stack["others"])
+}
+
+func (c *current) onFUNCTION_ARG1(v interface{}) (interface{}, error) {
+	return newFunctionArg(v)
+}
+
+func (p *parser) callonFUNCTION_ARG1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFUNCTION_ARG1(stack["v"])
+}
+
 func (c *current) onVALUE1(v interface{}) (interface{}, error) {
 	return newValue(v)
 }
@@ -3080,6 +3437,26 @@ func (p *parser) callonIGNORE_FLAG1() (interface{}, error) {
 	return p.cur.onIGNORE_FLAG1()
 }
 
+func (c *current) onCRITICAL_FLAG1() (interface{}, error) {
+	return newCriticality(c.text)
+}
+
+func (p *parser) callonCRITICAL_FLAG1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCRITICAL_FLAG1()
+}
+
+func (c *current) onOPTIONAL_FLAG1() (interface{}, error) {
+	return newCriticality(c.text)
+}
+
+func (p *parser) callonOPTIONAL_FLAG1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onOPTIONAL_FLAG1()
+}
+
 func (c *current) onCHAIN1(i, ii interface{}) (interface{}, error) {
 	return newChain(i, ii)
 }
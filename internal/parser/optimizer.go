@@ -1,17 +1,35 @@
 package parser
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser/ast"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/pkg/errors"
 )
 
-// Optimize transforms a restQL AST into the internal representation.
-func Optimize(queryAst *ast.Query) (domain.Query, error) {
-	statements, err := mapToStatements(queryAst.Blocks)
+// ErrUnknownFunction is returned when the query applies a
+// function that is neither built-in nor provided by plugins.
+var ErrUnknownFunction = errors.New("unknown function")
+
+// ErrUnknownStatusPolicy is returned when the query uses
+// a status policy that is not supported.
+var ErrUnknownStatusPolicy = errors.New("unknown status policy")
+
+// statusPolicies are the values accepted by `use status-policy`.
+var statusPolicies = map[string]bool{
+	"max-of-required": true,
+	"first-failure":   true,
+	"always-200":      true,
+}
+
+// Optimize transforms a restQL AST into the internal representation,
+// binding the applied functions to the ones provided by plugins.
+func Optimize(queryAst *ast.Query, functions map[string]restql.Function) (domain.Query, error) {
+	statements, err := mapToStatements(queryAst.Blocks, functions)
 	if err != nil {
 		return domain.Query{}, err
 	}
@@ -19,13 +37,18 @@ func Optimize(queryAst *ast.Query) (domain.Query, error) {
 	query := domain.Query{Statements: statements}
 
 	if queryAst.Use != nil {
-		query.Use = makeUse(queryAst)
+		use, err := makeUse(queryAst)
+		if err != nil {
+			return domain.Query{}, err
+		}
+
+		query.Use = use
 	}
 
 	return query, nil
 }
 
-func makeUse(queryAst *ast.Query) map[string]interface{} {
+func makeUse(queryAst *ast.Query) (map[string]interface{}, error) {
 	result := map[string]interface{}{}
 	for _, use := range queryAst.Use {
 		key := strings.Trim(use.Key, " ")
@@ -35,16 +58,24 @@ func makeUse(queryAst *ast.Query) map[string]interface{} {
 			result[key] = *use.Value.Int
 		}
 	}
-	return result
+
+	if policy, found := result["status-policy"]; found {
+		name, ok := policy.(string)
+		if !ok || !statusPolicies[name] {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownStatusPolicy, policy)
+		}
+	}
+
+	return result, nil
 }
 
-func mapToStatements(fromBlocks []ast.Block) ([]domain.Statement, error) {
+func mapToStatements(fromBlocks []ast.Block, functions map[string]restql.Function) ([]domain.Statement, error) {
 	result := make([]domain.Statement, len(fromBlocks))
 
 	for i, block := range fromBlocks {
-		statement, err := makeStatement(block)
+		statement, err := makeStatement(block, functions)
 		if err != nil {
-			return nil, nil
+			return nil, err
 		}
 
 		result[i] = statement
@@ -53,7 +84,7 @@ func mapToStatements(fromBlocks []ast.Block) ([]domain.Statement, error) {
 	return result, nil
 }
 
-func makeStatement(block ast.Block) (domain.Statement, error) {
+func makeStatement(block ast.Block, functions map[string]restql.Function) (domain.Statement, error) {
 	s := domain.Statement{
 		Method:   strings.TrimSpace(block.Method),
 		Resource: block.Resource,
@@ -62,11 +93,16 @@ func makeStatement(block ast.Block) (domain.Statement, error) {
 	}
 	for _, qualifier := range block.Qualifiers {
 		if qualifier.With != nil {
-			s.With = makeParams(qualifier)
+			with, err := makeParams(qualifier, functions)
+			if err != nil {
+				return domain.Statement{}, err
+			}
+
+			s.With = with
 		}
 
 		if qualifier.Only != nil {
-			filter, err := makeOnlyFilter(qualifier)
+			filter, err := makeOnlyFilter(qualifier, functions)
 			if err != nil {
 				return domain.Statement{}, err
 			}
@@ -98,17 +134,24 @@ func makeStatement(block ast.Block) (domain.Statement, error) {
 
 		s.Hidden = qualifier.Hidden || s.Hidden
 		s.IgnoreErrors = qualifier.IgnoreErrors || s.IgnoreErrors
+
+		if qualifier.Criticality != "" {
+			s.Criticality = restql.Criticality(qualifier.Criticality)
+		}
 	}
 
 	return s, nil
 }
 
-func makeParams(wq ast.Qualifier) domain.Params {
+func makeParams(wq ast.Qualifier, functions map[string]restql.Function) (domain.Params, error) {
 	values := make(map[string]interface{})
 	for _, item := range wq.With.KeyValues {
 		v := getValue(item.Value)
 
-		v = applyFunctions(v, item.Functions)
+		v, err := applyFunctions(v, item.Functions, functions)
+		if err != nil {
+			return domain.Params{}, err
+		}
 
 		values[item.Key] = v
 	}
@@ -119,50 +162,69 @@ func makeParams(wq ast.Qualifier) domain.Params {
 
 	parameterBody := wq.With.Body
 	if parameterBody == nil {
-		return p
+		return p, nil
 	}
 
 	var body interface{}
 	body = domain.Variable{Target: parameterBody.Target}
 
-	body = applyFunctions(body, parameterBody.Functions)
+	body, err := applyFunctions(body, parameterBody.Functions, functions)
+	if err != nil {
+		return domain.Params{}, err
+	}
 
 	p.Body = body
 
-	return p
+	return p, nil
 }
 
-func applyFunctions(v interface{}, functions []string) interface{} {
-	for _, fn := range functions {
-		switch fn {
-		case ast.NoMultiplex:
-			v = domain.NoMultiplex{Value: v}
-		case ast.AsBody:
-			v = domain.AsBody{Value: v}
-		case ast.Base64:
-			v = domain.Base64{Value: v}
-		case ast.JSON:
-			v = domain.JSON{Value: v}
-		case ast.Flatten:
-			v = domain.Flatten{Value: v}
-		case ast.NoExplode:
-			v = domain.NoExplode{Value: v}
-		case ast.AsQuery:
-			v = domain.AsQuery{Value: v}
+func applyFunctions(v interface{}, fns []interface{}, functions map[string]restql.Function) (interface{}, error) {
+	for _, fn := range fns {
+		switch fn := fn.(type) {
+		case string:
+			v = applyBuiltInFunction(v, fn)
+		case ast.FunctionCall:
+			cf, err := makeCustomFunction(v, fn, functions)
+			if err != nil {
+				return nil, err
+			}
+
+			v = cf
 		}
 	}
 
-	return v
+	return v, nil
 }
 
-func makeOnlyFilter(onlyQualifier ast.Qualifier) ([]interface{}, error) {
+func applyBuiltInFunction(v interface{}, fn string) interface{} {
+	switch fn {
+	case ast.NoMultiplex:
+		return domain.NoMultiplex{Value: v}
+	case ast.AsBody:
+		return domain.AsBody{Value: v}
+	case ast.Base64:
+		return domain.Base64{Value: v}
+	case ast.JSON:
+		return domain.JSON{Value: v}
+	case ast.Flatten:
+		return domain.Flatten{Value: v}
+	case ast.NoExplode:
+		return domain.NoExplode{Value: v}
+	case ast.AsQuery:
+		return domain.AsQuery{Value: v}
+	default:
+		return v
+	}
+}
+
+func makeOnlyFilter(onlyQualifier ast.Qualifier, functions map[string]restql.Function) ([]interface{}, error) {
 	filters := onlyQualifier.Only
 
 	result := make([]interface{}, len(filters))
 	for i, f := range filters {
 		var filter interface{} = f.Field
 		for _, fn := range f.Functions {
-			filterWithFunc, err := applyFunctionToFilter(filter, fn)
+			filterWithFunc, err := applyFunctionToFilter(filter, fn, functions)
 			if err != nil {
 				return nil, err
 			}
@@ -176,17 +238,47 @@ func makeOnlyFilter(onlyQualifier ast.Qualifier) ([]interface{}, error) {
 	return result, nil
 }
 
-func applyFunctionToFilter(field, fn interface{}) (interface{}, error) {
+func applyFunctionToFilter(field, fn interface{}, functions map[string]restql.Function) (interface{}, error) {
 	switch fn := fn.(type) {
 	case ast.Match:
 		return makeMatchFunction(field, fn)
 	case ast.FilterByRegex:
 		return makeFilterByRegexFunction(field, fn)
+	case ast.FunctionCall:
+		return makeCustomFunction(field, fn, functions)
 	default:
 		return field, nil
 	}
 }
 
+// makeCustomFunction binds the function call to the plugin function
+// with the same name, validating the literal arguments types.
+// Variables arguments are validated when the function is applied.
+func makeCustomFunction(target interface{}, call ast.FunctionCall, functions map[string]restql.Function) (domain.Function, error) {
+	fn, found := functions[call.Name]
+	if !found {
+		return domain.CustomFunction{}, fmt.Errorf("%w: %s", ErrUnknownFunction, call.Name)
+	}
+
+	if len(call.Args) != len(fn.Params) {
+		return domain.CustomFunction{}, fmt.Errorf("%w: %s expects %d arguments but got %d", restql.ErrInvalidFunctionArgument, fn.Name, len(fn.Params), len(call.Args))
+	}
+
+	args := make([]domain.Arg, len(call.Args))
+	for i, param := range fn.Params {
+		value := getValue(call.Args[i])
+		if _, isVariable := value.(domain.Variable); !isVariable {
+			if _, err := param.Type.Cast(value); err != nil {
+				return domain.CustomFunction{}, fmt.Errorf("%s argument %s : %w", fn.Name, param.Name, err)
+			}
+		}
+
+		args[i] = domain.Arg{Name: param.Name, Value: value}
+	}
+
+	return domain.CustomFunction{Value: target, Fn: fn, Args: args}, nil
+}
+
 func makeFilterByRegexFunction(target interface{}, filterByRegexFn ast.FilterByRegex) (domain.Function, error) {
 	var fr domain.Function = domain.FilterByRegex{Value: target}
 
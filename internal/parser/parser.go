@@ -4,11 +4,24 @@ import (
 	"errors"
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser/ast"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 )
 
 // ErrInvalidQuery represents a given query that not comply with the restQL syntax
 var ErrInvalidQuery = errors.New("invalid query")
 
+// WrapSyntaxError annotates err with the position of the syntax error
+// found in cause, if any, allowing it to be retrieved with errors.As
+// when cause is only described in the message of err.
+func WrapSyntaxError(err error, cause error) error {
+	var syntaxErr *ast.SyntaxError
+	if !errors.As(cause, &syntaxErr) {
+		return err
+	}
+
+	return &ast.SyntaxError{Line: syntaxErr.Line, Column: syntaxErr.Column, Offset: syntaxErr.Offset, Err: err}
+}
+
 // Parser is the interface implemented by types that
 // can transform a query string into an internal representation.
 type Parser interface {
@@ -17,16 +30,24 @@ type Parser interface {
 
 type parser struct {
 	astGenerator ast.Generator
+	functions    map[string]restql.Function
 }
 
-// New returns an instance of a Parser.
-func New() (Parser, error) {
+// New returns an instance of a Parser that accepts
+// the given functions through the apply operator (->),
+// besides the built-in ones.
+func New(functions ...restql.Function) (Parser, error) {
 	generator, err := ast.New()
 	if err != nil {
 		return parser{}, err
 	}
 
-	return parser{astGenerator: generator}, nil
+	fns := make(map[string]restql.Function, len(functions))
+	for _, fn := range functions {
+		fns[fn.Name] = fn
+	}
+
+	return parser{astGenerator: generator, functions: fns}, nil
 }
 
 func (p parser) Parse(queryStr string) (domain.Query, error) {
@@ -35,5 +56,5 @@ func (p parser) Parse(queryStr string) (domain.Query, error) {
 		return domain.Query{}, err
 	}
 
-	return Optimize(query)
+	return Optimize(query, p.functions)
 }
@@ -1,11 +1,13 @@
 package parser_test
 
 import (
+	"errors"
 	"regexp"
 	"testing"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/b2wdigital/restQL-golang/v6/test"
 )
 
@@ -81,6 +83,16 @@ func TestQueryParser(t *testing.T) {
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", IgnoreErrors: true}}},
 			"from hero ignore-errors",
 		},
+		{
+			"Unique from statement and critical flag",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Criticality: restql.Critical}}},
+			"from hero critical",
+		},
+		{
+			"Unique from statement and optional flag with ignore errors",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", IgnoreErrors: true, Criticality: restql.Optional}}},
+			"from hero ignore-errors, optional",
+		},
 		{
 			"Unique from statement and fixed timeout",
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Timeout: 2000}}},
@@ -177,6 +189,30 @@ func TestQueryParser(t *testing.T) {
 					from sidekick in hero.sidekick
 			`,
 		},
+		{
+			"Query with response mode",
+			domain.Query{
+				Use:        map[string]interface{}{"response": "data"},
+				Statements: []domain.Statement{{Method: "from", Resource: "hero"}},
+			},
+			`use response = "data"
+			from hero`,
+		},
+		{
+			"Query with status policy",
+			domain.Query{
+				Use: map[string]interface{}{"status-policy": "first-failure"},
+				Statements: []domain.Statement{
+					{Method: "from", Resource: "hero", Criticality: restql.Critical},
+					{Method: "from", Resource: "recommendations", Criticality: restql.Optional},
+				},
+			},
+			`use status-policy = "first-failure"
+			from hero
+				critical
+			from recommendations
+				optional`,
+		},
 		{
 			"Full query",
 			domain.Query{
@@ -332,6 +368,117 @@ func TestQueryParser(t *testing.T) {
 	}
 }
 
+func TestQueryParserWithFunctions(t *testing.T) {
+	upper := restql.Function{Name: "upper"}
+	pad := restql.Function{Name: "pad", Params: []restql.FunctionParam{{Name: "size", Type: restql.IntArg}, {Name: "char", Type: restql.StringArg}}}
+	jsonify := restql.Function{Name: "jsonify"}
+
+	tests := []struct {
+		name     string
+		expected interface{}
+		query    string
+	}{
+		{
+			"Unique from statement with custom function on parameter",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"name": domain.CustomFunction{Value: domain.Variable{"name"}, Fn: upper, Args: []domain.Arg{}}}}}}},
+			`from hero with name = $name -> upper`,
+		},
+		{
+			"Unique from statement with custom function with arguments on parameter",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.CustomFunction{Value: domain.Variable{"id"}, Fn: pad, Args: []domain.Arg{{Name: "size", Value: 10}, {Name: "char", Value: "0"}}}}}}}},
+			`from hero with id = $id -> pad(10, "0")`,
+		},
+		{
+			"Unique from statement with custom function with variable argument on parameter",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.CustomFunction{Value: domain.Variable{"id"}, Fn: pad, Args: []domain.Arg{{Name: "size", Value: domain.Variable{"size"}}, {Name: "char", Value: "0"}}}}}}}},
+			`from hero with id = $id -> pad($size, "0")`,
+		},
+		{
+			"Unique from statement with custom and built-in functions on parameter",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"name": domain.Base64{Value: domain.CustomFunction{Value: domain.Variable{"name"}, Fn: upper, Args: []domain.Arg{}}}}}}}},
+			`from hero with name = $name -> upper -> base64`,
+		},
+		{
+			"Unique from statement with custom function prefixed by built-in function name",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.JSON{Value: domain.CustomFunction{Value: domain.Variable{"id"}, Fn: jsonify, Args: []domain.Arg{}}}}}}}},
+			`from hero with id = $id -> jsonify -> json`,
+		},
+		{
+			"Unique from statement with custom function on only filter",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Only: []interface{}{domain.CustomFunction{Value: []string{"name"}, Fn: upper, Args: []domain.Arg{}}, []string{"weapons"}}}}},
+			`from hero only name -> upper, weapons`,
+		},
+	}
+
+	queryParser, err := parser.New(upper, pad, jsonify)
+	test.VerifyError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := queryParser.Parse(tt.query)
+
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestQueryParserWithInvalidFunctions(t *testing.T) {
+	pad := restql.Function{Name: "pad", Params: []restql.FunctionParam{{Name: "size", Type: restql.IntArg}}}
+
+	tests := []struct {
+		name     string
+		expected error
+		query    string
+	}{
+		{
+			"Unknown function on parameter",
+			parser.ErrUnknownFunction,
+			`from hero with name = $name -> upper`,
+		},
+		{
+			"Unknown function on only filter",
+			parser.ErrUnknownFunction,
+			`from hero only name -> upper`,
+		},
+		{
+			"Function with missing arguments",
+			restql.ErrInvalidFunctionArgument,
+			`from hero with id = $id -> pad`,
+		},
+		{
+			"Function with argument of wrong type",
+			restql.ErrInvalidFunctionArgument,
+			`from hero with id = $id -> pad("ten")`,
+		},
+		{
+			"Unknown status policy",
+			parser.ErrUnknownStatusPolicy,
+			`use status-policy = "first-success"
+			from hero`,
+		},
+		{
+			"Status policy with integer value",
+			parser.ErrUnknownStatusPolicy,
+			`use status-policy = 200
+			from hero`,
+		},
+	}
+
+	queryParser, err := parser.New(pad)
+	test.VerifyError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := queryParser.Parse(tt.query)
+
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 	query := `
 from hero as h
@@ -120,6 +120,16 @@ func (c *Cache) Get(ctx context.Context, key interface{}) (interface{}, error) {
 	return item.value, nil
 }
 
+// RemoveIf deletes the entries whose key matches the predicate,
+// forcing them to be loaded again on the next Get.
+func (c *Cache) RemoveIf(match func(key interface{}) bool) {
+	for _, key := range c.gcache.Keys(false) {
+		if match(key) {
+			c.gcache.Remove(key)
+		}
+	}
+}
+
 func (c *Cache) populate(ctx context.Context, key interface{}) (cacheItem, error) {
 	value, err := c.loader(ctx, key)
 	if err != nil {
@@ -39,6 +39,14 @@ func (c *MappingsReaderCache) FromTenant(ctx context.Context, tenant string) (ma
 	return mappings, nil
 }
 
+// Invalidate removes the cached mappings of the tenant,
+// which are fetched again on the next FromTenant.
+func (c *MappingsReaderCache) Invalidate(tenant string) {
+	c.cache.RemoveIf(func(key interface{}) bool {
+		return key == tenant
+	})
+}
+
 // TenantCacheLoader is the strategy to load
 // values for the cached mappings reader.
 func TenantCacheLoader(mr persistence.MappingsReader) Loader {
@@ -95,6 +103,24 @@ func (c *QueryReaderCache) Get(ctx context.Context, namespace, id string, revisi
 	return query, nil
 }
 
+// Invalidate removes all cached revisions of the query,
+// which are fetched again on the next Get.
+func (c *QueryReaderCache) Invalidate(namespace, id string) {
+	c.cache.RemoveIf(func(key interface{}) bool {
+		cacheKey, ok := key.(cacheQueryKey)
+		return ok && cacheKey.namespace == namespace && cacheKey.id == id
+	})
+}
+
+// InvalidateNamespace removes all cached revisions of
+// the queries in the namespace.
+func (c *QueryReaderCache) InvalidateNamespace(namespace string) {
+	c.cache.RemoveIf(func(key interface{}) bool {
+		cacheKey, ok := key.(cacheQueryKey)
+		return ok && cacheKey.namespace == namespace
+	})
+}
+
 // QueryCacheLoader is the strategy to load
 // values for the cached query reader.
 func QueryCacheLoader(qr persistence.QueryReader) Loader {
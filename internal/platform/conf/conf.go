@@ -1,6 +1,8 @@
 package conf
 
 import (
+	"fmt"
+
 	"github.com/caarlos0/env/v6"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
@@ -19,6 +21,14 @@ type requestIDConf struct {
 	Strategy string `yaml:"strategy"`
 }
 
+// AdminKey is a named credential for the administrative API, with the
+// scopes it is granted, like `namespace:checkout:write`.
+type AdminKey struct {
+	Name   string   `yaml:"name"`
+	Key    string   `yaml:"key"`
+	Scopes []string `yaml:"scopes"`
+}
+
 type timeoutConf struct {
 	Enable   bool   `yaml:"enable"`
 	Duration string `yaml:"duration"`
@@ -43,21 +53,30 @@ type requestCancellationConf struct {
 type Config struct {
 	HTTP struct {
 		ForwardPrefix        string        `yaml:"forwardPrefix" env:"RESTQL_FORWARD_PREFIX"`
-		QueryResourceTimeout time.Duration `env:"RESTQL_QUERY_RESOURCE_TIMEOUT" envDefault:"5s"`
+		QueryResourceTimeout time.Duration `yaml:"queryResourceTimeout" env:"RESTQL_QUERY_RESOURCE_TIMEOUT"`
 
-		GlobalQueryTimeout time.Duration `env:"RESTQL_QUERY_GLOBAL_TIMEOUT" envDefault:"30s"`
+		GlobalQueryTimeout time.Duration `yaml:"globalQueryTimeout" env:"RESTQL_QUERY_GLOBAL_TIMEOUT"`
 
 		Server struct {
-			APIAddr         string `env:"RESTQL_PORT,required"`
-			APIHealthAddr   string `env:"RESTQL_HEALTH_PORT,required"`
+			APIAddr         string `env:"RESTQL_PORT"`
+			APIHealthAddr   string `env:"RESTQL_HEALTH_PORT"`
 			PropfAddr       string `env:"RESTQL_PPROF_PORT"`
 			EnablePprof     bool   `env:"RESTQL_ENABLE_PPROF"`
 			EnableFullPprof bool   `env:"RESTQL_ENABLE_FULL_PPROF"`
 			Admin           struct {
-				Enable            bool   `yaml:"enable" env:"RESTQL_ADMIN_ENABLE"`
-				AuthorizationCode string `yaml:"authorizationCode" env:"RESTQL_ADMIN_AUTHORIZATION_CODE"`
+				Enable            bool       `yaml:"enable" env:"RESTQL_ADMIN_ENABLE"`
+				AuthorizationCode string     `yaml:"authorizationCode" env:"RESTQL_ADMIN_AUTHORIZATION_CODE"`
+				Keys              []AdminKey `yaml:"keys"`
+
+				Audit struct {
+					File string `yaml:"file" env:"RESTQL_ADMIN_AUDIT_FILE"`
+				} `yaml:"audit"`
 			} `yaml:"admin"`
 
+			ProblemDetails struct {
+				Enable bool `yaml:"enable" env:"RESTQL_PROBLEM_DETAILS_ENABLE"`
+			} `yaml:"problemDetails"`
+
 			GracefulShutdownTimeout time.Duration `yaml:"gracefulShutdownTimeout"`
 			ReadTimeout             time.Duration `yaml:"readTimeout"`
 			IdleTimeout             time.Duration `yaml:"idleTimeout"`
@@ -81,6 +100,17 @@ type Config struct {
 			MaxIdleConns        int           `yaml:"maxIdleConnections"`
 			MaxIdleConnsPerHost int           `yaml:"maxIdleConnectionsPerHost"`
 			MaxIdleConnDuration time.Duration `yaml:"maxIdleConnectionDuration"`
+
+			GRPC struct {
+				DescriptorSets []string `yaml:"descriptorSets" env:"RESTQL_GRPC_DESCRIPTOR_SETS" envSeparator:","`
+			} `yaml:"grpc"`
+
+			Recording struct {
+				Mode          string   `yaml:"mode" env:"RESTQL_RECORDING_MODE"`
+				Dir           string   `yaml:"dir" env:"RESTQL_RECORDING_DIR"`
+				Matching      string   `yaml:"matching" env:"RESTQL_RECORDING_MATCHING"`
+				IgnoreHeaders []string `yaml:"ignoreHeaders" env:"RESTQL_RECORDING_IGNORE_HEADERS" envSeparator:","`
+			} `yaml:"recording"`
 		} `yaml:"client"`
 	} `yaml:"http"`
 
@@ -113,12 +143,31 @@ type Config struct {
 		DisableDatabase bool `yaml:"disableDatabase" env:"RESTQL_PLUGINS_DATABASE_DISABLE"`
 	} `yaml:"plugins"`
 
+	Database struct {
+		// Timeout is the limit in milliseconds for each database operation.
+		Timeout int `yaml:"timeout" env:"RESTQL_DATABASE_TIMEOUT"`
+
+		SQLite struct {
+			Path string `yaml:"path" env:"RESTQL_DATABASE_SQLITE_PATH"`
+		} `yaml:"sqlite"`
+	} `yaml:"database"`
+
 	Tenant string `env:"RESTQL_TENANT"`
 
 	TenantMappings map[string]map[string]string `yaml:"tenants"`
 
 	Queries map[string]map[string][]string `yaml:"queries"`
 
+	QueriesDirectory struct {
+		Path         string        `yaml:"path" env:"RESTQL_QUERIES_DIRECTORY"`
+		PollInterval time.Duration `yaml:"pollInterval" env:"RESTQL_QUERIES_DIRECTORY_POLL_INTERVAL"`
+	} `yaml:"queriesDirectory"`
+
+	Reload struct {
+		Enable       bool          `yaml:"enable" env:"RESTQL_CONFIG_RELOAD_ENABLE"`
+		PollInterval time.Duration `yaml:"pollInterval" env:"RESTQL_CONFIG_RELOAD_POLL_INTERVAL"`
+	} `yaml:"reload"`
+
 	Env EnvSource
 
 	Build string
@@ -128,10 +177,14 @@ type Config struct {
 // defaults, YAML configuration file and
 // environment variables.
 func Load(build string) (*Config, error) {
+	return parse(readConfigFile(), build, true)
+}
+
+func parse(data []byte, build string, server bool) (*Config, error) {
 	cfg := Config{}
 	readDefaults(&cfg)
 
-	err := yaml.Unmarshal(readConfigFile(), &cfg)
+	err := yaml.Unmarshal(data, &cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -141,12 +194,51 @@ func Load(build string) (*Config, error) {
 		return nil, err
 	}
 
+	if server {
+		err = requireServerPorts()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	cfg.Build = build
 	cfg.Env = EnvSource{}
 
 	return &cfg, nil
 }
 
+// Defaults returns a Config with only the default
+// values, ignoring the YAML configuration file
+// and environment variables.
+func Defaults() *Config {
+	cfg := Config{}
+	readDefaults(&cfg)
+	cfg.Env = EnvSource{}
+
+	return &cfg
+}
+
+// serverPortsEnv are the environment variables required
+// only when restQL is started as a server.
+var serverPortsEnv = []string{"RESTQL_PORT", "RESTQL_HEALTH_PORT"}
+
+// LoadCLI returns a Config like Load, for the command line tools
+// that execute queries in-process and do not listen to any port,
+// therefore not requiring the server ports to be defined.
+func LoadCLI(build string) (*Config, error) {
+	return parse(readConfigFile(), build, false)
+}
+
+func requireServerPorts() error {
+	for _, name := range serverPortsEnv {
+		if _, found := os.LookupEnv(name); !found {
+			return fmt.Errorf("env: required environment variable %q is not set", name)
+		}
+	}
+
+	return nil
+}
+
 func readConfigFile() []byte {
 	path := getConfigFilepath()
 	if path == "" {
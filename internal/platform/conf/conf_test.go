@@ -0,0 +1,55 @@
+package conf_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestLoad_ServerPorts(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "restql.yml")
+	test.VerifyError(t, os.WriteFile(configFile, nil, 0644))
+	t.Setenv("RESTQL_CONFIG", configFile)
+
+	unsetEnv(t, "RESTQL_PORT")
+	unsetEnv(t, "RESTQL_HEALTH_PORT")
+
+	t.Run("should require the server ports", func(t *testing.T) {
+		_, err := conf.Load("test")
+		if err == nil {
+			t.Errorf("Load = nil, want error")
+		}
+	})
+
+	t.Run("should not require the server ports on command line tools", func(t *testing.T) {
+		cfg, err := conf.LoadCLI("test")
+		test.VerifyError(t, err)
+		test.Equal(t, cfg.HTTP.Server.APIAddr, "")
+
+		_, found := os.LookupEnv("RESTQL_PORT")
+		test.Equal(t, found, false)
+	})
+
+	t.Run("should load the server ports", func(t *testing.T) {
+		t.Setenv("RESTQL_PORT", "9000")
+		t.Setenv("RESTQL_HEALTH_PORT", "9001")
+
+		cfg, err := conf.Load("test")
+		test.VerifyError(t, err)
+		test.Equal(t, cfg.HTTP.Server.APIAddr, "9000")
+		test.Equal(t, cfg.HTTP.Server.APIHealthAddr, "9001")
+	})
+}
+
+func unsetEnv(t *testing.T, name string) {
+	t.Helper()
+
+	value, found := os.LookupEnv(name)
+	test.VerifyError(t, os.Unsetenv(name))
+	if found {
+		t.Cleanup(func() { os.Setenv(name, value) })
+	}
+}
@@ -0,0 +1,161 @@
+package conf
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// reloadablePaths are the fields, identified by their YAML path,
+// that can be changed while restQL is running.
+var reloadablePaths = []string{
+	"tenants",
+	"queries",
+	"logging.level",
+	"http.server.middlewares.cors",
+	"http.server.middlewares.timeout",
+	"http.queryResourceTimeout",
+	"http.globalQueryTimeout",
+}
+
+// secretPaths are the fields whose values are never shown in a Change.
+var secretPaths = []string{
+	"http.server.admin.authorizationCode",
+	"http.server.admin.keys",
+}
+
+const maskedValue = "******"
+
+// Change describes a field that differs between two configurations,
+// where a missing value, like a removed tenant, is nil.
+type Change struct {
+	Path     string
+	Previous interface{}
+	Current  interface{}
+}
+
+// Reloadable tells if the field can be changed without restarting restQL.
+func (c Change) Reloadable() bool {
+	return matchesAny(c.Path, reloadablePaths)
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Path, c.Previous, c.Current)
+}
+
+// Diff compares the configurations field by field, returning the
+// changes ordered by path. Values of secret fields are masked.
+func Diff(previous, current *Config) []Change {
+	var changes []Change
+	diffValues(&changes, "", reflect.ValueOf(*previous), reflect.ValueOf(*current))
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	for i, c := range changes {
+		if matchesAny(c.Path, secretPaths) {
+			changes[i].Previous = maskedValue
+			changes[i].Current = maskedValue
+		}
+	}
+
+	return changes
+}
+
+// MergeReloadable returns a copy of the running configuration with
+// the reloadable fields taken from the loaded one, describing the
+// configuration in effect after the reloadable changes are applied.
+func MergeReloadable(running, loaded *Config) *Config {
+	merged := *running
+	for _, path := range reloadablePaths {
+		copyField(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(loaded).Elem(), strings.Split(path, "."))
+	}
+
+	return &merged
+}
+
+func copyField(dst, src reflect.Value, path []string) {
+	if len(path) == 0 {
+		dst.Set(src)
+		return
+	}
+
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		if field.PkgPath == "" && fieldName(field) == path[0] {
+			copyField(dst.Field(i), src.Field(i), path[1:])
+			return
+		}
+	}
+}
+
+func diffValues(changes *[]Change, path string, previous, current reflect.Value) {
+	switch {
+	case !previous.IsValid() || !current.IsValid():
+		*changes = append(*changes, Change{Path: path, Previous: valueOf(previous), Current: valueOf(current)})
+	case previous.Kind() == reflect.Struct:
+		for i := 0; i < previous.NumField(); i++ {
+			field := previous.Type().Field(i)
+			name := fieldName(field)
+			if field.PkgPath != "" || name == "-" {
+				continue
+			}
+
+			diffValues(changes, joinPath(path, name), previous.Field(i), current.Field(i))
+		}
+	case previous.Kind() == reflect.Map && previous.Type().Key().Kind() == reflect.String:
+		keys := make(map[string]struct{})
+		for _, k := range previous.MapKeys() {
+			keys[k.String()] = struct{}{}
+		}
+		for _, k := range current.MapKeys() {
+			keys[k.String()] = struct{}{}
+		}
+
+		for k := range keys {
+			key := reflect.ValueOf(k).Convert(previous.Type().Key())
+			diffValues(changes, joinPath(path, k), previous.MapIndex(key), current.MapIndex(key))
+		}
+	case !reflect.DeepEqual(previous.Interface(), current.Interface()):
+		*changes = append(*changes, Change{Path: path, Previous: previous.Interface(), Current: current.Interface()})
+	}
+}
+
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+// fieldName returns the YAML key of the field,
+// which defaults to the lower case field name.
+func fieldName(field reflect.StructField) string {
+	tag := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if tag != "" {
+		return tag
+	}
+
+	return strings.ToLower(field.Name)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func matchesAny(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+
+	return false
+}
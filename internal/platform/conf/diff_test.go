@@ -0,0 +1,99 @@
+package conf_test
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(cfg *conf.Config)
+		expected []conf.Change
+	}{
+		{
+			"should return no changes for equal configurations",
+			func(cfg *conf.Config) {},
+			nil,
+		},
+		{
+			"should return added tenant mapping",
+			func(cfg *conf.Config) {
+				cfg.TenantMappings = map[string]map[string]string{"acme": {"hero": "http://hero.api"}}
+			},
+			[]conf.Change{{Path: "tenants.acme", Previous: nil, Current: map[string]string{"hero": "http://hero.api"}}},
+		},
+		{
+			"should return changed logging level",
+			func(cfg *conf.Config) { cfg.Logging.Level = "debug" },
+			[]conf.Change{{Path: "logging.level", Previous: "info", Current: "debug"}},
+		},
+		{
+			"should mask secret values",
+			func(cfg *conf.Config) { cfg.HTTP.Server.Admin.AuthorizationCode = "secret" },
+			[]conf.Change{{Path: "http.server.admin.authorizationCode", Previous: "******", Current: "******"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := conf.Defaults()
+			previous.Logging.Level = "info"
+			current := conf.Defaults()
+			current.Logging.Level = "info"
+			tt.change(current)
+
+			test.Equal(t, conf.Diff(previous, current), tt.expected)
+		})
+	}
+}
+
+func TestChange_Reloadable(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"tenants.acme", true},
+		{"queries.demo.hero", true},
+		{"logging.level", true},
+		{"http.server.middlewares.cors.allowOrigin", true},
+		{"http.server.middlewares.timeout.duration", true},
+		{"http.queryResourceTimeout", true},
+		{"http.globalQueryTimeout", true},
+		{"http.server.middlewares.requestId.enable", false},
+		{"logging.format", false},
+		{"tenant", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			test.Equal(t, conf.Change{Path: tt.path}.Reloadable(), tt.expected)
+		})
+	}
+}
+
+func TestMergeReloadable(t *testing.T) {
+	running := conf.Defaults()
+	running.Logging.Level = "info"
+
+	loaded := conf.Defaults()
+	loaded.Logging.Level = "debug"
+	loaded.Logging.Format = "pretty"
+	loaded.TenantMappings = map[string]map[string]string{"acme": {"hero": "http://hero.api"}}
+	loaded.HTTP.Server.Admin.AuthorizationCode = "secret"
+
+	merged := conf.MergeReloadable(running, loaded)
+
+	test.Equal(t, merged.Logging.Level, "debug")
+	test.Equal(t, merged.TenantMappings, loaded.TenantMappings)
+	test.Equal(t, merged.Logging.Format, running.Logging.Format)
+	test.Equal(t, merged.HTTP.Server.Admin.AuthorizationCode, running.HTTP.Server.Admin.AuthorizationCode)
+	test.Equal(t, running.Logging.Level, "info")
+
+	test.Equal(t, conf.Diff(merged, loaded), []conf.Change{
+		{Path: "http.server.admin.authorizationCode", Previous: "******", Current: "******"},
+		{Path: "logging.format", Previous: running.Logging.Format, Current: "pretty"},
+	})
+}
@@ -0,0 +1,144 @@
+package conf
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// ApplyFunc applies the reloadable changes of the current configuration,
+// returning an error to reject it when it is invalid.
+type ApplyFunc func(previous, current *Config, changes []Change) error
+
+// Watcher reloads the configuration file when it changes,
+// checking it on every poll interval, or when the process
+// receives a SIGHUP.
+type Watcher struct {
+	log      restql.Logger
+	path     string
+	interval time.Duration
+	current  *Config
+	state    fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher constructs a Watcher for the
+// file from which cfg was loaded.
+func NewWatcher(log restql.Logger, cfg *Config) *Watcher {
+	w := &Watcher{
+		log:      log,
+		path:     getConfigFilepath(),
+		interval: cfg.Reload.PollInterval,
+		current:  cfg,
+	}
+	w.state, _ = w.stat()
+
+	return w
+}
+
+// Run watches the configuration until the context is done,
+// calling apply for every new valid configuration with changes.
+func (w *Watcher) Run(ctx context.Context, apply ApplyFunc) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	var poll <-chan time.Time
+	if w.path != "" && w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	w.log.Info("watching configuration", "path", w.path, "interval", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			w.log.Info("configuration reload requested by signal")
+			w.reload(apply)
+		case <-poll:
+			state, err := w.stat()
+			if err != nil || state == w.state {
+				continue
+			}
+
+			w.state = state
+			w.reload(apply)
+		}
+	}
+}
+
+func (w *Watcher) reload(apply ApplyFunc) {
+	cfg, err := w.load()
+	if err != nil {
+		w.log.Error("configuration rejected", err, "path", w.path)
+		return
+	}
+
+	changes := Diff(w.current, cfg)
+	if len(changes) == 0 {
+		w.log.Info("configuration unchanged", "path", w.path)
+		return
+	}
+
+	var reloadable []Change
+	for _, c := range changes {
+		if c.Reloadable() {
+			reloadable = append(reloadable, c)
+			continue
+		}
+
+		w.log.Warn("configuration change requires restart", "change", c.String())
+	}
+
+	err = apply(w.current, cfg, reloadable)
+	if err != nil {
+		w.log.Error("configuration rejected", err, "path", w.path)
+		return
+	}
+
+	for _, c := range reloadable {
+		w.log.Info("configuration changed", "change", c.String())
+	}
+
+	w.current = MergeReloadable(w.current, cfg)
+	w.log.Info("configuration reloaded", "path", w.path, "changes", len(reloadable))
+}
+
+// load reads the configuration like Load, but failing
+// when the file cannot be read, instead of using only
+// the defaults and environment variables.
+func (w *Watcher) load() (*Config, error) {
+	if w.path == "" {
+		return nil, errors.New("no config file present")
+	}
+
+	data, err := ioutil.ReadFile(w.path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(data, w.current.Build, true)
+}
+
+func (w *Watcher) stat() (fileState, error) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return fileState{}, err
+	}
+
+	return fileState{modTime: info.ModTime(), size: info.Size()}, nil
+}
@@ -7,6 +7,8 @@ import (
 
 var defaults = []byte(`
 http:
+  queryResourceTimeout: 5s
+  globalQueryTimeout: 30s
   server:
     readTimeout: 3s
     idleTimeout: 5s
@@ -39,6 +41,13 @@ cache:
 
 database:
   timeout: 1000
+
+queriesDirectory:
+  pollInterval: 5s
+
+reload:
+  enable: true
+  pollInterval: 5s
 `)
 
 func readDefaults(cfg *Config) {
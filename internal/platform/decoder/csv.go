@@ -0,0 +1,48 @@
+package decoder
+
+import (
+	"bytes"
+	"encoding/csv"
+
+	"github.com/pkg/errors"
+)
+
+// csvDecoder transforms a CSV document into a list of objects,
+// using the first record as the header that provides
+// the keys for the values in the following records.
+type csvDecoder struct {
+	separator rune
+}
+
+func (c csvDecoder) Decode(body []byte) (interface{}, error) {
+	r := csv.NewReader(bytes.NewReader(body))
+	r.Comma = c.separator
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read csv document")
+	}
+
+	if len(records) == 0 {
+		return []interface{}{}, nil
+	}
+
+	header := records[0]
+	result := make([]interface{}, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]interface{}, len(header))
+		for i, key := range header {
+			if i < len(record) {
+				row[key] = record[i]
+			} else {
+				row[key] = nil
+			}
+		}
+
+		result = append(result, row)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package decoder
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// Registry indexes the available response decoders
+// by the media type they are able to handle.
+type Registry struct {
+	log      restql.Logger
+	decoders map[string]restql.ResponseDecoder
+}
+
+// New constructs a Registry with the built-in decoders
+// for XML, CSV and plain text content, plus the given
+// decoder plugins. Plugins take precedence over the
+// built-in decoders when handling the same media type.
+func New(log restql.Logger, plugins []restql.ResponseDecoderPlugin) Registry {
+	decoders := map[string]restql.ResponseDecoder{
+		"application/xml":           xmlDecoder{},
+		"text/xml":                  xmlDecoder{},
+		"+xml":                      xmlDecoder{},
+		"text/csv":                  csvDecoder{separator: ','},
+		"application/csv":           csvDecoder{separator: ','},
+		"text/tab-separated-values": csvDecoder{separator: '\t'},
+		"text/plain":                textDecoder{},
+	}
+
+	for _, p := range plugins {
+		for _, mediaType := range p.MediaTypes() {
+			mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+			if mediaType == "" {
+				continue
+			}
+
+			log.Debug("response decoder registered", "plugin", p.Name(), "media-type", mediaType)
+			decoders[mediaType] = p
+		}
+	}
+
+	return Registry{log: log, decoders: decoders}
+}
+
+// ForContentType returns the decoder for the media type described
+// in the given Content-Type header value. JSON content types never
+// have a decoder, since restQL natively handles them.
+//
+// It should only be used for bodies that are not valid JSON, since
+// upstreams frequently omit or misreport the Content-Type of JSON
+// responses.
+//
+// The lookup first try an exact match for the media type and then
+// fallback to its structured syntax suffix, like "+xml".
+func (r Registry) ForContentType(contentType string) (restql.ResponseDecoder, bool) {
+	if contentType == "" {
+		return nil, false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		r.log.Debug("failed to parse response content type", "content-type", contentType, "error", err)
+		return nil, false
+	}
+
+	if isJSON(mediaType) {
+		return nil, false
+	}
+
+	if d, found := r.decoders[mediaType]; found {
+		return d, true
+	}
+
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		d, found := r.decoders[mediaType[i:]]
+		return d, found
+	}
+
+	return nil, false
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,185 @@
+package decoder_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/decoder"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestRegistry_XML(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    interface{}
+	}{
+		{
+			"should decode element with text content as string",
+			"application/xml",
+			`<hero>Batman</hero>`,
+			test.Unmarshal(`{"hero": "Batman"}`),
+		},
+		{
+			"should decode attributes and children",
+			"text/xml; charset=utf-8",
+			`<?xml version="1.0"?><hero id="1"><name>Batman</name><city>Gotham</city></hero>`,
+			test.Unmarshal(`{"hero": {"@id": "1", "name": "Batman", "city": "Gotham"}}`),
+		},
+		{
+			"should group repeated elements in a list",
+			"application/xml",
+			`<heroes><hero>Batman</hero><hero>Superman</hero><hero>Flash</hero></heroes>`,
+			test.Unmarshal(`{"heroes": {"hero": ["Batman", "Superman", "Flash"]}}`),
+		},
+		{
+			"should keep text content of element with attributes",
+			"application/xml",
+			`<hero alias="Batman">Bruce Wayne</hero>`,
+			test.Unmarshal(`{"hero": {"@alias": "Batman", "#text": "Bruce Wayne"}}`),
+		},
+		{
+			"should use structured syntax suffix",
+			"application/atom+xml",
+			`<feed xmlns="http://www.w3.org/2005/Atom"><title>News</title></feed>`,
+			test.Unmarshal(`{"feed": {"title": "News"}}`),
+		},
+	}
+
+	registry := decoder.New(test.NoOpLogger, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, found := registry.ForContentType(tt.contentType)
+			if !found {
+				t.Fatalf("decoder not found for %s", tt.contentType)
+			}
+
+			got, err := d.Decode([]byte(tt.body))
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestRegistry_CSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    interface{}
+	}{
+		{
+			"should decode records as list of objects",
+			"text/csv",
+			"id,name\n1,Batman\n2,Superman\n",
+			test.Unmarshal(`[{"id": "1", "name": "Batman"}, {"id": "2", "name": "Superman"}]`),
+		},
+		{
+			"should decode tab separated values",
+			"text/tab-separated-values",
+			"id\tname\n1\tBatman\n",
+			test.Unmarshal(`[{"id": "1", "name": "Batman"}]`),
+		},
+		{
+			"should fill missing fields with null",
+			"text/csv",
+			"id,name\n1\n",
+			test.Unmarshal(`[{"id": "1", "name": null}]`),
+		},
+		{
+			"should decode header only document as empty list",
+			"text/csv",
+			"id,name\n",
+			test.Unmarshal(`[]`),
+		},
+	}
+
+	registry := decoder.New(test.NoOpLogger, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, found := registry.ForContentType(tt.contentType)
+			if !found {
+				t.Fatalf("decoder not found for %s", tt.contentType)
+			}
+
+			got, err := d.Decode([]byte(tt.body))
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestRegistry_ForContentType(t *testing.T) {
+	registry := decoder.New(test.NoOpLogger, []restql.ResponseDecoderPlugin{stubDecoderPlugin{}})
+
+	t.Run("should not return decoder for json", func(t *testing.T) {
+		_, found := registry.ForContentType("application/json; charset=utf-8")
+		test.Equal(t, found, false)
+
+		_, found = registry.ForContentType("application/problem+json")
+		test.Equal(t, found, false)
+	})
+
+	t.Run("should not return decoder for unknown media type", func(t *testing.T) {
+		_, found := registry.ForContentType("application/octet-stream")
+		test.Equal(t, found, false)
+	})
+
+	t.Run("should prefer plugin over built-in decoder", func(t *testing.T) {
+		d, found := registry.ForContentType("text/plain")
+		test.Equal(t, found, true)
+
+		got, err := d.Decode([]byte("anything"))
+		test.VerifyError(t, err)
+		test.Equal(t, got, "from plugin")
+	})
+}
+
+func TestResponseBody_WithDecoder(t *testing.T) {
+	registry := decoder.New(test.NoOpLogger, nil)
+	d, _ := registry.ForContentType("application/xml")
+
+	rb := restql.NewDecodableResponseBody(test.NoOpLogger, []byte(`<hero><name>Batman</name></hero>`), d)
+
+	test.Equal(t, rb.Valid(), true)
+	test.Equal(t, rb.Unmarshal(), test.Unmarshal(`{"hero": {"name": "Batman"}}`))
+
+	got, err := rb.Marshal()
+	test.VerifyError(t, err)
+	test.Equal(t, got, json.RawMessage(`{"hero":{"name":"Batman"}}`))
+}
+
+func TestResponseBody_WithFailingDecoder(t *testing.T) {
+	d := &failingDecoder{}
+	rb := restql.NewDecodableResponseBody(test.NoOpLogger, []byte(`<hero>`), d)
+
+	test.Equal(t, rb.Valid(), false)
+	test.Equal(t, rb.Unmarshal(), "<hero>")
+
+	got, err := rb.Marshal()
+	test.VerifyError(t, err)
+	test.Equal(t, got, "<hero>")
+	test.Equal(t, d.calls, 1)
+}
+
+type failingDecoder struct {
+	calls int
+}
+
+func (f *failingDecoder) Decode(body []byte) (interface{}, error) {
+	f.calls++
+	return nil, errors.New("malformed body")
+}
+
+type stubDecoderPlugin struct{}
+
+func (s stubDecoderPlugin) Name() string         { return "stub" }
+func (s stubDecoderPlugin) MediaTypes() []string { return []string{"text/plain"} }
+func (s stubDecoderPlugin) Decode(body []byte) (interface{}, error) {
+	return "from plugin", nil
+}
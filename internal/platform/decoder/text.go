@@ -0,0 +1,8 @@
+package decoder
+
+// textDecoder keeps a plain text body as a string value.
+type textDecoder struct{}
+
+func (t textDecoder) Decode(body []byte) (interface{}, error) {
+	return string(body), nil
+}
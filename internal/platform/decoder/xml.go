@@ -0,0 +1,113 @@
+package decoder
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Keys used when converting an XML element into a JSON object.
+const (
+	xmlAttributePrefix = "@"
+	xmlTextKey         = "#text"
+)
+
+var errEmptyXMLDocument = errors.New("xml document has no root element")
+
+// xmlDecoder transforms an XML document into a JSON tree
+// following these conventions:
+// • The root element becomes an object with a single key, the element name.
+// • Attributes become keys prefixed with "@".
+// • Elements with only text content become strings.
+// • Text content of elements with attributes or children is stored in the "#text" key.
+// • Repeated child elements are grouped in a list.
+// • Namespace prefixes are discarded, only the local name is used.
+type xmlDecoder struct{}
+
+func (x xmlDecoder) Decode(body []byte) (interface{}, error) {
+	d := xml.NewDecoder(bytes.NewReader(body))
+	d.Strict = false
+
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			return nil, errEmptyXMLDocument
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read xml document")
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(d, start)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{start.Name.Local: value}, nil
+		}
+	}
+}
+
+func decodeXMLElement(d *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	element := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+
+		element[xmlAttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	hasChildren := false
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read xml element")
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(d, token)
+			if err != nil {
+				return nil, err
+			}
+
+			hasChildren = true
+			appendXMLChild(element, token.Name.Local, child)
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+
+			if !hasChildren && len(element) == 0 {
+				return content, nil
+			}
+
+			if content != "" {
+				element[xmlTextKey] = content
+			}
+
+			return element, nil
+		}
+	}
+}
+
+func appendXMLChild(element map[string]interface{}, name string, child interface{}) {
+	current, found := element[name]
+	if !found {
+		element[name] = child
+		return
+	}
+
+	if list, ok := current.([]interface{}); ok {
+		element[name] = append(list, child)
+		return
+	}
+
+	element[name] = []interface{}{current, child}
+}
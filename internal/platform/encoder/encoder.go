@@ -0,0 +1,177 @@
+package encoder
+
+import (
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+const jsonMediaType = "application/json"
+
+// Encoder writes API responses in the negotiated media type.
+type Encoder struct {
+	mediaType string
+	encoder   restql.ResponseEncoder
+}
+
+// ContentType returns the value to be used in
+// the response Content-Type header.
+func (e Encoder) ContentType() string {
+	if e.mediaType == jsonMediaType {
+		return "application/json; charset=utf-8"
+	}
+
+	return e.mediaType
+}
+
+// Encode writes the given value in the encoder media type.
+//
+// Values are transformed into their generic JSON representation
+// before being passed to encoders other than the JSON one, hence
+// they only handle maps, slices and primitives, and any embedded
+// json.RawMessage is properly transcoded.
+func (e Encoder) Encode(w io.Writer, v interface{}) error {
+	if _, ok := e.encoder.(jsonEncoder); ok {
+		return e.encoder.Encode(w, v)
+	}
+
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+
+	return e.encoder.Encode(w, generic)
+}
+
+// Registry indexes the available response encoders
+// by the media type they produce.
+type Registry struct {
+	log        restql.Logger
+	mediaTypes []string
+	encoders   map[string]restql.ResponseEncoder
+}
+
+// New constructs a Registry with the built-in encoders
+// for JSON, MessagePack, CBOR and YAML, plus the given
+// encoder plugins. Plugins take precedence over the
+// built-in encoders when producing the same media type.
+func New(log restql.Logger, plugins []restql.ResponseEncoderPlugin) Registry {
+	r := Registry{log: log, encoders: make(map[string]restql.ResponseEncoder)}
+
+	r.register(jsonEncoder{}, jsonMediaType)
+	r.register(msgpackEncoder{}, "application/msgpack", "application/x-msgpack", "application/vnd.msgpack")
+	r.register(cborEncoder{}, "application/cbor")
+	r.register(yamlEncoder{}, "application/yaml", "application/x-yaml", "text/yaml")
+
+	for _, p := range plugins {
+		log.Debug("response encoder registered", "plugin", p.Name(), "media-types", p.MediaTypes())
+		r.register(p, p.MediaTypes()...)
+	}
+
+	return r
+}
+
+func (r *Registry) register(encoder restql.ResponseEncoder, mediaTypes ...string) {
+	for _, mediaType := range mediaTypes {
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if mediaType == "" {
+			continue
+		}
+
+		if _, found := r.encoders[mediaType]; !found {
+			r.mediaTypes = append(r.mediaTypes, mediaType)
+		}
+		r.encoders[mediaType] = encoder
+	}
+}
+
+// JSON returns the built-in JSON encoder.
+func JSON() Encoder {
+	return Encoder{mediaType: jsonMediaType, encoder: jsonEncoder{}}
+}
+
+// Default returns the JSON encoder, used when
+// the client does not express any preference.
+func (r Registry) Default() Encoder {
+	return Encoder{mediaType: jsonMediaType, encoder: r.encoders[jsonMediaType]}
+}
+
+// MediaTypes returns all media types that can be produced.
+func (r Registry) MediaTypes() []string {
+	return r.mediaTypes
+}
+
+// Negotiate chooses the encoder that best fits the given Accept
+// header value, respecting the quality factor of each media range.
+// It returns false if none of the acceptable media types is supported.
+func (r Registry) Negotiate(accept string) (Encoder, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return r.Default(), true
+	}
+
+	for _, mediaRange := range parseAccept(accept) {
+		switch {
+		case mediaRange == "*/*":
+			return r.Default(), true
+		case strings.HasSuffix(mediaRange, "/*"):
+			prefix := strings.TrimSuffix(mediaRange, "*")
+			for _, mediaType := range r.mediaTypes {
+				if strings.HasPrefix(mediaType, prefix) {
+					return Encoder{mediaType: mediaType, encoder: r.encoders[mediaType]}, true
+				}
+			}
+		default:
+			if e, found := r.encoders[mediaRange]; found {
+				return Encoder{mediaType: mediaRange, encoder: e}, true
+			}
+		}
+	}
+
+	return Encoder{}, false
+}
+
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAccept returns the acceptable media ranges
+// ordered by the client preference.
+func parseAccept(accept string) []string {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, found := params["q"]; found {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		if quality <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	result := make([]string, len(ranges))
+	for i, r := range ranges {
+		result[i] = r.mediaType
+	}
+
+	return result
+}
@@ -0,0 +1,126 @@
+package encoder_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/encoder"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v2"
+)
+
+func TestRegistry_Negotiate(t *testing.T) {
+	tests := []struct {
+		name          string
+		accept        string
+		expected      string
+		expectedFound bool
+	}{
+		{"should use json when there is no accept header", "", "application/json; charset=utf-8", true},
+		{"should use json for any media type", "*/*", "application/json; charset=utf-8", true},
+		{"should use exact media type", "application/x-msgpack", "application/x-msgpack", true},
+		{"should respect quality factor", "application/yaml;q=0.5, application/cbor", "application/cbor", true},
+		{"should ignore unacceptable media types", "application/cbor;q=0, application/yaml", "application/yaml", true},
+		{"should match media type wildcard", "text/html, text/*", "text/yaml", true},
+		{"should use plugin encoder", "application/vnd.custom", "application/vnd.custom", true},
+		{"should fail when no media type is supported", "text/html, image/png", "", false},
+	}
+
+	registry := encoder.New(test.NoOpLogger, []restql.ResponseEncoderPlugin{customEncoder{}})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, found := registry.Negotiate(tt.accept)
+
+			test.Equal(t, found, tt.expectedFound)
+			test.Equal(t, e.ContentType(), tt.expected)
+		})
+	}
+}
+
+type statementResult struct {
+	Details map[string]interface{} `json:"details"`
+	Result  interface{}            `json:"result,omitempty"`
+}
+
+func TestEncoder_Encode(t *testing.T) {
+	response := map[string]statementResult{
+		"hero": {
+			Details: map[string]interface{}{"status": 200},
+			Result:  json.RawMessage(`{"name": "Batman", "height": 1.88}`),
+		},
+	}
+
+	expected := test.Unmarshal(`{"hero": {"details": {"status": 200}, "result": {"name": "Batman", "height": 1.88}}}`)
+
+	registry := encoder.New(test.NoOpLogger, nil)
+
+	t.Run("should encode as json", func(t *testing.T) {
+		got := encode(t, registry, "application/json", response)
+
+		test.Equal(t, got, `{"hero":{"details":{"status":200},"result":{"name":"Batman","height":1.88}}}`+"\n")
+	})
+
+	t.Run("should transcode raw json into msgpack", func(t *testing.T) {
+		got := encode(t, registry, "application/msgpack", response)
+
+		var decoded map[string]interface{}
+		err := msgpack.Unmarshal([]byte(got), &decoded)
+		test.VerifyError(t, err)
+
+		test.Equal(t, normalize(t, decoded), expected)
+	})
+
+	t.Run("should transcode raw json into cbor", func(t *testing.T) {
+		got := encode(t, registry, "application/cbor", response)
+
+		var decoded map[string]interface{}
+		err := cbor.Unmarshal([]byte(got), &decoded)
+		test.VerifyError(t, err)
+
+		test.Equal(t, normalize(t, decoded), expected)
+	})
+
+	t.Run("should transcode raw json into yaml", func(t *testing.T) {
+		got := encode(t, registry, "application/yaml", response)
+
+		test.Equal(t, got, "hero:\n  details:\n    status: 200\n  result:\n    height: 1.88\n    name: Batman\n")
+	})
+}
+
+func encode(t *testing.T, registry encoder.Registry, accept string, v interface{}) string {
+	e, found := registry.Negotiate(accept)
+	if !found {
+		t.Fatalf("encoder not found for %s", accept)
+	}
+
+	var buf bytes.Buffer
+	err := e.Encode(&buf, v)
+	test.VerifyError(t, err)
+
+	return buf.String()
+}
+
+// normalize round trips a decoded value through JSON to
+// compare values regardless of the number types used.
+func normalize(t *testing.T, v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	test.VerifyError(t, err)
+
+	return test.Unmarshal(string(b))
+}
+
+type customEncoder struct{}
+
+func (c customEncoder) Name() string { return "custom" }
+
+func (c customEncoder) MediaTypes() []string { return []string{"application/vnd.custom"} }
+
+func (c customEncoder) Encode(w io.Writer, v interface{}) error {
+	return yaml.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,44 @@
+package encoder
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v2"
+)
+
+type jsonEncoder struct{}
+
+func (j jsonEncoder) Encode(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+type msgpackEncoder struct{}
+
+func (m msgpackEncoder) Encode(w io.Writer, v interface{}) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetSortMapKeys(true)
+
+	return enc.Encode(v)
+}
+
+var cborEncMode, _ = cbor.CanonicalEncOptions().EncMode()
+
+type cborEncoder struct{}
+
+func (c cborEncoder) Encode(w io.Writer, v interface{}) error {
+	return cborEncMode.NewEncoder(w).Encode(v)
+}
+
+type yamlEncoder struct{}
+
+func (y yamlEncoder) Encode(w io.Writer, v interface{}) error {
+	enc := yaml.NewEncoder(w)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
@@ -0,0 +1,55 @@
+package encoder
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// toGeneric transforms a value into its JSON representation
+// made of maps, slices and primitives. This resolves struct
+// tags and json.RawMessage values, like the ones produced
+// by restql.ResponseBody, which other formats cannot handle.
+func toGeneric(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal response value")
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var generic interface{}
+	if err := d.Decode(&generic); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal response value")
+	}
+
+	return normalizeNumbers(generic), nil
+}
+
+// normalizeNumbers replaces JSON numbers by integers, when
+// possible, or floats, preserving integers in binary formats.
+func normalizeNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalizeNumbers(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeNumbers(value)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
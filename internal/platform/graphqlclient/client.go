@@ -0,0 +1,70 @@
+package graphqlclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+var schemaToTransport = map[string]string{
+	"graphql":  "http",
+	"graphqls": "https",
+}
+
+// Client is an HTTPClient implementation that executes
+// GraphQL operations over HTTP, unwrapping the `data`
+// field of the response and translating the `errors`
+// field into a failed result.
+type Client struct {
+	log    restql.Logger
+	client domain.HTTPClient
+}
+
+// New constructs a Client that sends the GraphQL
+// operations through the given HTTP client.
+func New(log restql.Logger, client domain.HTTPClient) Client {
+	return Client{log: log, client: client}
+}
+
+// Do posts the GraphQL operation built from the statement
+// to the request host and path.
+func (c Client) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	if transport, found := schemaToTransport[request.Schema]; found {
+		request.Schema = transport
+	}
+	request.Method = http.MethodPost
+
+	response, err := c.client.Do(ctx, request)
+	if err != nil {
+		return response, err
+	}
+
+	return c.unwrap(response), nil
+}
+
+func (c Client) unwrap(response restql.HTTPResponse) restql.HTTPResponse {
+	if response.Body == nil {
+		return response
+	}
+
+	result, ok := response.Body.Unmarshal().(map[string]interface{})
+	if !ok {
+		return response
+	}
+
+	gqlErrors, _ := result["errors"].([]interface{})
+	if len(gqlErrors) == 0 {
+		response.Body = restql.NewResponseBodyFromValue(c.log, result["data"])
+		return response
+	}
+
+	response.StatusCode = errorStatus(response.StatusCode, gqlErrors)
+	response.Body = restql.NewResponseBodyFromValue(c.log, map[string]interface{}{
+		"data":   result["data"],
+		"errors": gqlErrors,
+	})
+
+	return response
+}
@@ -0,0 +1,88 @@
+package graphqlclient_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/graphqlclient"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestClient_Do(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        restql.HTTPRequest
+		response       restql.HTTPResponse
+		expectedSchema string
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			"should unwrap data from successful response",
+			restql.HTTPRequest{Schema: "graphql", Host: "hero.api", Path: "/graphql"},
+			restql.HTTPResponse{StatusCode: 200, Body: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(`{"data": {"hero": {"name": "Batman"}}}`))},
+			"http",
+			200,
+			test.Unmarshal(`{"hero": {"name": "Batman"}}`),
+		},
+		{
+			"should use https for graphqls schema",
+			restql.HTTPRequest{Schema: "graphqls", Host: "hero.api", Path: "/graphql"},
+			restql.HTTPResponse{StatusCode: 200, Body: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(`{"data": {"hero": null}}`))},
+			"https",
+			200,
+			test.Unmarshal(`{"hero": null}`),
+		},
+		{
+			"should fail with status from error code",
+			restql.HTTPRequest{Schema: "graphql", Host: "hero.api", Path: "/graphql"},
+			restql.HTTPResponse{StatusCode: 200, Body: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(`{"data": null, "errors": [{"message": "hero not found", "extensions": {"code": "NOT_FOUND"}}]}`))},
+			"http",
+			404,
+			test.Unmarshal(`{"data": null, "errors": [{"message": "hero not found", "extensions": {"code": "NOT_FOUND"}}]}`),
+		},
+		{
+			"should fail with internal error status for unknown error code",
+			restql.HTTPRequest{Schema: "graphql", Host: "hero.api", Path: "/graphql"},
+			restql.HTTPResponse{StatusCode: 200, Body: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(`{"data": {"hero": null}, "errors": [{"message": "boom"}]}`))},
+			"http",
+			500,
+			test.Unmarshal(`{"data": {"hero": null}, "errors": [{"message": "boom"}]}`),
+		},
+		{
+			"should keep failed http status",
+			restql.HTTPRequest{Schema: "graphql", Host: "hero.api", Path: "/graphql"},
+			restql.HTTPResponse{StatusCode: 400, Body: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(`{"errors": [{"message": "syntax error", "extensions": {"code": "UNAUTHENTICATED"}}]}`))},
+			"http",
+			400,
+			test.Unmarshal(`{"data": null, "errors": [{"message": "syntax error", "extensions": {"code": "UNAUTHENTICATED"}}]}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubClient{response: tt.response}
+			client := graphqlclient.New(test.NoOpLogger, stub)
+
+			got, err := client.Do(context.Background(), tt.request)
+			test.VerifyError(t, err)
+
+			test.Equal(t, stub.request.Schema, tt.expectedSchema)
+			test.Equal(t, stub.request.Method, http.MethodPost)
+			test.Equal(t, got.StatusCode, tt.expectedStatus)
+			test.Equal(t, got.Body.Unmarshal(), tt.expectedBody)
+		})
+	}
+}
+
+type stubClient struct {
+	request  restql.HTTPRequest
+	response restql.HTTPResponse
+}
+
+func (s *stubClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	s.request = request
+	return s.response, nil
+}
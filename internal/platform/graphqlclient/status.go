@@ -0,0 +1,53 @@
+package graphqlclient
+
+import "net/http"
+
+// codeToStatus translates the error codes commonly returned by
+// GraphQL servers in the `extensions.code` field into the
+// equivalent HTTP status used in restQL statement results.
+var codeToStatus = map[string]int{
+	"BAD_USER_INPUT":            http.StatusBadRequest,
+	"GRAPHQL_PARSE_FAILED":      http.StatusBadRequest,
+	"GRAPHQL_VALIDATION_FAILED": http.StatusBadRequest,
+	"UNAUTHENTICATED":           http.StatusUnauthorized,
+	"FORBIDDEN":                 http.StatusForbidden,
+	"NOT_FOUND":                 http.StatusNotFound,
+	"INTERNAL_SERVER_ERROR":     http.StatusInternalServerError,
+}
+
+// errorStatus chooses the statement status when the GraphQL
+// response has errors. Failed HTTP status are kept, otherwise
+// the first known error code is used, defaulting to 500.
+func errorStatus(httpStatus int, gqlErrors []interface{}) int {
+	if httpStatus >= 400 {
+		return httpStatus
+	}
+
+	for _, e := range gqlErrors {
+		code, ok := errorCode(e)
+		if !ok {
+			continue
+		}
+
+		if status, found := codeToStatus[code]; found {
+			return status
+		}
+	}
+
+	return http.StatusInternalServerError
+}
+
+func errorCode(gqlError interface{}) (string, bool) {
+	e, ok := gqlError.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	extensions, ok := e["extensions"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	code, ok := extensions["code"].(string)
+	return code, ok
+}
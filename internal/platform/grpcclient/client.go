@@ -0,0 +1,235 @@
+package grpcclient
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const secureSchema = "grpcs"
+
+var (
+	requestUnmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+	responseMarshalOptions  = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+)
+
+// Client is an HTTPClient implementation that executes
+// gRPC unary calls, translating the request parameters
+// into the method input message and the output message
+// into a JSON response body.
+type Client struct {
+	log       restql.Logger
+	lifecycle plugins.Lifecycle
+	files     *protoregistry.Files
+
+	connsMu sync.Mutex
+	conns   map[string]*grpc.ClientConn
+}
+
+// New constructs a Client that resolves methods
+// using the given protobuf descriptors.
+func New(log restql.Logger, pm plugins.Lifecycle, files *protoregistry.Files) *Client {
+	return &Client{log: log, lifecycle: pm, files: files, conns: make(map[string]*grpc.ClientConn)}
+}
+
+// Do executes the gRPC method identified by the request path
+// on the request host.
+func (c *Client) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	requestCtx, request := c.lifecycle.BeforeRequest(ctx, request)
+
+	target := fmt.Sprintf("%s://%s%s", request.Schema, request.Host, request.Path)
+
+	start := time.Now()
+	response, err := c.invoke(requestCtx, target, request)
+	response.Duration = time.Since(start)
+
+	_, response = c.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+	return response, err
+}
+
+func (c *Client) invoke(ctx context.Context, target string, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	response := restql.HTTPResponse{URL: target}
+
+	method, err := findMethod(c.files, request.Path)
+	if err != nil {
+		c.log.Error("failed to resolve grpc method", err, "url", target)
+		response.StatusCode = 500
+		response.Body = c.errorBody(codes.Unimplemented, err.Error())
+		return response, err
+	}
+
+	input := dynamicpb.NewMessage(method.Input())
+	if err := c.makeInput(request, input); err != nil {
+		c.log.Error("failed to build grpc input message", err, "url", target)
+		response.StatusCode = 400
+		response.Body = c.errorBody(codes.InvalidArgument, err.Error())
+		return response, err
+	}
+
+	conn, err := c.connection(request.Schema, request.Host)
+	if err != nil {
+		response.StatusCode = 500
+		response.Body = c.errorBody(codes.Unavailable, err.Error())
+		return response, errors.Wrap(err, "request execution failed")
+	}
+
+	if request.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, request.Timeout)
+		defer cancel()
+	}
+
+	md := makeMetadata(request.Headers)
+	if pluginMD, ok := metadata.FromOutgoingContext(ctx); ok {
+		md = metadata.Join(pluginMD, md)
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	var header metadata.MD
+	output := dynamicpb.NewMessage(method.Output())
+
+	err = conn.Invoke(ctx, request.Path, input, output, grpc.Header(&header))
+	response.Headers = readMetadata(header)
+
+	if err != nil {
+		st := status.Convert(err)
+		response.StatusCode = httpStatus(st.Code())
+		response.Body = c.errorBody(st.Code(), st.Message())
+
+		switch st.Code() {
+		case codes.DeadlineExceeded:
+			c.log.Info("request timed out", "url", target)
+			return response, domain.ErrRequestTimeout
+		case codes.Unavailable:
+			return response, errors.Wrap(err, "request execution failed")
+		}
+
+		return response, nil
+	}
+
+	body, err := responseMarshalOptions.Marshal(output)
+	if err != nil {
+		c.log.Error("failed to marshal grpc output message", err, "url", target)
+		response.StatusCode = 500
+		response.Body = c.errorBody(codes.Internal, err.Error())
+		return response, err
+	}
+
+	response.StatusCode = 200
+	response.Body = restql.NewResponseBodyFromBytes(c.log, body)
+
+	return response, nil
+}
+
+// errorBody describes a failed call with the gRPC status code
+// and message, giving every response a body like the upstream
+// responses have.
+func (c *Client) errorBody(code codes.Code, message string) *restql.ResponseBody {
+	return restql.NewResponseBodyFromValue(c.log, map[string]interface{}{
+		"code":    code.String(),
+		"message": message,
+	})
+}
+
+func (c *Client) makeInput(request restql.HTTPRequest, input *dynamicpb.Message) error {
+	fields := make(map[string]interface{})
+	for k, v := range request.Query {
+		fields[k] = v
+	}
+
+	if body, ok := request.Body.(map[string]interface{}); ok {
+		for k, v := range body {
+			fields[k] = v
+		}
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	return requestUnmarshalOptions.Unmarshal(data, input)
+}
+
+func (c *Client) connection(schema string, host string) (*grpc.ClientConn, error) {
+	key := schema + "://" + host
+
+	c.connsMu.Lock()
+	defer c.connsMu.Unlock()
+
+	if conn, found := c.conns[key]; found {
+		return conn, nil
+	}
+
+	var opts []grpc.DialOption
+	if schema == secureSchema {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(host, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.conns[key] = conn
+	return conn, nil
+}
+
+// Close terminates all connections opened by the Client.
+func (c *Client) Close() error {
+	c.connsMu.Lock()
+	defer c.connsMu.Unlock()
+
+	for key, conn := range c.conns {
+		if err := conn.Close(); err != nil {
+			c.log.Error("failed to close grpc connection", err, "target", key)
+		}
+		delete(c.conns, key)
+	}
+
+	return nil
+}
+
+func makeMetadata(headers restql.Headers) metadata.MD {
+	md := metadata.MD{}
+	for k, v := range headers {
+		if strings.EqualFold(k, "Content-Type") {
+			continue
+		}
+
+		md.Append(strings.ToLower(k), v)
+	}
+
+	return md
+}
+
+func readMetadata(md metadata.MD) restql.Headers {
+	h := make(restql.Headers)
+	for k, v := range md {
+		if len(v) > 0 {
+			h[k] = strings.Join(v, ",")
+		}
+	}
+
+	return h
+}
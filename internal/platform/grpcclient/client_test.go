@@ -0,0 +1,253 @@
+package grpcclient_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/grpcclient"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const heroService = "heroes.v1.HeroService"
+
+func TestClient_Do(t *testing.T) {
+	files, host := startHeroServer(t)
+	client := grpcclient.New(test.NoOpLogger, plugins.NoOpLifecycle, files)
+	defer client.Close()
+
+	t.Run("should translate params into input message and output into json", func(t *testing.T) {
+		request := restql.HTTPRequest{
+			Schema:  "grpc",
+			Host:    host,
+			Path:    "/" + heroService + "/GetHero",
+			Query:   map[string]interface{}{"id": "7", "unknown": "ignored"},
+			Headers: restql.Headers{"X-Tid": "abc", "Content-Type": "application/json"},
+			Timeout: time.Second,
+		}
+
+		response, err := client.Do(context.Background(), request)
+		test.VerifyError(t, err)
+
+		test.Equal(t, response.StatusCode, 200)
+		test.Equal(t, response.Body.Unmarshal(), test.Unmarshal(`{"id": 7, "name": "Batman", "request_id": "abc"}`))
+	})
+
+	t.Run("should merge body fields into input message", func(t *testing.T) {
+		request := restql.HTTPRequest{
+			Schema:  "grpc",
+			Host:    host,
+			Path:    "/" + heroService + "/GetHero",
+			Body:    map[string]interface{}{"id": 3},
+			Timeout: time.Second,
+		}
+
+		response, err := client.Do(context.Background(), request)
+		test.VerifyError(t, err)
+
+		test.Equal(t, response.StatusCode, 200)
+		test.Equal(t, response.Body.Unmarshal(), test.Unmarshal(`{"id": 3, "name": "Batman", "request_id": ""}`))
+	})
+
+	t.Run("should translate grpc status into http status", func(t *testing.T) {
+		request := restql.HTTPRequest{
+			Schema:  "grpc",
+			Host:    host,
+			Path:    "/" + heroService + "/GetHero",
+			Query:   map[string]interface{}{"id": "404"},
+			Timeout: time.Second,
+		}
+
+		response, err := client.Do(context.Background(), request)
+		test.VerifyError(t, err)
+
+		test.Equal(t, response.StatusCode, 404)
+		test.Equal(t, response.Body.Unmarshal(), map[string]interface{}{"code": "NotFound", "message": "hero not found"})
+	})
+
+	t.Run("should fail for unknown method", func(t *testing.T) {
+		request := restql.HTTPRequest{
+			Schema: "grpc",
+			Host:   host,
+			Path:   "/" + heroService + "/ListVillains",
+		}
+
+		response, err := client.Do(context.Background(), request)
+		if err == nil {
+			t.Fatalf("expected error for unknown method")
+		}
+
+		test.Equal(t, response.StatusCode, 500)
+		test.Equal(t, response.Body.Unmarshal().(map[string]interface{})["code"], "Unimplemented")
+	})
+
+	t.Run("should fail for unavailable host", func(t *testing.T) {
+		request := restql.HTTPRequest{
+			Schema:  "grpc",
+			Host:    "localhost:1",
+			Path:    "/" + heroService + "/GetHero",
+			Timeout: time.Second,
+		}
+
+		response, err := client.Do(context.Background(), request)
+		if err == nil {
+			t.Fatalf("expected error for unavailable host")
+		}
+
+		test.Equal(t, response.StatusCode, 503)
+		test.Equal(t, response.Body.Unmarshal().(map[string]interface{})["code"], "Unavailable")
+	})
+
+	t.Run("should call upstream with the context of the lifecycle plugins", func(t *testing.T) {
+		lifecycle := plugins.NewLifecycle(test.NoOpLogger, []restql.LifecyclePlugin{metadataPlugin{}})
+		client := grpcclient.New(test.NoOpLogger, lifecycle, files)
+		defer client.Close()
+
+		request := restql.HTTPRequest{
+			Schema:  "grpc",
+			Host:    host,
+			Path:    "/" + heroService + "/GetHero",
+			Query:   map[string]interface{}{"id": "7"},
+			Timeout: time.Second,
+		}
+
+		response, err := client.Do(context.Background(), request)
+		test.VerifyError(t, err)
+
+		test.Equal(t, response.Body.Unmarshal(), test.Unmarshal(`{"id": 7, "name": "Batman", "request_id": "from-plugin"}`))
+	})
+}
+
+// metadataPlugin adds outgoing metadata to the request context,
+// which must reach the upstream call.
+type metadataPlugin struct{}
+
+func (metadataPlugin) Name() string { return "metadata" }
+func (metadataPlugin) BeforeTransaction(ctx context.Context, tr restql.TransactionRequest) context.Context {
+	return ctx
+}
+func (metadataPlugin) AfterTransaction(ctx context.Context, tr restql.TransactionResponse) context.Context {
+	return ctx
+}
+func (metadataPlugin) BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) context.Context {
+	return ctx
+}
+func (metadataPlugin) AfterQuery(ctx context.Context, query string, result map[string]interface{}) context.Context {
+	return ctx
+}
+func (metadataPlugin) BeforeRequest(ctx context.Context, request restql.HTTPRequest) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "x-tid", "from-plugin")
+}
+func (metadataPlugin) AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) context.Context {
+	return ctx
+}
+
+func startHeroServer(t *testing.T) (*protoregistry.Files, string) {
+	fds := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{heroFileDescriptor()}}
+	data, err := proto.Marshal(fds)
+	test.VerifyError(t, err)
+
+	path := filepath.Join(t.TempDir(), "heroes.protoset")
+	err = ioutil.WriteFile(path, data, 0644)
+	test.VerifyError(t, err)
+
+	files, err := grpcclient.LoadDescriptorSets([]string{path})
+	if err != nil {
+		t.Fatalf("failed to load descriptor set: %v", err)
+	}
+
+	d, err := files.FindDescriptorByName(heroService)
+	test.VerifyError(t, err)
+	method := d.(protoreflect.ServiceDescriptor).Methods().ByName("GetHero")
+
+	l, err := net.Listen("tcp", "localhost:0")
+	test.VerifyError(t, err)
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: heroService,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "GetHero",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := dynamicpb.NewMessage(method.Input())
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+
+				id := in.Get(method.Input().Fields().ByName("id")).Int()
+				if id == 404 {
+					return nil, status.Error(codes.NotFound, "hero not found")
+				}
+
+				var requestID string
+				if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-tid")) > 0 {
+					requestID = md.Get("x-tid")[0]
+				}
+
+				out := dynamicpb.NewMessage(method.Output())
+				fields := method.Output().Fields()
+				out.Set(fields.ByName("id"), protoreflect.ValueOfInt32(int32(id)))
+				out.Set(fields.ByName("name"), protoreflect.ValueOfString("Batman"))
+				out.Set(fields.ByName("request_id"), protoreflect.ValueOfString(requestID))
+
+				return out, nil
+			},
+		}},
+	}, struct{}{})
+
+	go server.Serve(l)
+	t.Cleanup(server.Stop)
+
+	return files, l.Addr().String()
+}
+
+func heroFileDescriptor() *descriptorpb.FileDescriptorProto {
+	int32Type := descriptorpb.FieldDescriptorProto_TYPE_INT32
+	stringType := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("heroes.proto"),
+		Package: proto.String("heroes.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("GetHeroRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("id"), Number: proto.Int32(1), Type: &int32Type, Label: &optional, JsonName: proto.String("id")},
+				},
+			},
+			{
+				Name: proto.String("Hero"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("id"), Number: proto.Int32(1), Type: &int32Type, Label: &optional, JsonName: proto.String("id")},
+					{Name: proto.String("name"), Number: proto.Int32(2), Type: &stringType, Label: &optional, JsonName: proto.String("name")},
+					{Name: proto.String("request_id"), Number: proto.Int32(3), Type: &stringType, Label: &optional, JsonName: proto.String("requestId")},
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("HeroService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{Name: proto.String("GetHero"), InputType: proto.String(".heroes.v1.GetHeroRequest"), OutputType: proto.String(".heroes.v1.Hero")},
+				},
+			},
+		},
+	}
+}
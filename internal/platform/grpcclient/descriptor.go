@@ -0,0 +1,76 @@
+package grpcclient
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var errMethodNotFound = errors.New("grpc method not found in descriptor sets")
+
+// LoadDescriptorSets reads protobuf descriptor set files, as produced by
+// `protoc --include_imports --descriptor_set_out`, and index all the
+// files declared on them.
+func LoadDescriptorSets(paths []string) (*protoregistry.Files, error) {
+	fds := &descriptorpb.FileDescriptorSet{}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read descriptor set %s", path)
+		}
+
+		var set descriptorpb.FileDescriptorSet
+		if err := proto.Unmarshal(data, &set); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal descriptor set %s", path)
+		}
+
+		fds.File = append(fds.File, set.File...)
+	}
+
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build descriptor registry")
+	}
+
+	return files, nil
+}
+
+// findMethod resolves a method full name in the form
+// "/package.Service/Method" into its descriptor.
+func findMethod(files *protoregistry.Files, fullMethod string) (protoreflect.MethodDescriptor, error) {
+	fullMethod = strings.Trim(fullMethod, "/")
+
+	i := strings.LastIndex(fullMethod, "/")
+	if i < 0 {
+		return nil, errors.Wrapf(errMethodNotFound, "invalid method name %s", fullMethod)
+	}
+
+	serviceName, methodName := fullMethod[:i], fullMethod[i+1:]
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, errors.Wrapf(errMethodNotFound, "service %s", serviceName)
+	}
+
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errors.Wrapf(errMethodNotFound, "%s is not a service", serviceName)
+	}
+
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, errors.Wrapf(errMethodNotFound, "method %s on service %s", methodName, serviceName)
+	}
+
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, errors.Errorf("streaming method not supported: %s", fullMethod)
+	}
+
+	return method, nil
+}
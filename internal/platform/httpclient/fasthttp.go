@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/decoder"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/pkg/errors"
@@ -28,10 +29,11 @@ type fastHTTPClient struct {
 	client       *fasthttp.Client
 	log          restql.Logger
 	lifecycle    plugins.Lifecycle
+	decoders     decoder.Registry
 	responsePool *sync.Pool
 }
 
-func newFastHTTPClient(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config) *fastHTTPClient {
+func newFastHTTPClient(log restql.Logger, pm plugins.Lifecycle, decoders decoder.Registry, cfg *conf.Config) *fastHTTPClient {
 	clientCfg := cfg.HTTP.Client
 
 	r := &dnscache.Resolver{}
@@ -83,11 +85,11 @@ func newFastHTTPClient(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config
 		MaxConnWaitTimeout:            clientCfg.ConnTimeout,
 	}
 
-	return &fastHTTPClient{client: c, log: log, lifecycle: pm, responsePool: rp}
+	return &fastHTTPClient{client: c, log: log, lifecycle: pm, decoders: decoders, responsePool: rp}
 }
 
 func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
-	requestCtx := hc.lifecycle.BeforeRequest(ctx, request)
+	requestCtx, request := hc.lifecycle.BeforeRequest(ctx, request)
 
 	c := hc.responsePool.Get().(chan httpResult)
 
@@ -124,7 +126,7 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 		fasthttp.ReleaseResponse(hr.response)
 
 		err := fmt.Errorf("%w: %s", hr.err, domain.ErrRequestTimeout)
-		hc.lifecycle.AfterRequest(requestCtx, request, response, err)
+		_, response = hc.lifecycle.AfterRequest(requestCtx, request, response, err)
 
 		return response, domain.ErrRequestTimeout
 	case hr.err != nil:
@@ -134,12 +136,12 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 			fasthttp.ReleaseResponse(hr.response)
 		}
 
-		hc.lifecycle.AfterRequest(requestCtx, request, response, hr.err)
+		_, response = hc.lifecycle.AfterRequest(requestCtx, request, response, hr.err)
 
 		return response, errors.Wrap(hr.err, "request execution failed")
 	}
 
-	body, err := unmarshalBody(hc.log, hr.response)
+	body, err := unmarshalBody(hc.log, hc.decoders, hr.response)
 	if err != nil {
 		hc.log.Error("invalid json as body", err, "url", hr.target, "body", body.Unmarshal(), "statusCode", hr.response.StatusCode())
 	}
@@ -154,7 +156,7 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 
 	fasthttp.ReleaseResponse(hr.response)
 
-	hc.lifecycle.AfterRequest(requestCtx, request, response, hr.err)
+	_, response = hc.lifecycle.AfterRequest(requestCtx, request, response, hr.err)
 
 	return response, nil
 }
@@ -1,22 +1,28 @@
 package httpclient
 
 import (
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/decoder"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/valyala/fasthttp"
 	"time"
 )
 
-func unmarshalBody(log restql.Logger, response *fasthttp.Response) (*restql.ResponseBody, error) {
+func unmarshalBody(log restql.Logger, decoders decoder.Registry, response *fasthttp.Response) (*restql.ResponseBody, error) {
 	bodyByte := response.Body()
 	bb := make([]byte, len(bodyByte))
 	copy(bb, bodyByte)
 
 	rb := restql.NewResponseBodyFromBytes(log, bb)
-	if !rb.Valid() {
-		return rb, errInvalidJSON
+	if rb.Valid() {
+		return rb, nil
 	}
 
-	return rb, nil
+	contentType := string(response.Header.ContentType())
+	if d, found := decoders.ForContentType(contentType); found {
+		return restql.NewDecodableResponseBody(log, bb, d), nil
+	}
+
+	return rb, errInvalidJSON
 }
 
 func readHeaders(res *fasthttp.Response) restql.Headers {
@@ -0,0 +1,53 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+type router struct {
+	defaultClient domain.HTTPClient
+	transports    map[string]domain.HTTPClient
+}
+
+// NewRouter constructs an HTTPClient that delegates each request
+// to the transport registered for the request schema, using the
+// default client when there is none.
+func NewRouter(defaultClient domain.HTTPClient, transports map[string]domain.HTTPClient) domain.HTTPClient {
+	if len(transports) == 0 {
+		return defaultClient
+	}
+
+	return router{defaultClient: defaultClient, transports: transports}
+}
+
+func (r router) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	if transport, found := r.transports[request.Schema]; found {
+		return transport.Do(ctx, request)
+	}
+
+	return r.defaultClient.Do(ctx, request)
+}
+
+// Close closes the registered transports that hold resources,
+// like the gRPC client connections, once for each transport.
+func (r router) Close() error {
+	var firstErr error
+	closed := make(map[io.Closer]bool)
+	for _, transport := range r.transports {
+		closer, ok := transport.(io.Closer)
+		if !ok || closed[closer] {
+			continue
+		}
+
+		closed[closer] = true
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -3,6 +3,7 @@ package logger
 import (
 	"fmt"
 	"io"
+	"sync/atomic"
 
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/rs/zerolog"
@@ -19,6 +20,26 @@ type LogOptions struct {
 
 type zeroLogger struct {
 	zLogger zerolog.Logger
+	level   *levelSwitch
+}
+
+// levelSwitch holds the minimum level shared by a logger and
+// the ones derived from it, allowing it to change at runtime.
+type levelSwitch struct {
+	level    int32
+	disabled bool
+}
+
+func (ls *levelSwitch) get() zerolog.Level {
+	return zerolog.Level(atomic.LoadInt32(&ls.level))
+}
+
+func (ls *levelSwitch) set(level zerolog.Level) {
+	if ls.disabled {
+		return
+	}
+
+	atomic.StoreInt32(&ls.level, int32(level))
 }
 
 // New constructs a zeroLogger instance.
@@ -39,20 +60,51 @@ func New(w io.Writer, options LogOptions) restql.Logger {
 		zerolog.TimeFieldFormat = options.TimestampFieldFormat
 	}
 
+	ls := &levelSwitch{level: int32(logger.GetLevel()), disabled: !options.Enable}
+
 	level, err := zerolog.ParseLevel(options.Level)
 	if err == nil {
-		logger = logger.Level(level)
+		ls.set(level)
 	}
 
 	if !options.Enable {
-		logger.Level(zerolog.Disabled)
+		ls.level = int32(zerolog.Disabled)
 	}
 
-	return &zeroLogger{zLogger: logger}
+	return &zeroLogger{zLogger: logger, level: ls}
+}
+
+// ValidateLevel returns an error if the level name is unknown.
+func ValidateLevel(level string) error {
+	_, err := zerolog.ParseLevel(level)
+	return err
+}
+
+// SetLevel changes the minimum level of a logger created by New
+// and of all loggers derived from it. It has no effect when
+// logging is disabled or on loggers of other implementations.
+func SetLevel(log restql.Logger, level string) error {
+	zl, ok := log.(*zeroLogger)
+	if !ok {
+		return nil
+	}
+
+	l, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	zl.level.set(l)
+	return nil
+}
+
+func (zl *zeroLogger) logger() *zerolog.Logger {
+	l := zl.zLogger.Level(zl.level.get())
+	return &l
 }
 
 func (zl *zeroLogger) Panic(msg string, fields ...interface{}) {
-	entry := zl.zLogger.Panic()
+	entry := zl.logger().Panic()
 	fieldMap := makeFieldMap(fields)
 
 	entry.Fields(fieldMap).Msg(msg)
@@ -61,36 +113,36 @@ func (zl *zeroLogger) Panic(msg string, fields ...interface{}) {
 func (zl *zeroLogger) Fatal(msg string, fields ...interface{}) {
 	fieldMap := makeFieldMap(fields)
 
-	zl.zLogger.Fatal().Fields(fieldMap).Msg(msg)
+	zl.logger().Fatal().Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) Error(msg string, err error, fields ...interface{}) {
 	fieldMap := makeFieldMap(fields)
 
-	zl.zLogger.Error().Err(err).Fields(fieldMap).Msg(msg)
+	zl.logger().Error().Err(err).Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) Warn(msg string, fields ...interface{}) {
 	fieldMap := makeFieldMap(fields)
 
-	zl.zLogger.Warn().Fields(fieldMap).Msg(msg)
+	zl.logger().Warn().Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) Info(msg string, fields ...interface{}) {
 	fieldMap := makeFieldMap(fields)
 
-	zl.zLogger.Info().Fields(fieldMap).Msg(msg)
+	zl.logger().Info().Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) Debug(msg string, fields ...interface{}) {
 	fieldMap := makeFieldMap(fields)
 
-	zl.zLogger.Debug().Fields(fieldMap).Msg(msg)
+	zl.logger().Debug().Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) With(key string, value interface{}) restql.Logger {
 	cl := zl.zLogger.With().Str(key, fmt.Sprintf("%v", value)).Logger()
-	return &zeroLogger{zLogger: cl}
+	return &zeroLogger{zLogger: cl, level: zl.level}
 }
 
 func makeFieldMap(fields []interface{}) map[string]interface{} {
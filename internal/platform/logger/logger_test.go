@@ -0,0 +1,45 @@
+package logger_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/logger"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestSetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.New(&buf, logger.LogOptions{Enable: true, Level: "info"})
+	derived := log.With("tenant", "default")
+
+	log.Debug("first debug")
+	derived.Info("first info")
+
+	err := logger.SetLevel(log, "debug")
+	test.VerifyError(t, err)
+
+	derived.Debug("second debug")
+
+	err = logger.SetLevel(log, "verbose")
+	if err == nil {
+		t.Errorf("expected an error for unknown level")
+	}
+
+	output := buf.String()
+	test.Equal(t, strings.Contains(output, "first debug"), false)
+	test.Equal(t, strings.Contains(output, "first info"), true)
+	test.Equal(t, strings.Contains(output, "second debug"), true)
+}
+
+func TestSetLevel_Disabled(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.New(&buf, logger.LogOptions{Enable: false, Level: "info"})
+
+	err := logger.SetLevel(log, "debug")
+	test.VerifyError(t, err)
+
+	log.Info("info")
+	test.Equal(t, buf.String(), "")
+}
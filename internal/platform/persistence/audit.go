@@ -0,0 +1,150 @@
+package persistence
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// maxAuditLineSize bounds the size of an event read from the
+// audit file, which can hold entire query revisions.
+const maxAuditLineSize = 16 * 1024 * 1024
+
+// AuditLog stores the audit events of the administrative API.
+type AuditLog interface {
+	Record(ctx context.Context, event restql.AuditEvent) error
+	Find(ctx context.Context, filter restql.AuditFilter) ([]restql.AuditEvent, error)
+}
+
+// NewAuditLog constructs an AuditLog appending the events to the
+// file on the given path or, without a path, storing them on the
+// database when it implements restql.AuditDatabasePlugin.
+// Otherwise, events are discarded and cannot be found.
+func NewAuditLog(log restql.Logger, db Database, filePath string) (AuditLog, error) {
+	if filePath != "" {
+		log.Info("recording admin audit events on file", "path", filePath)
+		return newFileAuditLog(log, filePath)
+	}
+
+	if adb, ok := db.(restql.AuditDatabasePlugin); ok {
+		log.Info("recording admin audit events on database", "database", db.Name())
+		return databaseAuditLog{db: adb}, nil
+	}
+
+	log.Info("admin audit log disabled")
+	return noOpAuditLog{}, nil
+}
+
+type databaseAuditLog struct {
+	db restql.AuditDatabasePlugin
+}
+
+func (d databaseAuditLog) Record(ctx context.Context, event restql.AuditEvent) error {
+	return d.db.CreateAuditEvent(ctx, event)
+}
+
+func (d databaseAuditLog) Find(ctx context.Context, filter restql.AuditFilter) ([]restql.AuditEvent, error) {
+	return d.db.FindAuditEvents(ctx, filter)
+}
+
+// fileAuditLog keeps the events in a JSON lines file, only
+// appending to it, so that it can be shipped or rotated
+// by external tools.
+type fileAuditLog struct {
+	log  restql.Logger
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileAuditLog(log restql.Logger, path string) (*fileAuditLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open audit file")
+	}
+
+	return &fileAuditLog{log: log, path: path, file: file}, nil
+}
+
+func (f *fileAuditLog) Record(ctx context.Context, event restql.AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err = f.file.Write(line)
+	return err
+}
+
+// Find scans the whole file, keeping the latest events matching
+// the filter, since they are appended in chronological order.
+// Malformed lines, like a partial write interrupted by a crash,
+// are logged and skipped.
+func (f *fileAuditLog) Find(ctx context.Context, filter restql.AuditFilter) ([]restql.AuditEvent, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = restql.AuditDefaultFindEventsLimit
+	}
+
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open audit file")
+	}
+	defer file.Close()
+
+	var matches []restql.AuditEvent
+
+	line := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxAuditLineSize)
+	for scanner.Scan() {
+		line++
+
+		var e restql.AuditEvent
+		err := json.Unmarshal(scanner.Bytes(), &e)
+		if err != nil {
+			f.log.Warn("skipping invalid event on audit file", "path", f.path, "line", line, "error", err)
+			continue
+		}
+
+		if !filter.Matches(e) {
+			continue
+		}
+
+		matches = append(matches, e)
+		if len(matches) > limit {
+			matches = matches[1:]
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read audit file")
+	}
+
+	events := make([]restql.AuditEvent, len(matches))
+	for i, e := range matches {
+		events[len(matches)-1-i] = e
+	}
+
+	return events, nil
+}
+
+type noOpAuditLog struct{}
+
+func (n noOpAuditLog) Record(ctx context.Context, event restql.AuditEvent) error {
+	return nil
+}
+
+func (n noOpAuditLog) Find(ctx context.Context, filter restql.AuditFilter) ([]restql.AuditEvent, error) {
+	return nil, restql.ErrAuditNotEnabled
+}
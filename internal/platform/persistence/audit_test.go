@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestFileAuditLog(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	auditLog, err := NewAuditLog(noOpLogger, noOpDatabase{}, path)
+	test.VerifyError(t, err)
+
+	start := time.Date(2021, time.March, 10, 12, 0, 0, 0, time.UTC)
+	events := []restql.AuditEvent{
+		{Timestamp: start, Actor: "ops", RequestID: "1", Action: restql.AuditMappingCreate, Tenant: "dc", Name: "hero", After: "http://hero.api"},
+		{Timestamp: start.Add(time.Minute), Actor: "ops", Action: restql.AuditMappingUpdate, Tenant: "dc", Name: "hero", Before: "http://hero.api", After: "http://heroes.api"},
+		{Timestamp: start.Add(2 * time.Minute), Actor: "checkout", Action: restql.AuditRevisionCreate, Namespace: "checkout", Name: "cart", Revision: 1, After: "from cart"},
+	}
+	for _, e := range events {
+		test.VerifyError(t, auditLog.Record(ctx, e))
+	}
+
+	tests := []struct {
+		name     string
+		filter   restql.AuditFilter
+		expected []restql.AuditEvent
+	}{
+		{"should return newest first", restql.AuditFilter{}, []restql.AuditEvent{events[2], events[1], events[0]}},
+		{"should filter by actor", restql.AuditFilter{Actor: "ops"}, []restql.AuditEvent{events[1], events[0]}},
+		{"should filter by period", restql.AuditFilter{Since: start.Add(time.Minute)}, []restql.AuditEvent{events[2], events[1]}},
+		{"should keep latest events on limit", restql.AuditFilter{Tenant: "dc", Limit: 1}, []restql.AuditEvent{events[1]}},
+		{"should return empty", restql.AuditFilter{Namespace: "unknown"}, []restql.AuditEvent{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reopened, err := NewAuditLog(noOpLogger, noOpDatabase{}, path)
+			test.VerifyError(t, err)
+
+			got, err := reopened.Find(ctx, tt.filter)
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestFileAuditLog_InvalidLine(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	auditLog, err := NewAuditLog(noOpLogger, noOpDatabase{}, path)
+	test.VerifyError(t, err)
+
+	first := restql.AuditEvent{Timestamp: time.Date(2021, time.March, 10, 12, 0, 0, 0, time.UTC), Actor: "ops", Action: restql.AuditTenantCreate, Tenant: "dc"}
+	test.VerifyError(t, auditLog.Record(ctx, first))
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	test.VerifyError(t, err)
+	_, err = file.WriteString(`{"timestamp":"2021-03-10T12:01:00Z","actor":"op` + "\n")
+	test.VerifyError(t, err)
+	test.VerifyError(t, file.Close())
+
+	second := restql.AuditEvent{Timestamp: first.Timestamp.Add(2 * time.Minute), Actor: "ops", Action: restql.AuditTenantDelete, Tenant: "dc"}
+	test.VerifyError(t, auditLog.Record(ctx, second))
+
+	got, err := auditLog.Find(ctx, restql.AuditFilter{})
+	test.VerifyError(t, err)
+	test.Equal(t, got, []restql.AuditEvent{second, first})
+}
+
+func TestNewAuditLog(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should use database implementing audit", func(t *testing.T) {
+		db := newSQLiteDatabase(t)
+
+		auditLog, err := NewAuditLog(noOpLogger, db, "")
+		test.VerifyError(t, err)
+
+		event := restql.AuditEvent{Timestamp: time.Now().UTC(), Actor: "ops", Action: restql.AuditTenantCreate, Tenant: "dc"}
+		test.VerifyError(t, auditLog.Record(ctx, event))
+
+		got, err := auditLog.Find(ctx, restql.AuditFilter{})
+		test.VerifyError(t, err)
+		test.Equal(t, len(got), 1)
+		test.Equal(t, got[0].Action, restql.AuditTenantCreate)
+	})
+
+	t.Run("should be disabled without file and audit database", func(t *testing.T) {
+		auditLog, err := NewAuditLog(noOpLogger, noOpDatabase{}, "")
+		test.VerifyError(t, err)
+
+		test.VerifyError(t, auditLog.Record(ctx, restql.AuditEvent{Action: restql.AuditTenantCreate}))
+
+		_, err = auditLog.Find(ctx, restql.AuditFilter{})
+		if !errors.Is(err, restql.ErrAuditNotEnabled) {
+			t.Errorf("got error = %v, want = %v", err, restql.ErrAuditNotEnabled)
+		}
+	})
+}
@@ -2,6 +2,10 @@ package persistence
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence/sqlite"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/pkg/errors"
 )
@@ -9,15 +13,29 @@ import (
 // Database defines the operations exposed by an external store.
 type Database restql.DatabasePlugin
 
+// DatabaseOptions define which Database is constructed.
+type DatabaseOptions struct {
+	Disabled   bool
+	SQLitePath string
+	Timeout    time.Duration
+}
+
 // NewDatabase constructs a Database compliant value
-// from the database plugin registered.
-// In case of no plugin, a noop implementation is returned.
-func NewDatabase(log restql.Logger, disabled bool) (Database, error) {
-	if disabled {
+// from the database plugin registered or, in case of
+// no plugin, from the built-in SQLite database when
+// a path is provided.
+// Otherwise, a noop implementation is returned.
+func NewDatabase(log restql.Logger, options DatabaseOptions) (Database, error) {
+	if options.Disabled {
 		return noOpDatabase{}, nil
 	}
 
 	pluginInfo, found := restql.GetDatabasePlugin()
+	if !found && options.SQLitePath != "" {
+		log.Info("using built-in sqlite database")
+		return sqlite.New(log, sqlite.Options{Path: options.SQLitePath, Timeout: options.Timeout})
+	}
+
 	if !found {
 		log.Info("no database plugin provided")
 		return noOpDatabase{}, nil
@@ -41,6 +59,17 @@ func NewDatabase(log restql.Logger, disabled bool) (Database, error) {
 	return database, nil
 }
 
+// adminDatabase returns the administrative operations of the database,
+// failing when it does not implement restql.AdminDatabasePlugin.
+func adminDatabase(db Database) (restql.AdminDatabasePlugin, error) {
+	adb, ok := db.(restql.AdminDatabasePlugin)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", restql.ErrDatabaseOperationNotSupported, db.Name())
+	}
+
+	return adb, nil
+}
+
 var errNoDatabase = errors.New("no op database")
 
 type noOpDatabase struct{}
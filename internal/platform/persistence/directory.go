@@ -0,0 +1,349 @@
+package persistence
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	queryFileExtension   = ".rql"
+	frontMatterDelimiter = "---"
+)
+
+// QueryDirectory is a store of saved queries read from a directory tree,
+// like a Git repository checkout, with the layouts:
+// • <namespace>/<query>/<revision>.rql: one file for each revision, named with its number.
+// • <namespace>/<query>.rql: all revisions in a single file, each one preceded
+// by a front matter defining its number, like `---\nrevision: 2\n---`.
+// A file without front matter is the revision 1 of the query.
+//
+// In both layouts the revisions must be numbered sequentially from 1,
+// otherwise the query is ignored.
+type QueryDirectory struct {
+	log  restql.Logger
+	path string
+
+	mu       sync.RWMutex
+	queries  map[string][]restql.SavedQuery
+	snapshot map[string]fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewQueryDirectory constructs a QueryDirectory
+// loading the queries found on the path.
+func NewQueryDirectory(log restql.Logger, path string) (*QueryDirectory, error) {
+	d := &QueryDirectory{log: log, path: path}
+
+	snapshot, err := d.takeSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	d.queries = d.load(snapshot)
+	d.snapshot = snapshot
+
+	return d, nil
+}
+
+// Queries returns the saved queries indexed by namespace.
+func (d *QueryDirectory) Queries() map[string][]restql.SavedQuery {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.queries
+}
+
+// Watch polls the directory on every interval, reloading
+// the queries when a file is created, changed or removed,
+// and calling onChange for each query that differs from the
+// previous load. It blocks until the context is done.
+func (d *QueryDirectory) Watch(ctx context.Context, interval time.Duration, onChange func(namespace, name string)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.reload(onChange)
+		}
+	}
+}
+
+func (d *QueryDirectory) reload(onChange func(namespace, name string)) {
+	snapshot, err := d.takeSnapshot()
+	if err != nil {
+		d.log.Error("failed to read query directory", err, "path", d.path)
+		return
+	}
+
+	d.mu.RLock()
+	unchanged := reflect.DeepEqual(snapshot, d.snapshot)
+	previous := d.queries
+	d.mu.RUnlock()
+
+	if unchanged {
+		return
+	}
+
+	queries := d.load(snapshot)
+
+	d.mu.Lock()
+	d.queries = queries
+	d.snapshot = snapshot
+	d.mu.Unlock()
+
+	changed := changedQueries(previous, queries)
+	d.log.Info("query directory reloaded", "path", d.path, "changed", len(changed))
+
+	for _, q := range changed {
+		onChange(q.Namespace, q.Name)
+	}
+}
+
+func (d *QueryDirectory) takeSnapshot() (map[string]fileState, error) {
+	snapshot := make(map[string]fileState)
+	err := filepath.Walk(d.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != d.path && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) != queryFileExtension {
+			return nil
+		}
+
+		rel, err := filepath.Rel(d.path, path)
+		if err != nil {
+			return err
+		}
+
+		snapshot[filepath.ToSlash(rel)] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func (d *QueryDirectory) load(snapshot map[string]fileState) map[string][]restql.SavedQuery {
+	revisionsByQuery := make(map[queryLocation]map[int]string)
+	filesByQuery := make(map[queryLocation][]string)
+
+	for file := range snapshot {
+		location, revisions, err := d.readFile(file)
+		if err != nil {
+			d.log.Error("failed to read query file", err, "path", d.path, "file", file)
+			continue
+		}
+
+		filesByQuery[location] = append(filesByQuery[location], file)
+		if revisionsByQuery[location] == nil {
+			revisionsByQuery[location] = make(map[int]string)
+		}
+
+		for revision, text := range revisions {
+			if _, found := revisionsByQuery[location][revision]; found {
+				d.log.Warn("duplicated query revision", "namespace", location.namespace, "name", location.name, "revision", revision, "files", filesByQuery[location])
+			}
+			revisionsByQuery[location][revision] = text
+		}
+	}
+
+	queries := make(map[string][]restql.SavedQuery)
+	for location, revisions := range revisionsByQuery {
+		q, err := newDirectoryQuery(location, revisions)
+		if err != nil {
+			d.log.Error("invalid query in directory", err, "namespace", location.namespace, "name", location.name, "files", filesByQuery[location])
+			continue
+		}
+
+		queries[location.namespace] = append(queries[location.namespace], q)
+	}
+
+	for _, namespaceQueries := range queries {
+		sort.Slice(namespaceQueries, func(i, j int) bool {
+			return namespaceQueries[i].Name < namespaceQueries[j].Name
+		})
+	}
+
+	return queries
+}
+
+type queryLocation struct {
+	namespace string
+	name      string
+}
+
+func (d *QueryDirectory) readFile(file string) (queryLocation, map[int]string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(d.path, filepath.FromSlash(file)))
+	if err != nil {
+		return queryLocation{}, nil, err
+	}
+
+	parts := strings.Split(strings.TrimSuffix(file, queryFileExtension), "/")
+	switch len(parts) {
+	case 2:
+		revisions, err := parseQueryFile(content)
+		if err != nil {
+			return queryLocation{}, nil, err
+		}
+
+		return queryLocation{namespace: parts[0], name: parts[1]}, revisions, nil
+	case 3:
+		revision, err := strconv.Atoi(parts[2])
+		if err != nil || revision < 1 {
+			return queryLocation{}, nil, errors.Errorf("revision file name must be a positive number: %s", file)
+		}
+
+		return queryLocation{namespace: parts[0], name: parts[1]}, map[int]string{revision: strings.TrimSpace(string(content))}, nil
+	default:
+		return queryLocation{}, nil, errors.Errorf("query file must be at <namespace>/<query>.rql or <namespace>/<query>/<revision>.rql: %s", file)
+	}
+}
+
+type frontMatter struct {
+	Revision int `yaml:"revision"`
+}
+
+// parseQueryFile reads the revisions of a single file query,
+// where each one is preceded by a front matter block.
+func parseQueryFile(content []byte) (map[int]string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	start := 0
+	for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+
+	if start == len(lines) || strings.TrimSpace(lines[start]) != frontMatterDelimiter {
+		return map[int]string{1: strings.TrimSpace(string(content))}, nil
+	}
+
+	revisions := make(map[int]string)
+	previous := 0
+	i := start
+	for i < len(lines) {
+		end := indexOfDelimiter(lines, i+1)
+		if end < 0 {
+			return nil, errors.Errorf("unclosed front matter at line %d", i+1)
+		}
+
+		var fm frontMatter
+		err := yaml.Unmarshal([]byte(strings.Join(lines[i+1:end], "\n")), &fm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid front matter at line %d", i+1)
+		}
+
+		revision := fm.Revision
+		if revision == 0 {
+			revision = previous + 1
+		}
+
+		if _, found := revisions[revision]; found {
+			return nil, errors.Errorf("duplicated revision %d at line %d", revision, i+1)
+		}
+
+		next := indexOfDelimiter(lines, end+1)
+		if next < 0 {
+			next = len(lines)
+		}
+
+		revisions[revision] = strings.TrimSpace(strings.Join(lines[end+1:next], "\n"))
+		previous = revision
+		i = next
+	}
+
+	return revisions, nil
+}
+
+func indexOfDelimiter(lines []string, from int) int {
+	for i := from; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == frontMatterDelimiter {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func newDirectoryQuery(location queryLocation, revisions map[int]string) (restql.SavedQuery, error) {
+	q := restql.SavedQuery{
+		Namespace: location.namespace,
+		Name:      location.name,
+		Revisions: make([]restql.SavedQueryRevision, len(revisions)),
+	}
+
+	for i := range q.Revisions {
+		text, found := revisions[i+1]
+		if !found {
+			return restql.SavedQuery{}, errors.Errorf("revisions must be numbered sequentially from 1, missing revision %d", i+1)
+		}
+
+		q.Revisions[i] = restql.SavedQueryRevision{
+			Name:     location.name,
+			Text:     text,
+			Revision: i + 1,
+			Source:   restql.FileSystemSource,
+		}
+	}
+
+	return q, nil
+}
+
+func changedQueries(previous, current map[string][]restql.SavedQuery) []restql.SavedQuery {
+	var changed []restql.SavedQuery
+	for namespace, queries := range current {
+		for _, q := range queries {
+			old, found := findQueryByName(previous[namespace], q.Name)
+			if !found || !reflect.DeepEqual(old, q) {
+				changed = append(changed, q)
+			}
+		}
+	}
+
+	for namespace, queries := range previous {
+		for _, q := range queries {
+			if _, found := findQueryByName(current[namespace], q.Name); !found {
+				changed = append(changed, q)
+			}
+		}
+	}
+
+	return changed
+}
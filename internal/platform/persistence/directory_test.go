@@ -0,0 +1,211 @@
+package persistence
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestQueryDirectory_Load(t *testing.T) {
+	dir := t.TempDir()
+	writeQueryFile(t, dir, "heroes/hero/1.rql", "from hero")
+	writeQueryFile(t, dir, "heroes/hero/2.rql", "from hero\nwith id = $id\n")
+	writeQueryFile(t, dir, "heroes/sidekick.rql", "from sidekick")
+	writeQueryFile(t, dir, "villains/villain.rql", `---
+revision: 1
+---
+from villain
+---
+revision: 2
+---
+from villain
+with id = $id
+`)
+	writeQueryFile(t, dir, "villains/henchman/1.rql", "from henchman")
+	writeQueryFile(t, dir, "villains/henchman/3.rql", "from henchman with id = $id")
+	writeQueryFile(t, dir, "villains/README.md", "not a query")
+	writeQueryFile(t, dir, ".git/queries/ignored.rql", "from ignored")
+
+	d, err := NewQueryDirectory(noOpLogger, dir)
+	test.VerifyError(t, err)
+
+	expected := map[string][]restql.SavedQuery{
+		"heroes": {
+			{
+				Namespace: "heroes",
+				Name:      "hero",
+				Revisions: []restql.SavedQueryRevision{
+					{Name: "hero", Text: "from hero", Revision: 1, Source: restql.FileSystemSource},
+					{Name: "hero", Text: "from hero\nwith id = $id", Revision: 2, Source: restql.FileSystemSource},
+				},
+			},
+			{
+				Namespace: "heroes",
+				Name:      "sidekick",
+				Revisions: []restql.SavedQueryRevision{
+					{Name: "sidekick", Text: "from sidekick", Revision: 1, Source: restql.FileSystemSource},
+				},
+			},
+		},
+		"villains": {
+			{
+				Namespace: "villains",
+				Name:      "villain",
+				Revisions: []restql.SavedQueryRevision{
+					{Name: "villain", Text: "from villain", Revision: 1, Source: restql.FileSystemSource},
+					{Name: "villain", Text: "from villain\nwith id = $id", Revision: 2, Source: restql.FileSystemSource},
+				},
+			},
+		},
+	}
+
+	test.Equal(t, d.Queries(), expected)
+}
+
+func TestParseQueryFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected map[int]string
+		err      bool
+	}{
+		{
+			"file without front matter is the first revision",
+			"\nfrom hero\n",
+			map[int]string{1: "from hero"},
+			false,
+		},
+		{
+			"front matter without revision follows the previous one",
+			"---\n---\nfrom hero\n---\n---\nfrom hero with id = 1\n",
+			map[int]string{1: "from hero", 2: "from hero with id = 1"},
+			false,
+		},
+		{
+			"front matter with revision",
+			"---\nrevision: 2\n---\nfrom hero with id = 1\n---\nrevision: 1\n---\nfrom hero\n",
+			map[int]string{1: "from hero", 2: "from hero with id = 1"},
+			false,
+		},
+		{
+			"duplicated revision",
+			"---\nrevision: 1\n---\nfrom hero\n---\nrevision: 1\n---\nfrom hero\n",
+			nil,
+			true,
+		},
+		{
+			"unclosed front matter",
+			"---\nrevision: 1\nfrom hero\n",
+			nil,
+			true,
+		},
+		{
+			"invalid front matter",
+			"---\nrevision: one\n---\nfrom hero\n",
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseQueryFile([]byte(tt.content))
+			if tt.err {
+				if err == nil {
+					t.Fatalf("expected an error but got none")
+				}
+				return
+			}
+
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestQueryDirectory_Reload(t *testing.T) {
+	dir := t.TempDir()
+	writeQueryFile(t, dir, "heroes/hero.rql", "from hero")
+	writeQueryFile(t, dir, "heroes/sidekick.rql", "from sidekick")
+
+	d, err := NewQueryDirectory(noOpLogger, dir)
+	test.VerifyError(t, err)
+
+	var changed []string
+	onChange := func(namespace, name string) {
+		changed = append(changed, namespace+"/"+name)
+	}
+
+	d.reload(onChange)
+	test.Equal(t, len(changed), 0)
+
+	writeQueryFile(t, dir, "heroes/hero.rql", "---\n---\nfrom hero\n---\n---\nfrom hero with id = $id\n")
+	d.reload(onChange)
+	test.Equal(t, changed, []string{"heroes/hero"})
+
+	changed = nil
+	err = os.Remove(filepath.Join(dir, "heroes", "sidekick.rql"))
+	test.VerifyError(t, err)
+	d.reload(onChange)
+	test.Equal(t, changed, []string{"heroes/sidekick"})
+
+	qr := NewQueryReader(noOpLogger, nil, noOpDatabase{}).WithDirectory(d)
+
+	got, err := qr.Get(context.Background(), "heroes", "hero", 2)
+	test.VerifyError(t, err)
+	test.Equal(t, got, restql.SavedQueryRevision{Name: "hero", Text: "from hero with id = $id", Revision: 2, Source: restql.FileSystemSource})
+
+	_, err = qr.Get(context.Background(), "heroes", "sidekick", 1)
+	if err != restql.ErrQueryNotFound {
+		t.Errorf("got error = %v, want = %v", err, restql.ErrQueryNotFound)
+	}
+}
+
+func TestQueryReader_DirectoryPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	writeQueryFile(t, dir, "heroes/hero.rql", "from hero with id = 1")
+
+	d, err := NewQueryDirectory(noOpLogger, dir)
+	test.VerifyError(t, err)
+
+	local := map[string]map[string][]string{
+		"heroes": {
+			"hero":     {"from hero"},
+			"sidekick": {"from sidekick"},
+		},
+	}
+	qr := NewQueryReader(noOpLogger, local, noOpDatabase{}).WithDirectory(d)
+
+	hero, err := qr.Get(context.Background(), "heroes", "hero", 1)
+	test.VerifyError(t, err)
+	test.Equal(t, hero, restql.SavedQueryRevision{Name: "hero", Text: "from hero with id = 1", Revision: 1, Source: restql.FileSystemSource})
+
+	sidekick, err := qr.Get(context.Background(), "heroes", "sidekick", 1)
+	test.VerifyError(t, err)
+	test.Equal(t, sidekick, restql.SavedQueryRevision{Name: "sidekick", Text: "from sidekick", Revision: 1, Source: restql.ConfigFileSource})
+
+	qw := NewQueryWriter(noOpLogger, nil, noOpDatabase{}).WithDirectory(d)
+	err = qw.Write(context.Background(), "heroes", "hero", "from hero")
+	if err != ErrUpdateQueryNotAllowed {
+		t.Errorf("got error = %v, want = %v", err, ErrUpdateQueryNotAllowed)
+	}
+}
+
+func writeQueryFile(t *testing.T, dir string, name string, content string) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("failed to create directory: %s", err)
+	}
+
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("failed to write query file: %s", err)
+	}
+}
@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"sync/atomic"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// localMappings holds the mappings from the configuration file,
+// shared by the copies of a reader or writer and replaced when
+// the file is reloaded.
+type localMappings struct {
+	v atomic.Value
+}
+
+func newLocalMappings(log restql.Logger, local map[string]map[string]string) *localMappings {
+	lm := &localMappings{}
+	lm.set(log, local)
+	return lm
+}
+
+func (lm *localMappings) get() map[string]map[string]restql.Mapping {
+	return lm.v.Load().(map[string]map[string]restql.Mapping)
+}
+
+func (lm *localMappings) set(log restql.Logger, local map[string]map[string]string) {
+	mappings := make(map[string]map[string]restql.Mapping)
+	for t, m := range local {
+		mappings[t] = parseMappingsFromLocal(log, m)
+	}
+
+	lm.v.Store(mappings)
+}
+
+// localQueries holds the queries from the configuration file,
+// shared by the copies of a reader or writer and replaced when
+// the file is reloaded.
+type localQueries struct {
+	v atomic.Value
+}
+
+func newLocalQueries(local map[string]map[string][]string) *localQueries {
+	lq := &localQueries{}
+	lq.set(local)
+	return lq
+}
+
+func (lq *localQueries) get() map[string][]restql.SavedQuery {
+	return lq.v.Load().(map[string][]restql.SavedQuery)
+}
+
+func (lq *localQueries) set(local map[string]map[string][]string) {
+	lq.v.Store(parseLocalQueries(local))
+}
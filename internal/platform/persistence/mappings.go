@@ -18,26 +18,28 @@ var envMappingWithTenantRegex = regexp.MustCompile(`^RESTQL_MAPPING_(\w+)_(\w+)$
 type MappingsReader struct {
 	log           restql.Logger
 	env           map[string]map[string]restql.Mapping
-	localByTenant map[string]map[string]restql.Mapping
+	localByTenant *localMappings
 	db            Database
 }
 
 // NewMappingReader constructs a MappingsReader instance.
 func NewMappingReader(log restql.Logger, env domain.EnvSource, local map[string]map[string]string, db Database) MappingsReader {
 	envWithTenantMappings := getMappingsFromEnv(log, env)
-	localMappings := make(map[string]map[string]restql.Mapping)
-	for t, m := range local {
-		localMappings[t] = parseMappingsFromLocal(log, m)
-	}
 
-	return MappingsReader{log: log, env: envWithTenantMappings, localByTenant: localMappings, db: db}
+	return MappingsReader{log: log, env: envWithTenantMappings, localByTenant: newLocalMappings(log, local), db: db}
+}
+
+// SetLocal replaces the mappings from the configuration file
+// on the reader and all its copies.
+func (mr MappingsReader) SetLocal(local map[string]map[string]string) {
+	mr.localByTenant.set(mr.log, local)
 }
 
 // ListTenants fetch all tenants under which mappings are organized
 func (mr MappingsReader) ListTenants(ctx context.Context) ([]string, error) {
 	tenantSet := make(map[string]struct{})
 
-	for tenant := range mr.localByTenant {
+	for tenant := range mr.localByTenant.get() {
 		tenantSet[tenant] = struct{}{}
 	}
 
@@ -73,7 +75,7 @@ func (mr MappingsReader) FromTenant(ctx context.Context, tenant string) (map[str
 
 	result := make(map[string]restql.Mapping)
 
-	localTenantMappings, found := mr.localByTenant[tenant]
+	localTenantMappings, found := mr.localByTenant.get()[tenant]
 	if found {
 		for k, v := range localTenantMappings {
 			result[k] = v
@@ -125,23 +127,28 @@ func (mr MappingsReader) applyEnvMappings(result map[string]restql.Mapping, tena
 // ErrSetResourceMappingNotAllowed is returned when trying to write a resource mapping on a resource stored on local or env.
 var ErrSetResourceMappingNotAllowed = errors.New("a resource mapping must have a source of type database in order to provide writing operations")
 
+// ErrSetTenantNotAllowed is returned when trying to delete a tenant with mappings stored on local or env.
+var ErrSetTenantNotAllowed = errors.New("a tenant with mappings on local or env cannot be deleted")
+
 // MappingsWriter is the entity that maps resource name to URL.
 type MappingsWriter struct {
 	log   restql.Logger
 	db    Database
 	env   map[string]map[string]restql.Mapping
-	local map[string]map[string]restql.Mapping
+	local *localMappings
 }
 
 // NewMappingWriter creates an instance of MappingsWriter
 func NewMappingWriter(log restql.Logger, env domain.EnvSource, local map[string]map[string]string, db Database) MappingsWriter {
 	envMappings := getMappingsFromEnv(log, env)
-	localMappings := make(map[string]map[string]restql.Mapping)
-	for t, m := range local {
-		localMappings[t] = parseMappingsFromLocal(log, m)
-	}
 
-	return MappingsWriter{log: log, env: envMappings, local: localMappings, db: db}
+	return MappingsWriter{log: log, env: envMappings, local: newLocalMappings(log, local), db: db}
+}
+
+// SetLocal replaces the mappings from the configuration file
+// on the writer and all its copies.
+func (mw *MappingsWriter) SetLocal(local map[string]map[string]string) {
+	mw.local.set(mw.log, local)
 }
 
 // Create makes a new mapping from the name to the URL on the given tenant
@@ -160,8 +167,60 @@ func (mw *MappingsWriter) Update(ctx context.Context, tenant string, resource st
 	return mw.db.SetMapping(ctx, tenant, resource, url)
 }
 
+// Delete removes the mapping of the resource name under the given tenant
+func (mw *MappingsWriter) Delete(ctx context.Context, tenant string, resource string) error {
+	if !mw.allowWrite(tenant, resource) {
+		log := restql.GetLogger(ctx)
+		log.Error("delete operation on resource mapping not allowed", ErrSetResourceMappingNotAllowed, "tenant", tenant, "resource", resource)
+		return ErrSetResourceMappingNotAllowed
+	}
+
+	adb, err := adminDatabase(mw.db)
+	if err != nil {
+		return err
+	}
+
+	return adb.DeleteMapping(ctx, tenant, resource)
+}
+
+// CreateTenant makes a new tenant without mappings
+func (mw *MappingsWriter) CreateTenant(ctx context.Context, tenant string) error {
+	adb, err := adminDatabase(mw.db)
+	if err != nil {
+		return err
+	}
+
+	return adb.CreateTenant(ctx, tenant)
+}
+
+// DeleteTenant removes the tenant and all its mappings
+func (mw *MappingsWriter) DeleteTenant(ctx context.Context, tenant string) error {
+	if !mw.allowTenantWrite(tenant) {
+		log := restql.GetLogger(ctx)
+		log.Error("delete operation on tenant not allowed", ErrSetTenantNotAllowed, "tenant", tenant)
+		return ErrSetTenantNotAllowed
+	}
+
+	adb, err := adminDatabase(mw.db)
+	if err != nil {
+		return err
+	}
+
+	return adb.DeleteTenant(ctx, tenant)
+}
+
+func (mw *MappingsWriter) allowTenantWrite(tenant string) bool {
+	_, found := mw.local.get()[tenant]
+	if found {
+		return false
+	}
+
+	_, found = mw.env[tenant]
+	return !found
+}
+
 func (mw *MappingsWriter) allowWrite(tenant string, resourceName string) bool {
-	localTenantMappings, found := mw.local[tenant]
+	localTenantMappings, found := mw.local.get()[tenant]
 	if found {
 		for resource := range localTenantMappings {
 			if resource == resourceName {
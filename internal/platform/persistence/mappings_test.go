@@ -2,12 +2,14 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"io/ioutil"
 	"testing"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/logger"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence/sqlite"
 	"github.com/b2wdigital/restQL-golang/v6/test"
 )
 
@@ -203,3 +205,55 @@ func (s stubEnvSource) GetString(key string) string {
 func (s stubEnvSource) GetAll() map[string]string {
 	return s.getAll
 }
+
+func TestMappingsWriter_Delete(t *testing.T) {
+	ctx := context.Background()
+	envSource := stubEnvSource{getAll: map[string]string{"RESTQL_MAPPING_envtenant_HERO": "http://hero.api/"}}
+	local := map[string]map[string]string{mytenant: {"hero": "http://hero.api/"}}
+
+	db := newSQLiteDatabase(t)
+	test.VerifyError(t, db.CreateMapping(ctx, mytenant, "sidekick", "http://sidekick.api/"))
+	test.VerifyError(t, db.CreateTenant(ctx, "dbtenant"))
+
+	writer := NewMappingWriter(noOpLogger, envSource, local, db)
+
+	tests := []struct {
+		name     string
+		delete   func() error
+		expected error
+	}{
+		{"should delete database mapping", func() error { return writer.Delete(ctx, mytenant, "sidekick") }, nil},
+		{"should not delete local mapping", func() error { return writer.Delete(ctx, mytenant, "hero") }, ErrSetResourceMappingNotAllowed},
+		{"should delete database tenant", func() error { return writer.DeleteTenant(ctx, "dbtenant") }, nil},
+		{"should not delete tenant with local mappings", func() error { return writer.DeleteTenant(ctx, mytenant) }, ErrSetTenantNotAllowed},
+		{"should not delete tenant with env mappings", func() error { return writer.DeleteTenant(ctx, "envtenant") }, ErrSetTenantNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.delete()
+			if err != tt.expected {
+				t.Errorf("got error = %v, want = %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMappingsWriter_DatabaseOperationNotSupported(t *testing.T) {
+	writer := NewMappingWriter(noOpLogger, stubEnvSource{getAll: map[string]string{}}, nil, noOpDatabase{})
+
+	err := writer.CreateTenant(context.Background(), mytenant)
+	if !errors.Is(err, restql.ErrDatabaseOperationNotSupported) {
+		t.Errorf("got error = %v, want = %v", err, restql.ErrDatabaseOperationNotSupported)
+	}
+}
+
+func newSQLiteDatabase(t *testing.T) *sqlite.Database {
+	db, err := sqlite.New(noOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
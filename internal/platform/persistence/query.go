@@ -7,18 +7,33 @@ import (
 	"github.com/pkg/errors"
 )
 
-// A QueryReader get a query from local configuration file
-// or a database instance.
+// A QueryReader get a query from local configuration file,
+// a query directory or a database instance.
 type QueryReader struct {
 	log   restql.Logger
-	local map[string][]restql.SavedQuery
+	local *localQueries
+	dir   *QueryDirectory
 	db    Database
 }
 
 // NewQueryReader constructs a QueryReader from the given
 // configuration and database.
 func NewQueryReader(log restql.Logger, local map[string]map[string][]string, db Database) QueryReader {
-	return QueryReader{log: log, local: parseLocalQueries(local), db: db}
+	return QueryReader{log: log, local: newLocalQueries(local), db: db}
+}
+
+// SetLocal replaces the queries from the configuration file
+// on the reader and all its copies.
+func (qr QueryReader) SetLocal(local map[string]map[string][]string) {
+	qr.local.set(local)
+}
+
+// WithDirectory returns a QueryReader that also reads the queries
+// from the directory, which take precedence over the ones with
+// the same name in the configuration file.
+func (qr QueryReader) WithDirectory(dir *QueryDirectory) QueryReader {
+	qr.dir = dir
+	return qr
 }
 
 // Get retrieves a query by its identity (namespace, id and revision),
@@ -61,8 +76,8 @@ func (qr QueryReader) Get(ctx context.Context, namespace, id string, revision in
 }
 
 func (qr QueryReader) getQueryFromLocal(namespace string, id string, revision int) (restql.SavedQueryRevision, error) {
-	queriesInNamespace, ok := qr.local[namespace]
-	if !ok {
+	queriesInNamespace := mergeLocalQueries(qr.local.get(), qr.dir, namespace)
+	if len(queriesInNamespace) == 0 {
 		return restql.SavedQueryRevision{}, errors.Errorf("namespace not found in local: %s", namespace)
 	}
 
@@ -91,10 +106,16 @@ func (qr QueryReader) getQueryFromLocal(namespace string, id string, revision in
 func (qr QueryReader) ListNamespaces(ctx context.Context) ([]string, error) {
 	namespaceSet := make(map[string]struct{})
 
-	for namespace := range qr.local {
+	for namespace := range qr.local.get() {
 		namespaceSet[namespace] = struct{}{}
 	}
 
+	if qr.dir != nil {
+		for namespace := range qr.dir.Queries() {
+			namespaceSet[namespace] = struct{}{}
+		}
+	}
+
 	dbNamespaces, err := qr.db.FindAllNamespaces(ctx)
 	if err != nil {
 		log := restql.GetLogger(ctx)
@@ -120,7 +141,7 @@ func (qr QueryReader) ListNamespaces(ctx context.Context) ([]string, error) {
 func (qr QueryReader) ListQueriesForNamespace(ctx context.Context, namespace string, archived bool) ([]restql.SavedQuery, error) {
 	var queries []restql.SavedQuery
 	if !archived {
-		queries = qr.local[namespace]
+		queries = mergeLocalQueries(qr.local.get(), qr.dir, namespace)
 	}
 
 	dbQueries, err := qr.db.FindQueriesForNamespace(ctx, namespace, archived)
@@ -153,7 +174,7 @@ func (qr QueryReader) ListQueriesForNamespace(ctx context.Context, namespace str
 // stored on the config file, env or database.
 func (qr QueryReader) ListQueryRevisions(ctx context.Context, namespace string, queryName string, archived bool) (restql.SavedQuery, error) {
 	var localQuery restql.SavedQuery
-	q, found := findQueryByName(qr.local[namespace], queryName)
+	q, found := findQueryByName(mergeLocalQueries(qr.local.get(), qr.dir, namespace), queryName)
 	if found && !archived {
 		localQuery = q
 	}
@@ -196,14 +217,19 @@ func setDatabaseSource(r []restql.SavedQueryRevision) []restql.SavedQueryRevisio
 }
 
 // ErrUpdateQueryNotAllowed is returned when trying to write a query revision
-// on a query stored on local or env.
+// on a query stored on local, env or a query directory.
 var ErrUpdateQueryNotAllowed = errors.New("a local query cannot be updated : remove it from the local configuration or migrate it to the database")
 
+// ErrUpdateNamespaceNotAllowed is returned when trying to rename or delete
+// a namespace with queries stored on local, env or a query directory.
+var ErrUpdateNamespaceNotAllowed = errors.New("a namespace with local queries cannot be updated : remove them from the local configuration or migrate them to the database")
+
 // QueryWriter is the entity that create a new query revision
 // when it is stored on the database.
 type QueryWriter struct {
 	log   restql.Logger
-	local map[string][]restql.SavedQuery
+	local *localQueries
+	dir   *QueryDirectory
 	db    Database
 }
 
@@ -211,11 +237,24 @@ type QueryWriter struct {
 func NewQueryWriter(log restql.Logger, local map[string]map[string][]string, db Database) QueryWriter {
 	return QueryWriter{
 		log:   log,
-		local: parseLocalQueries(local),
+		local: newLocalQueries(local),
 		db:    db,
 	}
 }
 
+// SetLocal replaces the queries from the configuration file
+// on the writer and all its copies.
+func (qw QueryWriter) SetLocal(local map[string]map[string][]string) {
+	qw.local.set(local)
+}
+
+// WithDirectory returns a QueryWriter that also refuses
+// to write the queries stored on the directory.
+func (qw QueryWriter) WithDirectory(dir *QueryDirectory) QueryWriter {
+	qw.dir = dir
+	return qw
+}
+
 // Write creates a new query revision
 func (qw QueryWriter) Write(ctx context.Context, namespace, name, content string) error {
 	if !qw.allowWrite(namespace, name) {
@@ -251,16 +290,95 @@ func (qw QueryWriter) UpdateRevisionArchiving(ctx context.Context, namespace str
 	return err
 }
 
-func (qw QueryWriter) allowWrite(namespace string, name string) bool {
-	namespaceQueries, found := qw.local[namespace]
-	if !found {
-		return true
+// RenameNamespace moves all queries of the namespace to a new one
+func (qw QueryWriter) RenameNamespace(ctx context.Context, namespace string, newNamespace string) error {
+	if !qw.allowNamespaceWrite(namespace) || !qw.allowNamespaceWrite(newNamespace) {
+		return ErrUpdateNamespaceNotAllowed
 	}
 
-	_, found = findQueryByName(namespaceQueries, name)
+	adb, err := adminDatabase(qw.db)
+	if err != nil {
+		return err
+	}
+
+	err = adb.RenameNamespace(ctx, namespace, newNamespace)
+	if err != nil {
+		qw.log.Error("failed to rename namespace", err)
+	}
+
+	return err
+}
+
+// DeleteNamespace removes all queries of the namespace
+func (qw QueryWriter) DeleteNamespace(ctx context.Context, namespace string) error {
+	if !qw.allowNamespaceWrite(namespace) {
+		return ErrUpdateNamespaceNotAllowed
+	}
+
+	adb, err := adminDatabase(qw.db)
+	if err != nil {
+		return err
+	}
+
+	err = adb.DeleteNamespace(ctx, namespace)
+	if err != nil {
+		qw.log.Error("failed to delete namespace", err)
+	}
+
+	return err
+}
+
+// Rename moves the query with all its revisions
+// to another name and namespace
+func (qw QueryWriter) Rename(ctx context.Context, namespace, name, newNamespace, newName string) error {
+	if !qw.allowWrite(namespace, name) || !qw.allowWrite(newNamespace, newName) {
+		return ErrUpdateQueryNotAllowed
+	}
+
+	adb, err := adminDatabase(qw.db)
+	if err != nil {
+		return err
+	}
+
+	err = adb.RenameQuery(ctx, namespace, name, newNamespace, newName)
+	if err != nil {
+		qw.log.Error("failed to rename query", err)
+	}
+
+	return err
+}
+
+func (qw QueryWriter) allowNamespaceWrite(namespace string) bool {
+	return len(mergeLocalQueries(qw.local.get(), qw.dir, namespace)) == 0
+}
+
+func (qw QueryWriter) allowWrite(namespace string, name string) bool {
+	_, found := findQueryByName(mergeLocalQueries(qw.local.get(), qw.dir, namespace), name)
 	return !found
 }
 
+// mergeLocalQueries merges the queries from the configuration file
+// with the ones from the directory, which take precedence.
+func mergeLocalQueries(local map[string][]restql.SavedQuery, dir *QueryDirectory, namespace string) []restql.SavedQuery {
+	if dir == nil {
+		return local[namespace]
+	}
+
+	dirQueries := dir.Queries()[namespace]
+	if len(dirQueries) == 0 {
+		return local[namespace]
+	}
+
+	queries := make([]restql.SavedQuery, 0, len(local[namespace])+len(dirQueries))
+	for _, q := range local[namespace] {
+		if _, found := findQueryByName(dirQueries, q.Name); !found {
+			queries = append(queries, q)
+		}
+	}
+
+	return append(queries, dirQueries...)
+}
+
 func findQueryByName(queries []restql.SavedQuery, name string) (restql.SavedQuery, bool) {
 	for _, query := range queries {
 		if query.Name == name {
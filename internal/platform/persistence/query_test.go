@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestQueryWriter_Rename(t *testing.T) {
+	ctx := context.Background()
+	local := map[string]map[string][]string{"local": {"hero": {"from hero"}}}
+
+	db := newSQLiteDatabase(t)
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "db", "hero", "from hero"))
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "db", "sidekick", "from sidekick"))
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "other", "villain", "from villain"))
+
+	writer := NewQueryWriter(noOpLogger, local, db)
+
+	tests := []struct {
+		name     string
+		rename   func() error
+		expected error
+	}{
+		{"should move database query", func() error { return writer.Rename(ctx, "db", "sidekick", "moved", "sidekick") }, nil},
+		{"should not move local query", func() error { return writer.Rename(ctx, "local", "hero", "db", "champion") }, ErrUpdateQueryNotAllowed},
+		{"should not move over local query", func() error { return writer.Rename(ctx, "db", "hero", "local", "hero") }, ErrUpdateQueryNotAllowed},
+		{"should fail on existing target", func() error { return writer.Rename(ctx, "db", "hero", "other", "villain") }, restql.ErrQueryAlreadyExistsInDatabase},
+		{"should not rename local namespace", func() error { return writer.RenameNamespace(ctx, "local", "renamed") }, ErrUpdateNamespaceNotAllowed},
+		{"should not rename over local namespace", func() error { return writer.RenameNamespace(ctx, "db", "local") }, ErrUpdateNamespaceNotAllowed},
+		{"should rename database namespace", func() error { return writer.RenameNamespace(ctx, "db", "renamed") }, nil},
+		{"should not delete local namespace", func() error { return writer.DeleteNamespace(ctx, "local") }, ErrUpdateNamespaceNotAllowed},
+		{"should delete database namespace", func() error { return writer.DeleteNamespace(ctx, "other") }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rename()
+			if err != tt.expected {
+				t.Errorf("got error = %v, want = %v", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// migrations are the statements that build the database schema,
+// where the position of each migration is its version. New migrations
+// must only be appended, as applied ones are never executed again.
+var migrations = [][]string{
+	{
+		`CREATE TABLE queries (
+			namespace TEXT NOT NULL,
+			name      TEXT NOT NULL,
+			archived  INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (namespace, name)
+		)`,
+		`CREATE TABLE query_revisions (
+			namespace  TEXT NOT NULL,
+			name       TEXT NOT NULL,
+			revision   INTEGER NOT NULL,
+			text       TEXT NOT NULL,
+			archived   INTEGER NOT NULL DEFAULT 0,
+			created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (namespace, name, revision)
+		)`,
+		`CREATE TABLE mappings (
+			tenant   TEXT NOT NULL,
+			resource TEXT NOT NULL,
+			url      TEXT NOT NULL,
+			PRIMARY KEY (tenant, resource)
+		)`,
+	},
+	{
+		`CREATE TABLE tenants (
+			tenant TEXT NOT NULL PRIMARY KEY
+		)`,
+		`INSERT INTO tenants (tenant) SELECT DISTINCT tenant FROM mappings`,
+	},
+	{
+		`CREATE TABLE audit_events (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp  TEXT NOT NULL,
+			actor      TEXT NOT NULL,
+			request_id TEXT NOT NULL DEFAULT '',
+			action     TEXT NOT NULL,
+			tenant     TEXT NOT NULL DEFAULT '',
+			namespace  TEXT NOT NULL DEFAULT '',
+			name       TEXT NOT NULL DEFAULT '',
+			revision   INTEGER NOT NULL DEFAULT 0,
+			before     TEXT NOT NULL DEFAULT '',
+			after      TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE INDEX audit_events_timestamp ON audit_events (timestamp)`,
+	},
+}
+
+// migrate applies the migrations not yet present in the
+// schema_migrations table, each one in its own transaction.
+func migrate(ctx context.Context, log restql.Logger, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`)
+	if err != nil {
+		return err
+	}
+
+	var current int
+	err = db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current)
+	if err != nil {
+		return err
+	}
+
+	for i := current; i < len(migrations); i++ {
+		version := i + 1
+		log.Info("applying database migration", "version", version)
+
+		err = applyMigration(ctx, db, version, migrations[i])
+		if err != nil {
+			return fmt.Errorf("migration %d failed: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, version int, statements []string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range statements {
+		_, err = tx.ExecContext(ctx, statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (?)`, version)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,811 @@
+// Package sqlite provides the built-in restql.DatabasePlugin implementation,
+// storing saved queries and mappings in an embedded SQLite file.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+
+	// SQLite driver compiled to WebAssembly, which does not require cgo.
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+)
+
+const driverName = "sqlite3"
+
+// MemoryPath is the special path used to keep
+// the database in memory, without a file.
+const MemoryPath = ":memory:"
+
+// DefaultTimeout is used when Options has no timeout.
+const DefaultTimeout = time.Second
+
+// Options define the SQLite database file and operations timeout.
+type Options struct {
+	Path    string
+	Timeout time.Duration
+}
+
+// auditTimestampLayout has a fixed width, so that the
+// stored timestamps are ordered as text.
+const auditTimestampLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// Database is a restql.AdminDatabasePlugin and a
+// restql.AuditDatabasePlugin backed by a SQLite file.
+type Database struct {
+	log     restql.Logger
+	db      *sql.DB
+	timeout time.Duration
+}
+
+// New opens the SQLite file, creating it if needed,
+// and applies the pending schema migrations.
+func New(log restql.Logger, options Options) (*Database, error) {
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	db, err := sql.Open(driverName, options.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", restql.ErrDatabaseCommunicationFailed, err)
+	}
+
+	// SQLite allows only one writer at a time, hence a single connection
+	// avoids busy errors and keeps in memory databases alive.
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+
+	// The first connection compiles the SQLite module, which can take
+	// longer than a query on slow hosts, hence it is not bound to the
+	// query timeout.
+	err = db.PingContext(context.Background())
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%w: %s", restql.ErrDatabaseCommunicationFailed, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err = migrate(ctx, log, db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%w: %s", restql.ErrDatabaseCommunicationFailed, err)
+	}
+
+	log.Info("sqlite database ready", "path", options.Path)
+
+	return &Database{log: log, db: db, timeout: timeout}, nil
+}
+
+// Name returns the plugin identification.
+func (d *Database) Name() string {
+	return "sqlite"
+}
+
+// Close releases the database file.
+func (d *Database) Close() error {
+	return d.db.Close()
+}
+
+// FindAllNamespaces returns the namespaces with saved queries.
+func (d *Database) FindAllNamespaces(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, `SELECT DISTINCT namespace FROM queries ORDER BY namespace`)
+	if err != nil {
+		return nil, d.communicationError("find all namespaces", err)
+	}
+
+	namespaces, err := scanStrings(rows)
+	if err != nil {
+		return nil, d.communicationError("find all namespaces", err)
+	}
+
+	return namespaces, nil
+}
+
+// FindQueriesForNamespace returns the queries in the namespace with the
+// given archived state, with all their revisions. It fails with
+// restql.ErrNamespaceNotFound when the namespace has no queries.
+func (d *Database) FindQueriesForNamespace(ctx context.Context, namespace string, archived bool) ([]restql.SavedQuery, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var exists bool
+	err := d.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM queries WHERE namespace = ?)`, namespace).Scan(&exists)
+	if err != nil {
+		return nil, d.communicationError("find queries for namespace", err)
+	}
+
+	if !exists {
+		return nil, restql.ErrNamespaceNotFound
+	}
+
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT name FROM queries WHERE namespace = ? AND archived = ? ORDER BY name`,
+		namespace, archived,
+	)
+	if err != nil {
+		return nil, d.communicationError("find queries for namespace", err)
+	}
+
+	names, err := scanStrings(rows)
+	if err != nil {
+		return nil, d.communicationError("find queries for namespace", err)
+	}
+
+	queries := make([]restql.SavedQuery, len(names))
+	for i, name := range names {
+		revisions, err := d.findRevisions(ctx, namespace, name)
+		if err != nil {
+			return nil, d.communicationError("find queries for namespace", err)
+		}
+
+		queries[i] = restql.SavedQuery{Namespace: namespace, Name: name, Archived: archived, Revisions: revisions}
+	}
+
+	return queries, nil
+}
+
+// FindQueryWithAllRevisions returns the query with the given archived state
+// and all its revisions, or restql.ErrQueryNotFoundInDatabase.
+func (d *Database) FindQueryWithAllRevisions(ctx context.Context, namespace string, queryName string, archived bool) (restql.SavedQuery, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var exists bool
+	err := d.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM queries WHERE namespace = ? AND name = ? AND archived = ?)`,
+		namespace, queryName, archived,
+	).Scan(&exists)
+	if err != nil {
+		return restql.SavedQuery{}, d.communicationError("find query with all revisions", err)
+	}
+
+	if !exists {
+		return restql.SavedQuery{}, restql.ErrQueryNotFoundInDatabase
+	}
+
+	revisions, err := d.findRevisions(ctx, namespace, queryName)
+	if err != nil {
+		return restql.SavedQuery{}, d.communicationError("find query with all revisions", err)
+	}
+
+	return restql.SavedQuery{Namespace: namespace, Name: queryName, Archived: archived, Revisions: revisions}, nil
+}
+
+func (d *Database) findRevisions(ctx context.Context, namespace string, queryName string) ([]restql.SavedQueryRevision, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT revision, text, archived FROM query_revisions WHERE namespace = ? AND name = ? ORDER BY revision`,
+		namespace, queryName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []restql.SavedQueryRevision
+	for rows.Next() {
+		r := restql.SavedQueryRevision{Name: queryName}
+		err := rows.Scan(&r.Revision, &r.Text, &r.Archived)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, r)
+	}
+
+	return revisions, rows.Err()
+}
+
+// FindQuery returns the query revision, whether it is archived or not,
+// or restql.ErrQueryNotFoundInDatabase.
+func (d *Database) FindQuery(ctx context.Context, namespace string, name string, revision int) (restql.SavedQueryRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	r := restql.SavedQueryRevision{Name: name, Revision: revision}
+	err := d.db.QueryRowContext(ctx,
+		`SELECT text, archived FROM query_revisions WHERE namespace = ? AND name = ? AND revision = ?`,
+		namespace, name, revision,
+	).Scan(&r.Text, &r.Archived)
+	switch {
+	case err == sql.ErrNoRows:
+		return restql.SavedQueryRevision{}, restql.ErrQueryNotFoundInDatabase
+	case err != nil:
+		return restql.SavedQueryRevision{}, d.communicationError("find query", err)
+	}
+
+	return r, nil
+}
+
+// CreateQueryRevision appends a revision to the query, creating it if
+// needed. Since the new revision is not archived, the query is unarchived.
+func (d *Database) CreateQueryRevision(ctx context.Context, namespace string, queryName string, content string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	err := d.inTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO queries (namespace, name, archived) VALUES (?, ?, 0)
+			ON CONFLICT (namespace, name) DO UPDATE SET archived = 0`,
+			namespace, queryName,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO query_revisions (namespace, name, revision, text)
+			SELECT ?, ?, COALESCE(MAX(revision), 0) + 1, ? FROM query_revisions WHERE namespace = ? AND name = ?`,
+			namespace, queryName, content, namespace, queryName,
+		)
+		return err
+	})
+	if err != nil {
+		return d.communicationError("create query revision", err)
+	}
+
+	return nil
+}
+
+// UpdateQueryArchiving archives or unarchives the query. Archiving
+// a query also archives all its revisions, while unarchiving it
+// keeps the revisions archived.
+func (d *Database) UpdateQueryArchiving(ctx context.Context, namespace string, queryName string, archived bool) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	found := true
+	err := d.inTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx,
+			`UPDATE queries SET archived = ? WHERE namespace = ? AND name = ?`,
+			archived, namespace, queryName,
+		)
+		if err != nil {
+			return err
+		}
+
+		found, err = affectedAny(result)
+		if err != nil || !found || !archived {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE query_revisions SET archived = 1 WHERE namespace = ? AND name = ?`,
+			namespace, queryName,
+		)
+		return err
+	})
+	if err != nil {
+		return d.communicationError("update query archiving", err)
+	}
+
+	if !found {
+		return restql.ErrQueryNotFoundInDatabase
+	}
+
+	return nil
+}
+
+// UpdateRevisionArchiving archives or unarchives the query revision.
+// Unarchiving a revision also unarchives its query.
+func (d *Database) UpdateRevisionArchiving(ctx context.Context, namespace string, name string, revision int, archived bool) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	found := true
+	err := d.inTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx,
+			`UPDATE query_revisions SET archived = ? WHERE namespace = ? AND name = ? AND revision = ?`,
+			archived, namespace, name, revision,
+		)
+		if err != nil {
+			return err
+		}
+
+		found, err = affectedAny(result)
+		if err != nil || !found || archived {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE queries SET archived = 0 WHERE namespace = ? AND name = ?`,
+			namespace, name,
+		)
+		return err
+	})
+	if err != nil {
+		return d.communicationError("update revision archiving", err)
+	}
+
+	if !found {
+		return restql.ErrQueryNotFoundInDatabase
+	}
+
+	return nil
+}
+
+// FindAllTenants returns the tenants created explicitly or with mappings.
+func (d *Database) FindAllTenants(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, `SELECT tenant FROM tenants ORDER BY tenant`)
+	if err != nil {
+		return nil, d.communicationError("find all tenants", err)
+	}
+
+	tenants, err := scanStrings(rows)
+	if err != nil {
+		return nil, d.communicationError("find all tenants", err)
+	}
+
+	return tenants, nil
+}
+
+// FindMappingsForTenant returns the tenant mappings,
+// or restql.ErrMappingsNotFoundInDatabase.
+func (d *Database) FindMappingsForTenant(ctx context.Context, tenantID string) ([]restql.Mapping, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, `SELECT resource, url FROM mappings WHERE tenant = ? ORDER BY resource`, tenantID)
+	if err != nil {
+		return nil, d.communicationError("find mappings for tenant", err)
+	}
+	defer rows.Close()
+
+	var mappings []restql.Mapping
+	for rows.Next() {
+		var resource, url string
+		err := rows.Scan(&resource, &url)
+		if err != nil {
+			return nil, d.communicationError("find mappings for tenant", err)
+		}
+
+		mapping, err := restql.NewMapping(resource, url)
+		if err != nil {
+			d.log.Error("invalid mapping stored in database", err, "tenant", tenantID, "resource", resource)
+			continue
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, d.communicationError("find mappings for tenant", err)
+	}
+
+	if len(mappings) == 0 {
+		return nil, restql.ErrMappingsNotFoundInDatabase
+	}
+
+	return mappings, nil
+}
+
+// CreateMapping stores a new mapping for the tenant,
+// failing with restql.ErrMappingAlreadyExistsInDatabase
+// when the resource is already mapped.
+func (d *Database) CreateMapping(ctx context.Context, tenantID string, mappingsName string, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	created := true
+	err := d.inTransaction(ctx, func(tx *sql.Tx) error {
+		err := insertTenant(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx,
+			`INSERT INTO mappings (tenant, resource, url) VALUES (?, ?, ?) ON CONFLICT (tenant, resource) DO NOTHING`,
+			tenantID, mappingsName, url,
+		)
+		if err != nil {
+			return err
+		}
+
+		created, err = affectedAny(result)
+		return err
+	})
+	if err != nil {
+		return d.communicationError("create mapping", err)
+	}
+
+	if !created {
+		return restql.ErrMappingAlreadyExistsInDatabase
+	}
+
+	return nil
+}
+
+// SetMapping creates or updates the tenant mapping.
+func (d *Database) SetMapping(ctx context.Context, tenantID string, mappingsName string, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	err := d.inTransaction(ctx, func(tx *sql.Tx) error {
+		err := insertTenant(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO mappings (tenant, resource, url) VALUES (?, ?, ?) ON CONFLICT (tenant, resource) DO UPDATE SET url = excluded.url`,
+			tenantID, mappingsName, url,
+		)
+		return err
+	})
+	if err != nil {
+		return d.communicationError("set mapping", err)
+	}
+
+	return nil
+}
+
+// DeleteMapping removes the tenant mapping, keeping the tenant
+// even if it has no other mappings.
+func (d *Database) DeleteMapping(ctx context.Context, tenantID string, mappingsName string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	result, err := d.db.ExecContext(ctx, `DELETE FROM mappings WHERE tenant = ? AND resource = ?`, tenantID, mappingsName)
+	if err != nil {
+		return d.communicationError("delete mapping", err)
+	}
+
+	deleted, err := affectedAny(result)
+	if err != nil {
+		return d.communicationError("delete mapping", err)
+	}
+
+	if !deleted {
+		return restql.ErrMappingsNotFoundInDatabase
+	}
+
+	return nil
+}
+
+// CreateTenant stores a tenant without mappings, failing with
+// restql.ErrTenantAlreadyExistsInDatabase when it is already stored.
+func (d *Database) CreateTenant(ctx context.Context, tenantID string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	result, err := d.db.ExecContext(ctx, `INSERT INTO tenants (tenant) VALUES (?) ON CONFLICT (tenant) DO NOTHING`, tenantID)
+	if err != nil {
+		return d.communicationError("create tenant", err)
+	}
+
+	created, err := affectedAny(result)
+	if err != nil {
+		return d.communicationError("create tenant", err)
+	}
+
+	if !created {
+		return restql.ErrTenantAlreadyExistsInDatabase
+	}
+
+	return nil
+}
+
+// DeleteTenant removes the tenant and all its mappings.
+func (d *Database) DeleteTenant(ctx context.Context, tenantID string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	found := true
+	err := d.inTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM tenants WHERE tenant = ?`, tenantID)
+		if err != nil {
+			return err
+		}
+
+		found, err = affectedAny(result)
+		if err != nil || !found {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM mappings WHERE tenant = ?`, tenantID)
+		return err
+	})
+	if err != nil {
+		return d.communicationError("delete tenant", err)
+	}
+
+	if !found {
+		return restql.ErrTenantNotFoundInDatabase
+	}
+
+	return nil
+}
+
+// RenameNamespace moves all queries of the namespace to the new one,
+// which must not have queries.
+func (d *Database) RenameNamespace(ctx context.Context, namespace string, newNamespace string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	err := d.inTransaction(ctx, func(tx *sql.Tx) error {
+		exists, err := namespaceExists(ctx, tx, namespace)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return restql.ErrNamespaceNotFound
+		}
+
+		exists, err = namespaceExists(ctx, tx, newNamespace)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return restql.ErrNamespaceAlreadyExistsInDatabase
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE queries SET namespace = ? WHERE namespace = ?`, newNamespace, namespace)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE query_revisions SET namespace = ? WHERE namespace = ?`, newNamespace, namespace)
+		return err
+	})
+	switch {
+	case err == restql.ErrNamespaceNotFound || err == restql.ErrNamespaceAlreadyExistsInDatabase:
+		return err
+	case err != nil:
+		return d.communicationError("rename namespace", err)
+	}
+
+	return nil
+}
+
+// DeleteNamespace removes all queries of the namespace with their revisions.
+func (d *Database) DeleteNamespace(ctx context.Context, namespace string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	found := true
+	err := d.inTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM queries WHERE namespace = ?`, namespace)
+		if err != nil {
+			return err
+		}
+
+		found, err = affectedAny(result)
+		if err != nil || !found {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM query_revisions WHERE namespace = ?`, namespace)
+		return err
+	})
+	if err != nil {
+		return d.communicationError("delete namespace", err)
+	}
+
+	if !found {
+		return restql.ErrNamespaceNotFound
+	}
+
+	return nil
+}
+
+// RenameQuery moves the query and its revisions to the new name and
+// namespace, which must not have a query with the same name.
+func (d *Database) RenameQuery(ctx context.Context, namespace string, queryName string, newNamespace string, newQueryName string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	err := d.inTransaction(ctx, func(tx *sql.Tx) error {
+		exists, err := queryExists(ctx, tx, namespace, queryName)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return restql.ErrQueryNotFoundInDatabase
+		}
+
+		exists, err = queryExists(ctx, tx, newNamespace, newQueryName)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return restql.ErrQueryAlreadyExistsInDatabase
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE queries SET namespace = ?, name = ? WHERE namespace = ? AND name = ?`,
+			newNamespace, newQueryName, namespace, queryName,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE query_revisions SET namespace = ?, name = ? WHERE namespace = ? AND name = ?`,
+			newNamespace, newQueryName, namespace, queryName,
+		)
+		return err
+	})
+	switch {
+	case err == restql.ErrQueryNotFoundInDatabase || err == restql.ErrQueryAlreadyExistsInDatabase:
+		return err
+	case err != nil:
+		return d.communicationError("rename query", err)
+	}
+
+	return nil
+}
+
+// CreateAuditEvent appends the event to the audit log.
+func (d *Database) CreateAuditEvent(ctx context.Context, event restql.AuditEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO audit_events (timestamp, actor, request_id, action, tenant, namespace, name, revision, before, after)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.Timestamp.UTC().Format(auditTimestampLayout), event.Actor, event.RequestID, event.Action,
+		event.Tenant, event.Namespace, event.Name, event.Revision, event.Before, event.After,
+	)
+	if err != nil {
+		return d.communicationError("create audit event", err)
+	}
+
+	return nil
+}
+
+// FindAuditEvents returns the audit events matching
+// the filter, from the newest to the oldest.
+func (d *Database) FindAuditEvents(ctx context.Context, filter restql.AuditFilter) ([]restql.AuditEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		conditions = append(conditions, condition)
+		args = append(args, value)
+	}
+
+	if filter.Actor != "" {
+		addCondition("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		addCondition("action = ?", filter.Action)
+	}
+	if filter.Tenant != "" {
+		addCondition("tenant = ?", filter.Tenant)
+	}
+	if filter.Namespace != "" {
+		addCondition("namespace = ?", filter.Namespace)
+	}
+	if !filter.Since.IsZero() {
+		addCondition("timestamp >= ?", filter.Since.UTC().Format(auditTimestampLayout))
+	}
+	if !filter.Until.IsZero() {
+		addCondition("timestamp < ?", filter.Until.UTC().Format(auditTimestampLayout))
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = restql.AuditDefaultFindEventsLimit
+	}
+
+	query := `SELECT timestamp, actor, request_id, action, tenant, namespace, name, revision, before, after FROM audit_events`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY timestamp DESC, id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, d.communicationError("find audit events", err)
+	}
+	defer rows.Close()
+
+	events := []restql.AuditEvent{}
+	for rows.Next() {
+		var e restql.AuditEvent
+		var timestamp string
+		err := rows.Scan(&timestamp, &e.Actor, &e.RequestID, &e.Action, &e.Tenant, &e.Namespace, &e.Name, &e.Revision, &e.Before, &e.After)
+		if err != nil {
+			return nil, d.communicationError("find audit events", err)
+		}
+
+		e.Timestamp, err = time.Parse(auditTimestampLayout, timestamp)
+		if err != nil {
+			return nil, d.communicationError("find audit events", err)
+		}
+
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, d.communicationError("find audit events", err)
+	}
+
+	return events, nil
+}
+
+func insertTenant(ctx context.Context, tx *sql.Tx, tenantID string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO tenants (tenant) VALUES (?) ON CONFLICT (tenant) DO NOTHING`, tenantID)
+	return err
+}
+
+func namespaceExists(ctx context.Context, tx *sql.Tx, namespace string) (bool, error) {
+	var exists bool
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM queries WHERE namespace = ?)`, namespace).Scan(&exists)
+	return exists, err
+}
+
+func queryExists(ctx context.Context, tx *sql.Tx, namespace string, queryName string) (bool, error) {
+	var exists bool
+	err := tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM queries WHERE namespace = ? AND name = ?)`,
+		namespace, queryName,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (d *Database) inTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (d *Database) communicationError(operation string, err error) error {
+	d.log.Error("sqlite operation failed", err, "operation", operation)
+	return fmt.Errorf("%w: %s", restql.ErrDatabaseCommunicationFailed, err)
+}
+
+func affectedAny(result sql.Result) (bool, error) {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func scanStrings(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		err := rows.Scan(&v)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	return values, rows.Err()
+}
@@ -0,0 +1,57 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence/sqlite"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/databasetest"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestDatabase(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T) restql.DatabasePlugin {
+		return newDatabase(t, filepath.Join(t.TempDir(), "restql.db"))
+	})
+}
+
+func TestDatabase_InMemory(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T) restql.DatabasePlugin {
+		return newDatabase(t, sqlite.MemoryPath)
+	})
+}
+
+func TestDatabase_Reopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "restql.db")
+
+	db := newDatabase(t, path)
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "ns", "hero", "from hero"))
+	test.VerifyError(t, db.Close())
+
+	db = newDatabase(t, path)
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "ns", "hero", "from hero with id = 1"))
+
+	query, err := db.FindQueryWithAllRevisions(ctx, "ns", "hero", false)
+	test.VerifyError(t, err)
+	test.Equal(t, query, restql.SavedQuery{
+		Namespace: "ns",
+		Name:      "hero",
+		Revisions: []restql.SavedQueryRevision{
+			{Name: "hero", Text: "from hero", Revision: 1},
+			{Name: "hero", Text: "from hero with id = 1", Revision: 2},
+		},
+	})
+}
+
+func newDatabase(t *testing.T, path string) *sqlite.Database {
+	db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: path})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
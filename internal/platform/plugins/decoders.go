@@ -0,0 +1,14 @@
+package plugins
+
+import "github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+
+// NewResponseDecoders constructs all the registered
+// response decoder plugins.
+func NewResponseDecoders(log restql.Logger) []restql.ResponseDecoderPlugin {
+	ps := loadResponseDecoderPlugins(log)
+	if len(ps) == 0 {
+		log.Debug("no response decoder plugin provided")
+	}
+
+	return ps
+}
@@ -0,0 +1,14 @@
+package plugins
+
+import "github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+
+// NewResponseEncoders constructs all the registered
+// response encoder plugins.
+func NewResponseEncoders(log restql.Logger) []restql.ResponseEncoderPlugin {
+	ps := loadResponseEncoderPlugins(log)
+	if len(ps) == 0 {
+		log.Debug("no response encoder plugin provided")
+	}
+
+	return ps
+}
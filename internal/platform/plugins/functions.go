@@ -0,0 +1,38 @@
+package plugins
+
+import (
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// NewFunctions returns the functions provided by all the
+// registered function plugins. When more than one function
+// has the same name, the first one registered is used.
+func NewFunctions(log restql.Logger) []restql.Function {
+	ps := loadFunctionPlugins(log)
+	if len(ps) == 0 {
+		log.Debug("no function plugin provided")
+		return nil
+	}
+
+	var fns []restql.Function
+	names := make(map[string]string)
+	for _, p := range ps {
+		for _, fn := range p.Functions() {
+			if fn.Name == "" || fn.Apply == nil {
+				log.Error("failed to load function", errors.New("function must have a name and an apply function"), "plugin", p.Name())
+				continue
+			}
+
+			if previous, found := names[fn.Name]; found {
+				log.Warn("function already registered", "function", fn.Name, "plugin", p.Name(), "registered-by", previous)
+				continue
+			}
+
+			names[fn.Name] = p.Name()
+			fns = append(fns, fn)
+		}
+	}
+
+	return fns
+}
@@ -13,31 +13,42 @@ import (
 )
 
 // Lifecycle represent the hooks on the query execution.
+//
+// BeforeQuery, BeforeRequest and AfterRequest return the
+// query context, request and response modified by the
+// plugins, which must be used in place of the given ones.
 type Lifecycle interface {
 	BeforeTransaction(ctx context.Context, requestCtx *fasthttp.RequestCtx) context.Context
 	AfterTransaction(ctx context.Context, requestCtx *fasthttp.RequestCtx) context.Context
-	BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) context.Context
+	BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) (context.Context, restql.QueryContext)
 	AfterQuery(ctx context.Context, query string, result domain.Resources) context.Context
-	BeforeRequest(ctx context.Context, request restql.HTTPRequest) context.Context
-	AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) context.Context
+	BeforeRequest(ctx context.Context, request restql.HTTPRequest) (context.Context, restql.HTTPRequest)
+	AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) (context.Context, restql.HTTPResponse)
+	BeforeStatement(ctx context.Context, statement restql.Statement) context.Context
+	AfterStatement(ctx context.Context, statement restql.Statement, result restql.DoneResource) context.Context
+	OnStatementSkipped(ctx context.Context, statement restql.Statement, reason restql.SkipReason) context.Context
+	OnQueryError(ctx context.Context, query string, err error) context.Context
 }
 
 type pluginExecutor func(ctx context.Context, p restql.LifecyclePlugin) context.Context
 
+type mutatingPluginExecutor func(ctx context.Context, p restql.MutatingLifecyclePlugin) context.Context
+
+type statementPluginExecutor func(ctx context.Context, p restql.StatementLifecyclePlugin) context.Context
+
 type manager struct {
 	log              restql.Logger
 	availablePlugins []restql.LifecyclePlugin
 }
 
-// NewLifecycle constructs a Lifecycle instance.
-func NewLifecycle(log restql.Logger) (Lifecycle, error) {
-	ps := loadLifecyclePlugins(log)
+// NewLifecycle constructs a Lifecycle instance
+// that executes the hooks of the given plugins.
+func NewLifecycle(log restql.Logger, ps []restql.LifecyclePlugin) Lifecycle {
 	if len(ps) == 0 {
-		log.Info("no lifecycle hook provided")
-		return NoOpLifecycle, nil
+		return NoOpLifecycle
 	}
 
-	return manager{log: log, availablePlugins: ps}, nil
+	return manager{log: log, availablePlugins: ps}
 }
 
 func (m manager) BeforeTransaction(ctx context.Context, requestCtx *fasthttp.RequestCtx) context.Context {
@@ -55,10 +66,18 @@ func (m manager) AfterTransaction(ctx context.Context, requestCtx *fasthttp.Requ
 	})
 }
 
-func (m manager) BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) context.Context {
-	return m.executeAllPluginsWithContext(ctx, "BeforeQuery", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
+func (m manager) BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) (context.Context, restql.QueryContext) {
+	ctx = m.executeAllMutatingPlugins(ctx, "MutateQueryInput", func(currentCtx context.Context, p restql.MutatingLifecyclePlugin) context.Context {
+		mutatedCtx, input := p.MutateQueryInput(currentCtx, query, queryCtx)
+		queryCtx.Input = input
+		return mutatedCtx
+	})
+
+	ctx = m.executeAllPluginsWithContext(ctx, "BeforeQuery", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
 		return p.BeforeQuery(currentCtx, query, queryCtx)
 	})
+
+	return ctx, queryCtx
 }
 
 func (m manager) AfterQuery(ctx context.Context, query string, result domain.Resources) context.Context {
@@ -72,17 +91,100 @@ func (m manager) AfterQuery(ctx context.Context, query string, result domain.Res
 	})
 }
 
-func (m manager) BeforeRequest(ctx context.Context, request restql.HTTPRequest) context.Context {
-	return m.executeAllPluginsWithContext(ctx, "BeforeRequest", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
+func (m manager) BeforeRequest(ctx context.Context, request restql.HTTPRequest) (context.Context, restql.HTTPRequest) {
+	ctx = m.executeAllMutatingPlugins(ctx, "MutateRequest", func(currentCtx context.Context, p restql.MutatingLifecyclePlugin) context.Context {
+		mutatedCtx, mutatedRequest := p.MutateRequest(currentCtx, request)
+		request = mutatedRequest
+		return mutatedCtx
+	})
+
+	ctx = m.executeAllPluginsWithContext(ctx, "BeforeRequest", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
 		return p.BeforeRequest(currentCtx, request)
 	})
+
+	return ctx, request
 }
 
-func (m manager) AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) context.Context {
-	return m.executeAllPluginsWithContext(ctx, "AfterRequest", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
+func (m manager) AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) (context.Context, restql.HTTPResponse) {
+	ctx = m.executeAllMutatingPlugins(ctx, "MutateResponse", func(currentCtx context.Context, p restql.MutatingLifecyclePlugin) context.Context {
+		mutatedCtx, mutatedResponse := p.MutateResponse(currentCtx, request, response, err)
+		response = mutatedResponse
+		return mutatedCtx
+	})
+
+	ctx = m.executeAllPluginsWithContext(ctx, "AfterRequest", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
 		return p.AfterRequest(currentCtx, request, response, err)
 	})
+
+	return ctx, response
+}
+
+func (m manager) BeforeStatement(ctx context.Context, statement restql.Statement) context.Context {
+	return m.executeAllStatementPlugins(ctx, "BeforeStatement", func(currentCtx context.Context, p restql.StatementLifecyclePlugin) context.Context {
+		return p.BeforeStatement(currentCtx, statement)
+	})
 }
+
+func (m manager) AfterStatement(ctx context.Context, statement restql.Statement, result restql.DoneResource) context.Context {
+	return m.executeAllStatementPlugins(ctx, "AfterStatement", func(currentCtx context.Context, p restql.StatementLifecyclePlugin) context.Context {
+		return p.AfterStatement(currentCtx, statement, result)
+	})
+}
+
+func (m manager) OnStatementSkipped(ctx context.Context, statement restql.Statement, reason restql.SkipReason) context.Context {
+	return m.executeAllStatementPlugins(ctx, "OnStatementSkipped", func(currentCtx context.Context, p restql.StatementLifecyclePlugin) context.Context {
+		return p.OnStatementSkipped(currentCtx, statement, reason)
+	})
+}
+
+func (m manager) OnQueryError(ctx context.Context, query string, err error) context.Context {
+	return m.executeAllStatementPlugins(ctx, "OnQueryError", func(currentCtx context.Context, p restql.StatementLifecyclePlugin) context.Context {
+		return p.OnQueryError(currentCtx, query, err)
+	})
+}
+
+// executeAllStatementPlugins runs the hook of the plugins implementing
+// restql.StatementLifecyclePlugin in the order they were registered.
+func (m manager) executeAllStatementPlugins(ctx context.Context, hook string, fn statementPluginExecutor) context.Context {
+	log := restql.GetLogger(ctx)
+
+	pluginCtx := ctx
+	for _, p := range m.availablePlugins {
+		sp, ok := p.(restql.StatementLifecyclePlugin)
+		if !ok {
+			continue
+		}
+
+		m.safeExecute(log, p.Name(), hook, func() {
+			pluginCtx = fn(pluginCtx, sp)
+		})
+	}
+
+	return pluginCtx
+}
+
+// executeAllMutatingPlugins runs the hook of the plugins implementing
+// restql.MutatingLifecyclePlugin in the order they were registered.
+// The hook must only update the mutated value after the plugin
+// returns, so a panic keeps the value given to the plugin.
+func (m manager) executeAllMutatingPlugins(ctx context.Context, hook string, fn mutatingPluginExecutor) context.Context {
+	log := restql.GetLogger(ctx)
+
+	pluginCtx := ctx
+	for _, p := range m.availablePlugins {
+		mp, ok := p.(restql.MutatingLifecyclePlugin)
+		if !ok {
+			continue
+		}
+
+		m.safeExecute(log, p.Name(), hook, func() {
+			pluginCtx = fn(pluginCtx, mp)
+		})
+	}
+
+	return pluginCtx
+}
+
 func (m manager) executeAllPluginsWithContext(ctx context.Context, hook string, fn pluginExecutor) context.Context {
 	log := restql.GetLogger(ctx)
 
@@ -153,15 +255,27 @@ func (n noOpLifecycle) BeforeTransaction(ctx context.Context, requestCtx *fastht
 func (n noOpLifecycle) AfterTransaction(ctx context.Context, requestCtx *fasthttp.RequestCtx) context.Context {
 	return ctx
 }
-func (n noOpLifecycle) BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) context.Context {
-	return ctx
+func (n noOpLifecycle) BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) (context.Context, restql.QueryContext) {
+	return ctx, queryCtx
 }
 func (n noOpLifecycle) AfterQuery(ctx context.Context, query string, result domain.Resources) context.Context {
 	return ctx
 }
-func (n noOpLifecycle) BeforeRequest(ctx context.Context, request restql.HTTPRequest) context.Context {
+func (n noOpLifecycle) BeforeRequest(ctx context.Context, request restql.HTTPRequest) (context.Context, restql.HTTPRequest) {
+	return ctx, request
+}
+func (n noOpLifecycle) AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) (context.Context, restql.HTTPResponse) {
+	return ctx, response
+}
+func (n noOpLifecycle) BeforeStatement(ctx context.Context, statement restql.Statement) context.Context {
+	return ctx
+}
+func (n noOpLifecycle) AfterStatement(ctx context.Context, statement restql.Statement, result restql.DoneResource) context.Context {
+	return ctx
+}
+func (n noOpLifecycle) OnStatementSkipped(ctx context.Context, statement restql.Statement, reason restql.SkipReason) context.Context {
 	return ctx
 }
-func (n noOpLifecycle) AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) context.Context {
+func (n noOpLifecycle) OnQueryError(ctx context.Context, query string, err error) context.Context {
 	return ctx
 }
@@ -0,0 +1,239 @@
+package plugins_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+type ctxKey string
+
+// observerPlugin records the values received by the LifecyclePlugin hooks.
+type observerPlugin struct {
+	name     string
+	calls    *[]string
+	input    restql.QueryInput
+	request  restql.HTTPRequest
+	response restql.HTTPResponse
+}
+
+func (op *observerPlugin) Name() string { return op.name }
+
+func (op *observerPlugin) BeforeTransaction(ctx context.Context, tr restql.TransactionRequest) context.Context {
+	return ctx
+}
+
+func (op *observerPlugin) AfterTransaction(ctx context.Context, tr restql.TransactionResponse) context.Context {
+	return ctx
+}
+
+func (op *observerPlugin) BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) context.Context {
+	*op.calls = append(*op.calls, op.name+".BeforeQuery")
+	op.input = queryCtx.Input
+	return ctx
+}
+
+func (op *observerPlugin) AfterQuery(ctx context.Context, query string, result map[string]interface{}) context.Context {
+	return ctx
+}
+
+func (op *observerPlugin) BeforeRequest(ctx context.Context, request restql.HTTPRequest) context.Context {
+	*op.calls = append(*op.calls, op.name+".BeforeRequest")
+	op.request = request
+	return ctx
+}
+
+func (op *observerPlugin) AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) context.Context {
+	*op.calls = append(*op.calls, op.name+".AfterRequest")
+	op.response = response
+	return ctx
+}
+
+// mutatingPlugin appends its name to the query input, the request
+// path and the response URL, and stores its name in the context.
+type mutatingPlugin struct {
+	observerPlugin
+	panics bool
+}
+
+func (mp *mutatingPlugin) MutateQueryInput(ctx context.Context, query string, queryCtx restql.QueryContext) (context.Context, restql.QueryInput) {
+	*mp.calls = append(*mp.calls, mp.name+".MutateQueryInput")
+	if mp.panics {
+		panic("failed to mutate query input")
+	}
+
+	input := queryCtx.Input
+	input.Params = map[string]interface{}{"id": input.Params["id"].(string) + "-" + mp.name}
+	return context.WithValue(ctx, ctxKey("mutated-by"), mp.name), input
+}
+
+func (mp *mutatingPlugin) MutateRequest(ctx context.Context, request restql.HTTPRequest) (context.Context, restql.HTTPRequest) {
+	*mp.calls = append(*mp.calls, mp.name+".MutateRequest")
+	if mp.panics {
+		panic("failed to mutate request")
+	}
+
+	request.Path = request.Path + "/" + mp.name
+	return context.WithValue(ctx, ctxKey("mutated-by"), mp.name), request
+}
+
+func (mp *mutatingPlugin) MutateResponse(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) (context.Context, restql.HTTPResponse) {
+	*mp.calls = append(*mp.calls, mp.name+".MutateResponse")
+	if mp.panics {
+		panic("failed to mutate response")
+	}
+
+	response.URL = response.URL + "/" + mp.name
+	response.StatusCode = 200
+	return context.WithValue(ctx, ctxKey("mutated-by"), mp.name), response
+}
+
+func newPlugins(calls *[]string, panicking string) (*mutatingPlugin, *observerPlugin, *mutatingPlugin) {
+	first := &mutatingPlugin{observerPlugin: observerPlugin{name: "first", calls: calls}, panics: panicking == "first"}
+	observer := &observerPlugin{name: "observer", calls: calls}
+	second := &mutatingPlugin{observerPlugin: observerPlugin{name: "second", calls: calls}, panics: panicking == "second"}
+
+	return first, observer, second
+}
+
+func TestLifecycle_BeforeQuery(t *testing.T) {
+	var calls []string
+	first, observer, second := newPlugins(&calls, "")
+	lifecycle := plugins.NewLifecycle(test.NoOpLogger, []restql.LifecyclePlugin{first, observer, second})
+
+	queryCtx := restql.QueryContext{Input: restql.QueryInput{Params: map[string]interface{}{"id": "1"}}}
+	ctx, got := lifecycle.BeforeQuery(context.Background(), "from hero", queryCtx)
+
+	expectedInput := restql.QueryInput{Params: map[string]interface{}{"id": "1-first-second"}}
+	test.Equal(t, got.Input, expectedInput)
+	test.Equal(t, queryCtx.Input.Params["id"], "1")
+	test.Equal(t, ctx.Value(ctxKey("mutated-by")), "second")
+	test.Equal(t, calls, []string{
+		"first.MutateQueryInput", "second.MutateQueryInput",
+		"first.BeforeQuery", "observer.BeforeQuery", "second.BeforeQuery",
+	})
+	test.Equal(t, observer.input, expectedInput)
+	test.Equal(t, first.input, expectedInput)
+}
+
+func TestLifecycle_BeforeRequest(t *testing.T) {
+	var calls []string
+	first, observer, second := newPlugins(&calls, "")
+	lifecycle := plugins.NewLifecycle(test.NoOpLogger, []restql.LifecyclePlugin{first, observer, second})
+
+	request := restql.HTTPRequest{Host: "hero.api", Path: "/heroes"}
+	ctx, got := lifecycle.BeforeRequest(context.Background(), request)
+
+	expectedRequest := restql.HTTPRequest{Host: "hero.api", Path: "/heroes/first/second"}
+	test.Equal(t, got, expectedRequest)
+	test.Equal(t, ctx.Value(ctxKey("mutated-by")), "second")
+	test.Equal(t, calls, []string{
+		"first.MutateRequest", "second.MutateRequest",
+		"first.BeforeRequest", "observer.BeforeRequest", "second.BeforeRequest",
+	})
+	test.Equal(t, observer.request, expectedRequest)
+}
+
+func TestLifecycle_AfterRequest(t *testing.T) {
+	var calls []string
+	first, observer, second := newPlugins(&calls, "")
+	lifecycle := plugins.NewLifecycle(test.NoOpLogger, []restql.LifecyclePlugin{first, observer, second})
+
+	request := restql.HTTPRequest{Host: "hero.api", Path: "/heroes"}
+	response := restql.HTTPResponse{URL: "http://hero.api/heroes", StatusCode: 404}
+	_, got := lifecycle.AfterRequest(context.Background(), request, response, nil)
+
+	expectedResponse := restql.HTTPResponse{URL: "http://hero.api/heroes/first/second", StatusCode: 200}
+	test.Equal(t, got, expectedResponse)
+	test.Equal(t, calls, []string{
+		"first.MutateResponse", "second.MutateResponse",
+		"first.AfterRequest", "observer.AfterRequest", "second.AfterRequest",
+	})
+	test.Equal(t, observer.response, expectedResponse)
+}
+
+func TestLifecycle_MutationPanic(t *testing.T) {
+	var calls []string
+	first, observer, second := newPlugins(&calls, "first")
+	lifecycle := plugins.NewLifecycle(test.NoOpLogger, []restql.LifecyclePlugin{first, observer, second})
+
+	queryCtx := restql.QueryContext{Input: restql.QueryInput{Params: map[string]interface{}{"id": "1"}}}
+	_, gotQueryCtx := lifecycle.BeforeQuery(context.Background(), "from hero", queryCtx)
+	test.Equal(t, gotQueryCtx.Input, restql.QueryInput{Params: map[string]interface{}{"id": "1-second"}})
+
+	ctx, gotRequest := lifecycle.BeforeRequest(context.Background(), restql.HTTPRequest{Path: "/heroes"})
+	test.Equal(t, gotRequest, restql.HTTPRequest{Path: "/heroes/second"})
+	test.Equal(t, ctx.Value(ctxKey("mutated-by")), "second")
+
+	_, gotResponse := lifecycle.AfterRequest(context.Background(), restql.HTTPRequest{}, restql.HTTPResponse{URL: "http://hero.api"}, nil)
+	test.Equal(t, gotResponse, restql.HTTPResponse{URL: "http://hero.api/second", StatusCode: 200})
+}
+
+func TestNoOpLifecycle(t *testing.T) {
+	queryCtx := restql.QueryContext{Input: restql.QueryInput{Params: map[string]interface{}{"id": "1"}}}
+	_, gotQueryCtx := plugins.NoOpLifecycle.BeforeQuery(context.Background(), "from hero", queryCtx)
+	test.Equal(t, gotQueryCtx, queryCtx)
+
+	request := restql.HTTPRequest{Path: "/heroes"}
+	_, gotRequest := plugins.NoOpLifecycle.BeforeRequest(context.Background(), request)
+	test.Equal(t, gotRequest, request)
+
+	response := restql.HTTPResponse{StatusCode: 200, Body: restql.NewResponseBodyFromValue(test.NoOpLogger, nil)}
+	_, gotResponse := plugins.NoOpLifecycle.AfterRequest(context.Background(), request, response, nil)
+	test.Equal(t, gotResponse, response)
+}
+
+// statementPlugin records the statement hooks calls.
+type statementPlugin struct {
+	observerPlugin
+	panics bool
+}
+
+func (sp *statementPlugin) BeforeStatement(ctx context.Context, statement restql.Statement) context.Context {
+	*sp.calls = append(*sp.calls, sp.name+".BeforeStatement:"+statement.Name)
+	if sp.panics {
+		panic("failed to observe statement")
+	}
+	return context.WithValue(ctx, ctxKey("statement"), sp.name)
+}
+
+func (sp *statementPlugin) AfterStatement(ctx context.Context, statement restql.Statement, result restql.DoneResource) context.Context {
+	*sp.calls = append(*sp.calls, sp.name+".AfterStatement:"+statement.Name)
+	return ctx
+}
+
+func (sp *statementPlugin) OnStatementSkipped(ctx context.Context, statement restql.Statement, reason restql.SkipReason) context.Context {
+	*sp.calls = append(*sp.calls, sp.name+".OnStatementSkipped:"+string(reason))
+	return ctx
+}
+
+func (sp *statementPlugin) OnQueryError(ctx context.Context, query string, err error) context.Context {
+	*sp.calls = append(*sp.calls, sp.name+".OnQueryError:"+err.Error())
+	return ctx
+}
+
+func TestLifecycle_StatementHooks(t *testing.T) {
+	var calls []string
+	first := &statementPlugin{observerPlugin: observerPlugin{name: "first", calls: &calls}, panics: true}
+	observer := &observerPlugin{name: "observer", calls: &calls}
+	second := &statementPlugin{observerPlugin: observerPlugin{name: "second", calls: &calls}}
+	lifecycle := plugins.NewLifecycle(test.NoOpLogger, []restql.LifecyclePlugin{first, observer, second})
+
+	statement := restql.Statement{Name: "hero", Resource: "hero", Method: "from"}
+	ctx := lifecycle.BeforeStatement(context.Background(), statement)
+	lifecycle.AfterStatement(ctx, statement, restql.DoneResource{Status: 200})
+	lifecycle.OnStatementSkipped(context.Background(), statement, restql.DependsOnUnresolved)
+	lifecycle.OnQueryError(context.Background(), "from hero", errors.New("query timed out"))
+
+	test.Equal(t, ctx.Value(ctxKey("statement")), "second")
+	test.Equal(t, calls, []string{
+		"first.BeforeStatement:hero", "second.BeforeStatement:hero",
+		"first.AfterStatement:hero", "second.AfterStatement:hero",
+		"first.OnStatementSkipped:depends-on-unresolved", "second.OnStatementSkipped:depends-on-unresolved",
+		"first.OnQueryError:query timed out", "second.OnQueryError:query timed out",
+	})
+}
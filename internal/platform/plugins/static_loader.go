@@ -5,7 +5,9 @@ import (
 	"github.com/pkg/errors"
 )
 
-func loadLifecyclePlugins(logger restql.Logger) []restql.LifecyclePlugin {
+// LoadLifecyclePlugins instantiates all the
+// registered lifecycle plugins.
+func LoadLifecyclePlugins(logger restql.Logger) []restql.LifecyclePlugin {
 	var ps []restql.LifecyclePlugin
 	for _, pluginInfo := range restql.GetLifecyclePlugins() {
 		p, err := pluginInfo.New(logger)
@@ -25,3 +27,66 @@ func loadLifecyclePlugins(logger restql.Logger) []restql.LifecyclePlugin {
 	}
 	return ps
 }
+
+func loadResponseDecoderPlugins(logger restql.Logger) []restql.ResponseDecoderPlugin {
+	var ps []restql.ResponseDecoderPlugin
+	for _, pluginInfo := range restql.GetResponseDecoderPlugins() {
+		p, err := pluginInfo.New(logger)
+		if err != nil {
+			logger.Error("failed to load plugin", err)
+			continue
+		}
+
+		pluginInstance, ok := p.(restql.ResponseDecoderPlugin)
+		if !ok {
+			logger.Error("failed to load plugin", errors.Errorf("plugin of incorrect type: %T", p))
+			continue
+		}
+
+		logger.Debug("plugin loaded", "name", pluginInstance.Name())
+		ps = append(ps, pluginInstance)
+	}
+	return ps
+}
+
+func loadResponseEncoderPlugins(logger restql.Logger) []restql.ResponseEncoderPlugin {
+	var ps []restql.ResponseEncoderPlugin
+	for _, pluginInfo := range restql.GetResponseEncoderPlugins() {
+		p, err := pluginInfo.New(logger)
+		if err != nil {
+			logger.Error("failed to load plugin", err)
+			continue
+		}
+
+		pluginInstance, ok := p.(restql.ResponseEncoderPlugin)
+		if !ok {
+			logger.Error("failed to load plugin", errors.Errorf("plugin of incorrect type: %T", p))
+			continue
+		}
+
+		logger.Debug("plugin loaded", "name", pluginInstance.Name())
+		ps = append(ps, pluginInstance)
+	}
+	return ps
+}
+
+func loadFunctionPlugins(logger restql.Logger) []restql.FunctionPlugin {
+	var ps []restql.FunctionPlugin
+	for _, pluginInfo := range restql.GetFunctionPlugins() {
+		p, err := pluginInfo.New(logger)
+		if err != nil {
+			logger.Error("failed to load plugin", err)
+			continue
+		}
+
+		pluginInstance, ok := p.(restql.FunctionPlugin)
+		if !ok {
+			logger.Error("failed to load plugin", errors.Errorf("plugin of incorrect type: %T", p))
+			continue
+		}
+
+		logger.Debug("plugin loaded", "name", pluginInstance.Name())
+		ps = append(ps, pluginInstance)
+	}
+	return ps
+}
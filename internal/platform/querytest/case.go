@@ -0,0 +1,236 @@
+package querytest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Case file names must end with one of these suffixes
+// to be found when searching a directory.
+var caseSuffixes = []string{".test.yml", ".test.yaml"}
+
+const snapshotSuffix = ".snap.json"
+
+// Case is a saved or ad-hoc query execution with
+// a known input and mocked upstream responses.
+type Case struct {
+	Name     string            `yaml:"name"`
+	Tenant   string            `yaml:"tenant"`
+	Query    CaseQuery         `yaml:"query"`
+	Input    CaseInput         `yaml:"input"`
+	Mappings map[string]string `yaml:"mappings"`
+	Upstream map[string]Mocks  `yaml:"upstream"`
+
+	// Path is the file the case was loaded from.
+	Path string `yaml:"-"`
+}
+
+// CaseQuery identifies the query under test, either
+// by a saved query reference or by its text.
+type CaseQuery struct {
+	Namespace string `yaml:"namespace"`
+	ID        string `yaml:"id"`
+	Revision  int    `yaml:"revision"`
+	Text      string `yaml:"text"`
+}
+
+// IsSaved returns true when the case references a saved query.
+func (cq CaseQuery) IsSaved() bool {
+	return cq.Text == ""
+}
+
+func (cq CaseQuery) String() string {
+	if cq.IsSaved() {
+		return fmt.Sprintf("%s/%s/%d", cq.Namespace, cq.ID, cq.Revision)
+	}
+
+	return "ad-hoc"
+}
+
+// CaseInput is the client information sent with the query.
+type CaseInput struct {
+	Params  map[string]interface{} `yaml:"params"`
+	Headers map[string]string      `yaml:"headers"`
+	Body    interface{}            `yaml:"body"`
+}
+
+// Mock is the response served to an upstream call made by a statement.
+// When Path or Params are defined, the mock is only used for requests
+// with the same path and with all the given query parameters.
+type Mock struct {
+	Path    string                 `yaml:"path"`
+	Params  map[string]interface{} `yaml:"params"`
+	Status  int                    `yaml:"status"`
+	Headers map[string]string      `yaml:"headers"`
+	Body    interface{}            `yaml:"body"`
+	Timeout bool                   `yaml:"timeout"`
+}
+
+// Mocks are the responses available for a resource,
+// tried in order of declaration.
+type Mocks []Mock
+
+// UnmarshalYAML accepts both a single mock and a list of mocks.
+func (ms *Mocks) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []Mock
+	if err := unmarshal(&list); err == nil {
+		*ms = list
+		return nil
+	}
+
+	var single Mock
+	if err := unmarshal(&single); err != nil {
+		return err
+	}
+
+	*ms = Mocks{single}
+	return nil
+}
+
+// SnapshotPath returns the file where the case expected
+// response is stored, beside the case file.
+func (c Case) SnapshotPath() string {
+	for _, suffix := range caseSuffixes {
+		if strings.HasSuffix(c.Path, suffix) {
+			return strings.TrimSuffix(c.Path, suffix) + snapshotSuffix
+		}
+	}
+
+	return strings.TrimSuffix(c.Path, filepath.Ext(c.Path)) + snapshotSuffix
+}
+
+// LoadCase reads and validates the case defined in the file.
+func LoadCase(path string) (Case, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Case{}, errors.Wrapf(err, "failed to read test case %s", path)
+	}
+
+	var c Case
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return Case{}, errors.Wrapf(err, "invalid test case %s", path)
+	}
+
+	c.Path = path
+	if c.Name == "" {
+		c.Name = filepath.Base(path)
+	}
+
+	if err := validateCase(c); err != nil {
+		return Case{}, errors.Wrapf(err, "invalid test case %s", path)
+	}
+
+	c.Input.Params = normalizeMap(c.Input.Params)
+	c.Input.Body = normalizeYAML(c.Input.Body)
+	for resource, mocks := range c.Upstream {
+		for i := range mocks {
+			mocks[i].Params = normalizeMap(mocks[i].Params)
+			mocks[i].Body = normalizeYAML(mocks[i].Body)
+		}
+		c.Upstream[resource] = mocks
+	}
+
+	return c, nil
+}
+
+func validateCase(c Case) error {
+	q := c.Query
+	if q.IsSaved() && (q.Namespace == "" || q.ID == "" || q.Revision <= 0) {
+		return errors.New("query must have a text or a namespace, id and revision")
+	}
+
+	if !q.IsSaved() && (q.Namespace != "" || q.ID != "" || q.Revision != 0) {
+		return errors.New("query cannot have both a text and a saved query reference")
+	}
+
+	return nil
+}
+
+// FindCases returns the case files in the given paths, searching
+// directories recursively. Files are accepted regardless of their names.
+func FindCases(paths []string) ([]string, error) {
+	var files []string
+
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+
+		err = filepath.Walk(p, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !info.IsDir() && isCaseFile(path) {
+				files = append(files, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func isCaseFile(path string) bool {
+	for _, suffix := range caseSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+
+	normalized := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		normalized[k] = normalizeYAML(v)
+	}
+
+	return normalized
+}
+
+// normalizeYAML replaces the generic maps created by the YAML
+// decoder by maps with string keys, like the JSON decoder does.
+func normalizeYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			m[fmt.Sprintf("%v", k)] = normalizeYAML(value)
+		}
+		return m
+	case map[string]interface{}:
+		return normalizeMap(v)
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = normalizeYAML(value)
+		}
+		return l
+	default:
+		return v
+	}
+}
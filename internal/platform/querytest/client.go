@@ -0,0 +1,158 @@
+package querytest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+	"github.com/pkg/errors"
+)
+
+// ErrUnmatchedRequest is returned by the mocked upstream when
+// no mock defined by the case matches the request.
+var ErrUnmatchedRequest = errors.New("no mock matches the upstream request")
+
+// mockHostSuffix is appended to the resource name to build the host
+// of its mapping, so that the upstream calls can be traced back
+// to the statement resource.
+const mockHostSuffix = ".restql.mock"
+
+// mockMappingsReader replaces the host of every mapping
+// by one derived from the resource name.
+type mockMappingsReader struct {
+	reader engine.MappingsReader
+}
+
+func (mr mockMappingsReader) FromTenant(ctx context.Context, tenant string) (map[string]restql.Mapping, error) {
+	mappings, err := mr.reader.FromTenant(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	mocked := make(map[string]restql.Mapping, len(mappings))
+	for resource, m := range mappings {
+		url := strings.Replace(m.URL(), "://"+m.Host(), "://"+resource+mockHostSuffix, 1)
+		mm, err := restql.NewMapping(resource, url)
+		if err != nil {
+			return nil, err
+		}
+
+		mm.Source = m.Source
+		mocked[resource] = mm
+	}
+
+	return mocked, nil
+}
+
+// mockClient is an HTTPClient that serves the
+// upstream calls from the case mocks.
+type mockClient struct {
+	log      restql.Logger
+	upstream map[string]Mocks
+
+	unmatchedMu sync.Mutex
+	unmatched   []restql.HTTPRequest
+}
+
+func newMockClient(log restql.Logger, upstream map[string]Mocks) *mockClient {
+	return &mockClient{log: log, upstream: upstream}
+}
+
+func (mc *mockClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	resource := strings.TrimSuffix(request.Host, mockHostSuffix)
+	url := fmt.Sprintf("%s://%s%s", request.Schema, resource, request.Path)
+
+	mock, found := mc.findMock(resource, request)
+	if !found {
+		mc.unmatchedMu.Lock()
+		mc.unmatched = append(mc.unmatched, request)
+		mc.unmatchedMu.Unlock()
+
+		return restql.HTTPResponse{URL: url}, ErrUnmatchedRequest
+	}
+
+	status := mock.Status
+	if status == 0 {
+		status = 200
+	}
+
+	response := restql.HTTPResponse{
+		URL:        url,
+		StatusCode: status,
+		Headers:    mock.Headers,
+		Body:       restql.NewResponseBodyFromBytes(mc.log, nil),
+	}
+
+	if mock.Body != nil {
+		body, err := json.Marshal(mock.Body)
+		if err != nil {
+			return restql.HTTPResponse{URL: url}, errors.Wrapf(err, "invalid mock body for %s", resource)
+		}
+		response.Body = restql.NewResponseBodyFromBytes(mc.log, body)
+	}
+
+	if mock.Timeout {
+		response.StatusCode = 408
+		return response, domain.ErrRequestTimeout
+	}
+
+	return response, nil
+}
+
+func (mc *mockClient) findMock(resource string, request restql.HTTPRequest) (Mock, bool) {
+	for _, mock := range mc.upstream[resource] {
+		if mock.Path != "" && mock.Path != request.Path {
+			continue
+		}
+
+		if !paramsMatch(mock.Params, request.Query) {
+			continue
+		}
+
+		return mock, true
+	}
+
+	return Mock{}, false
+}
+
+// paramsMatch compares the values in their textual form,
+// since the query parameters types depend on how they
+// were defined in the query.
+func paramsMatch(expected map[string]interface{}, actual map[string]interface{}) bool {
+	for name, value := range expected {
+		actualValue, found := actual[name]
+		if !found || textual(value) != textual(actualValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func textual(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(b)
+}
+
+func (mc *mockClient) Unmatched() []restql.HTTPRequest {
+	mc.unmatchedMu.Lock()
+	defer mc.unmatchedMu.Unlock()
+
+	unmatched := make([]restql.HTTPRequest, len(mc.unmatched))
+	copy(unmatched, mc.unmatched)
+
+	return unmatched
+}
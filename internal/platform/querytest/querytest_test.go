@@ -0,0 +1,167 @@
+package querytest_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/querytest"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+const heroCase = `
+name: hero with sidekick
+query:
+  text: |
+    from hero
+      with name = $name
+    from sidekick
+      with hero = hero.id
+input:
+  params:
+    name: batman
+mappings:
+  hero: http://hero.api/heroes
+  sidekick: http://sidekick.api/heroes/:hero/sidekicks
+upstream:
+  hero:
+    params: {name: batman}
+    body: {id: 1, name: Batman}
+  sidekick:
+    - path: /heroes/2/sidekicks
+      status: 404
+    - path: /heroes/1/sidekicks
+      body: [{name: Robin}]
+`
+
+func newRunner(t *testing.T) querytest.Runner {
+	cfg := &conf.Config{Env: conf.EnvSource{}}
+	cfg.HTTP.QueryResourceTimeout = time.Second
+	cfg.HTTP.GlobalQueryTimeout = time.Second
+
+	r, err := querytest.NewRunner(test.NoOpLogger, cfg)
+	test.VerifyError(t, err)
+
+	return r
+}
+
+func writeCase(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "hero.test.yml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	test.VerifyError(t, err)
+
+	return path
+}
+
+func TestRunner_Run(t *testing.T) {
+	c, err := querytest.LoadCase(writeCase(t, heroCase))
+	test.VerifyError(t, err)
+
+	got, err := newRunner(t).Run(context.Background(), c)
+	test.VerifyError(t, err)
+
+	expected := querytest.Snapshot{
+		StatusCode: 200,
+		Headers:    map[string]string{},
+		Body: test.Unmarshal(`{
+			"hero": {"details": {"status": 200, "success": true, "metadata": {}}, "result": {"id": 1, "name": "Batman"}},
+			"sidekick": {"details": {"status": 200, "success": true, "metadata": {}}, "result": [{"name": "Robin"}]}
+		}`),
+	}
+
+	test.Equal(t, got, expected)
+}
+
+func TestRunner_RunWithUnmatchedRequest(t *testing.T) {
+	c, err := querytest.LoadCase(writeCase(t, heroCase))
+	test.VerifyError(t, err)
+
+	c.Input.Params["name"] = "joker"
+
+	_, err = newRunner(t).Run(context.Background(), c)
+	test.Equal(t, errors.Is(err, querytest.ErrUnmatchedRequest), true)
+}
+
+func TestRunner_RunWithEvaluationError(t *testing.T) {
+	c, err := querytest.LoadCase(writeCase(t, heroCase))
+	test.VerifyError(t, err)
+
+	c.Query.Text = "from villain"
+
+	got, err := newRunner(t).Run(context.Background(), c)
+	test.VerifyError(t, err)
+
+	test.NotEqual(t, got.Error, "")
+}
+
+func TestLoadCase_InvalidQuery(t *testing.T) {
+	_, err := querytest.LoadCase(writeCase(t, "query:\n  namespace: demo\n"))
+	if err == nil {
+		t.Fatalf("expected error for incomplete saved query reference")
+	}
+}
+
+func TestCompareSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hero.snap.json")
+	snapshot := querytest.Snapshot{StatusCode: 200, Body: test.Unmarshal(`{"hero": {"name": "Batman"}}`)}
+
+	_, err := querytest.CompareSnapshot(path, snapshot)
+	test.Equal(t, errors.Is(err, querytest.ErrSnapshotNotFound), true)
+
+	err = querytest.WriteSnapshot(path, snapshot)
+	test.VerifyError(t, err)
+
+	diff, err := querytest.CompareSnapshot(path, snapshot)
+	test.VerifyError(t, err)
+	test.Equal(t, diff, "")
+
+	changed := querytest.Snapshot{StatusCode: 200, Body: test.Unmarshal(`{"hero": {"name": "Bruce"}}`)}
+	diff, err = querytest.CompareSnapshot(path, changed)
+	test.VerifyError(t, err)
+	test.NotEqual(t, diff, "")
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		diff     string
+	}{
+		{
+			"equal texts",
+			"a\nb\nc\n",
+			"a\nb\nc\n",
+			"",
+		},
+		{
+			"changed line",
+			"a\nb\nc\n",
+			"a\nx\nc\n",
+			"--- snapshot\n+++ actual\n  a\n- b\n+ x\n  c\n",
+		},
+		{
+			"added line",
+			"a\nb\n",
+			"a\nb\nc\n",
+			"--- snapshot\n+++ actual\n  a\n  b\n+ c\n",
+		},
+		{
+			"changes far apart",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			"0\n2\n3\n4\n5\n6\n7\n8\n9\n11\n",
+			"--- snapshot\n+++ actual\n- 1\n+ 0\n  2\n  3\n  4\n  ...\n  7\n  8\n  9\n- 10\n+ 11\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := querytest.Diff([]byte(tt.expected), []byte(tt.actual))
+			test.Equal(t, got, tt.diff)
+		})
+	}
+}
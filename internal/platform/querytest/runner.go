@@ -0,0 +1,153 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+)
+
+// defaultTenant is used when neither the case
+// nor the configuration defines a tenant.
+const defaultTenant = "default"
+
+// Runner executes test cases through the restQL engine,
+// using the mappings and saved queries from the configuration.
+// Saved queries are also read from the queries directory, if configured.
+// Only function plugins are used, while other plugins and
+// the database are not.
+type Runner struct {
+	log       restql.Logger
+	cfg       *conf.Config
+	functions []restql.Function
+	queryDir  *persistence.QueryDirectory
+}
+
+// NewRunner constructs a Runner for the configuration.
+func NewRunner(log restql.Logger, cfg *conf.Config) (Runner, error) {
+	r := Runner{log: log, cfg: cfg, functions: plugins.NewFunctions(log)}
+
+	if path := cfg.QueriesDirectory.Path; path != "" {
+		dir, err := persistence.NewQueryDirectory(log, path)
+		if err != nil {
+			return Runner{}, err
+		}
+		r.queryDir = dir
+	}
+
+	return r, nil
+}
+
+// Run executes the case query and returns its response snapshot.
+// Evaluation failures are part of the snapshot, while upstream
+// calls without a matching mock fail the run.
+func (r Runner) Run(ctx context.Context, c Case) (Snapshot, error) {
+	db, err := persistence.NewDatabase(r.log, persistence.DatabaseOptions{Disabled: true})
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	var mr engine.MappingsReader = persistence.NewMappingReader(r.log, r.cfg.Env, r.cfg.TenantMappings, db)
+	if len(c.Mappings) > 0 {
+		mr = caseMappingsReader{reader: mr, mappings: c.Mappings}
+	}
+	mr = mockMappingsReader{reader: mr}
+
+	client := newMockClient(r.log, c.Upstream)
+	e, err := engine.New(engine.Options{
+		Logger:             r.log,
+		HTTPClient:         client,
+		Tenant:             r.tenant(c),
+		MappingsReader:     mr,
+		QueryReader:        persistence.NewQueryReader(r.log, r.cfg.Queries, db).WithDirectory(r.queryDir),
+		ResourceTimeout:    r.cfg.HTTP.QueryResourceTimeout,
+		GlobalQueryTimeout: r.cfg.HTTP.GlobalQueryTimeout,
+		ForwardPrefix:      r.cfg.HTTP.ForwardPrefix,
+		Functions:          r.functions,
+	})
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	input := engine.Input{Params: c.Input.Params, Headers: c.Input.Headers, Body: c.Input.Body}
+	ctx = restql.WithLogger(ctx, r.log)
+
+	var result engine.Result
+	if c.Query.IsSaved() {
+		result, err = e.RunSaved(ctx, c.Query.Namespace, c.Query.ID, c.Query.Revision, input)
+	} else {
+		result, err = e.Run(ctx, c.Query.Text, input)
+	}
+
+	if unmatched := client.Unmatched(); len(unmatched) > 0 {
+		return Snapshot{}, unmatchedError(unmatched)
+	}
+
+	if err != nil {
+		return Snapshot{Error: err.Error()}, nil
+	}
+
+	response, err := web.MakeResultResponse(result, restql.QueryInput{Params: input.Params}, false)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	return newSnapshot(response)
+}
+
+func (r Runner) tenant(c Case) string {
+	switch {
+	case c.Tenant != "":
+		return c.Tenant
+	case r.cfg.Tenant != "":
+		return r.cfg.Tenant
+	default:
+		return defaultTenant
+	}
+}
+
+func unmatchedError(unmatched []restql.HTTPRequest) error {
+	requests := make([]string, len(unmatched))
+	for i, request := range unmatched {
+		resource := strings.TrimSuffix(request.Host, mockHostSuffix)
+		requests[i] = fmt.Sprintf("%s %s%s %v", request.Method, resource, request.Path, request.Query)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnmatchedRequest, strings.Join(requests, ", "))
+}
+
+// caseMappingsReader adds the mappings defined by the
+// case to the ones available for the tenant.
+type caseMappingsReader struct {
+	reader   engine.MappingsReader
+	mappings map[string]string
+}
+
+func (cr caseMappingsReader) FromTenant(ctx context.Context, tenant string) (map[string]restql.Mapping, error) {
+	mappings, err := cr.reader.FromTenant(ctx, tenant)
+	if err != nil && !errors.Is(err, restql.ErrMappingsNotFound) {
+		return nil, err
+	}
+
+	result := make(map[string]restql.Mapping, len(mappings)+len(cr.mappings))
+	for resource, m := range mappings {
+		result[resource] = m
+	}
+
+	for resource, url := range cr.mappings {
+		m, err := restql.NewMapping(resource, url)
+		if err != nil {
+			return nil, err
+		}
+		result[resource] = m
+	}
+
+	return result, nil
+}
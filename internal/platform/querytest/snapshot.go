@@ -0,0 +1,245 @@
+package querytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web"
+	"github.com/pkg/errors"
+)
+
+// ErrSnapshotNotFound is returned when a case
+// has no stored snapshot to be compared with.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// Snapshot is the stored form of a query response.
+// When the query evaluation fails, only the error is stored.
+type Snapshot struct {
+	StatusCode int               `json:"statusCode,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       interface{}       `json:"body,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// newSnapshot transforms the response body into its generic
+// JSON representation, so that it can be compared with a
+// snapshot read from a file.
+func newSnapshot(response web.QueryResponse) (Snapshot, error) {
+	data, err := json.Marshal(response.Body)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return Snapshot{}, err
+	}
+
+	return Snapshot{StatusCode: response.StatusCode, Headers: response.Headers, Body: body}, nil
+}
+
+// Marshal returns the snapshot as indented JSON,
+// with object keys sorted.
+func (s Snapshot) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	e.SetIndent("", "  ")
+
+	if err := e.Encode(s); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// readSnapshot loads the stored snapshot from the file.
+func readSnapshot(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotNotFound, path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read snapshot %s", path)
+	}
+
+	return data, nil
+}
+
+// CompareSnapshot returns the difference between the snapshot
+// stored in the file and the actual one, or an empty string
+// if they are equal.
+func CompareSnapshot(path string, actual Snapshot) (string, error) {
+	data, err := readSnapshot(path)
+	if err != nil {
+		return "", err
+	}
+
+	var stored Snapshot
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return "", errors.Wrapf(err, "invalid snapshot %s", path)
+	}
+
+	expected, err := stored.Marshal()
+	if err != nil {
+		return "", err
+	}
+
+	got, err := actual.Marshal()
+	if err != nil {
+		return "", err
+	}
+
+	return Diff(expected, got), nil
+}
+
+// WriteSnapshot stores the snapshot in the file.
+func WriteSnapshot(path string, s Snapshot) error {
+	data, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// diffContext is the number of unchanged
+// lines shown around each change.
+const diffContext = 3
+
+type diffOp byte
+
+const (
+	diffEqual  diffOp = ' '
+	diffDelete diffOp = '-'
+	diffInsert diffOp = '+'
+)
+
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// Diff compares the expected and actual texts line by line, returning
+// the changes with the expected lines prefixed by "-" and the actual
+// lines prefixed by "+". It returns an empty string when they are equal.
+func Diff(expected, actual []byte) string {
+	a := splitLines(expected)
+	b := splitLines(actual)
+
+	lines := diffLines(a, b)
+
+	changed := false
+	for _, l := range lines {
+		if l.op != diffEqual {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("--- snapshot\n+++ actual\n")
+
+	lastPrinted := -1
+	for i, l := range lines {
+		if !nearChange(lines, i) {
+			continue
+		}
+
+		if lastPrinted >= 0 && i > lastPrinted+1 {
+			sb.WriteString("  ...\n")
+		}
+
+		sb.WriteByte(byte(l.op))
+		sb.WriteByte(' ')
+		sb.WriteString(l.text)
+		sb.WriteByte('\n')
+
+		lastPrinted = i
+	}
+
+	return sb.String()
+}
+
+func nearChange(lines []diffLine, i int) bool {
+	from := i - diffContext
+	if from < 0 {
+		from = 0
+	}
+
+	to := i + diffContext
+	if to >= len(lines) {
+		to = len(lines) - 1
+	}
+
+	for j := from; j <= to; j++ {
+		if lines[j].op != diffEqual {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitLines(data []byte) []string {
+	s := strings.TrimRight(string(data), "\n")
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, "\n")
+}
+
+// diffLines builds the edit script from the
+// longest common subsequence of the lines.
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{diffEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{diffDelete, a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{diffInsert, b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{diffDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{diffInsert, b[j]})
+	}
+
+	return lines
+}
@@ -0,0 +1,280 @@
+package recording
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// Matching defines which parts of an upstream request
+// must be equal to a recorded one to replay it.
+type Matching string
+
+// Matching strictness levels available for replay.
+const (
+	// StrictMatching compares method, URL, query parameters, body and headers.
+	StrictMatching Matching = "strict"
+	// DefaultMatching compares method, URL, query parameters and body.
+	DefaultMatching Matching = "default"
+	// LooseMatching compares method and URL.
+	LooseMatching Matching = "loose"
+)
+
+// ParseMatching returns the Matching identified by the given name,
+// using the DefaultMatching when the name is empty.
+func ParseMatching(name string) (Matching, error) {
+	switch m := Matching(name); m {
+	case "":
+		return DefaultMatching, nil
+	case StrictMatching, DefaultMatching, LooseMatching:
+		return m, nil
+	default:
+		return "", errors.Errorf("unknown recording matching: %s", name)
+	}
+}
+
+// Options wraps the parameters used to build
+// the key that identifies an upstream request.
+type Options struct {
+	Matching Matching
+	// IgnoreHeaders are not compared by the StrictMatching,
+	// useful for headers that change on every request,
+	// like request ids.
+	IgnoreHeaders []string
+}
+
+// sensitiveHeaders carry credentials, hence they are redacted
+// on the fixture files and not compared by the StrictMatching.
+var sensitiveHeaders = []string{"authorization", "proxy-authorization", "cookie", "x-api-key"}
+
+const redactedHeaderValue = "[REDACTED]"
+
+type fixture struct {
+	Request  fixtureRequest  `json:"request"`
+	Response fixtureResponse `json:"response"`
+}
+
+type fixtureRequest struct {
+	Method  string                 `json:"method"`
+	Schema  string                 `json:"schema"`
+	Host    string                 `json:"host"`
+	Path    string                 `json:"path"`
+	Query   map[string]interface{} `json:"query,omitempty"`
+	Headers map[string]string      `json:"headers,omitempty"`
+	Body    interface{}            `json:"body,omitempty"`
+}
+
+type fixtureResponse struct {
+	URL        string            `json:"url"`
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       json.RawMessage   `json:"body,omitempty"`
+	BodyText   string            `json:"bodyText,omitempty"`
+	DurationMs int64             `json:"durationMs"`
+	Error      string            `json:"error,omitempty"`
+	Timeout    bool              `json:"timeout,omitempty"`
+}
+
+func newFixtureRequest(request restql.HTTPRequest, options Options) fixtureRequest {
+	return fixtureRequest{
+		Method:  request.Method,
+		Schema:  request.Schema,
+		Host:    request.Host,
+		Path:    request.Path,
+		Query:   request.Query,
+		Headers: redactHeaders(request.Headers, options.IgnoreHeaders),
+		Body:    request.Body,
+	}
+}
+
+// redactHeaders replaces the values of the sensitive and
+// ignored headers, so that they are not written to disk.
+func redactHeaders(headers map[string]string, ignore []string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+
+	excluded := excludedHeaders(ignore)
+
+	redacted := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if _, found := excluded[strings.ToLower(k)]; found {
+			v = redactedHeaderValue
+		}
+
+		redacted[k] = v
+	}
+
+	return redacted
+}
+
+func newFixtureResponse(response restql.HTTPResponse, err error) (fixtureResponse, error) {
+	fr := fixtureResponse{
+		URL:        response.URL,
+		StatusCode: response.StatusCode,
+		Headers:    response.Headers,
+		DurationMs: response.Duration.Milliseconds(),
+	}
+
+	if err != nil {
+		fr.Error = err.Error()
+		fr.Timeout = errors.Is(err, domain.ErrRequestTimeout)
+	}
+
+	if response.Body == nil {
+		return fr, nil
+	}
+
+	body, err := response.Body.Marshal()
+	if err != nil {
+		return fixtureResponse{}, err
+	}
+
+	switch body := body.(type) {
+	case json.RawMessage:
+		fr.Body = body
+	case string:
+		fr.BodyText = body
+	}
+
+	return fr, nil
+}
+
+func (fr fixtureResponse) toResponse(log restql.Logger) (restql.HTTPResponse, error) {
+	response := restql.HTTPResponse{
+		URL:        fr.URL,
+		StatusCode: fr.StatusCode,
+		Headers:    fr.Headers,
+		Duration:   time.Duration(fr.DurationMs) * time.Millisecond,
+	}
+
+	switch {
+	case len(fr.Body) > 0:
+		response.Body = restql.NewResponseBodyFromBytes(log, fr.Body)
+	case fr.BodyText != "":
+		response.Body = restql.NewResponseBodyFromBytes(log, []byte(fr.BodyText))
+	default:
+		response.Body = restql.NewResponseBodyFromBytes(log, nil)
+	}
+
+	switch {
+	case fr.Timeout:
+		return response, domain.ErrRequestTimeout
+	case fr.Error != "":
+		return response, errors.New(fr.Error)
+	default:
+		return response, nil
+	}
+}
+
+type requestKeyFields struct {
+	Method  string            `json:"method"`
+	Schema  string            `json:"schema"`
+	Host    string            `json:"host"`
+	Path    string            `json:"path"`
+	Query   interface{}       `json:"query,omitempty"`
+	Body    interface{}       `json:"body,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// requestKey normalizes the request into a string that
+// identifies it according to the matching options.
+func requestKey(request fixtureRequest, options Options) string {
+	key := requestKeyFields{
+		Method: strings.ToUpper(request.Method),
+		Schema: request.Schema,
+		Host:   request.Host,
+		Path:   request.Path,
+	}
+
+	if options.Matching != LooseMatching {
+		if len(request.Query) > 0 {
+			key.Query = normalizeValue(request.Query)
+		}
+		key.Body = normalizeValue(request.Body)
+	}
+
+	if options.Matching == StrictMatching {
+		key.Headers = normalizeHeaders(request.Headers, options.IgnoreHeaders)
+	}
+
+	b, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Sprintf("%#v", key)
+	}
+
+	return string(b)
+}
+
+// normalizeValue transforms the value into its generic JSON
+// representation, so that values built by restQL and values
+// read from fixture files can be compared.
+func normalizeValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return string(b)
+	}
+
+	return generic
+}
+
+func normalizeHeaders(headers map[string]string, ignore []string) map[string]string {
+	excluded := excludedHeaders(ignore)
+
+	normalized := make(map[string]string, len(headers))
+	for k, v := range headers {
+		k = strings.ToLower(k)
+		if _, found := excluded[k]; found {
+			continue
+		}
+
+		normalized[k] = v
+	}
+
+	return normalized
+}
+
+// excludedHeaders returns the lower cased names of the sensitive
+// and ignored headers, which are neither recorded nor compared.
+func excludedHeaders(ignore []string) map[string]struct{} {
+	excluded := make(map[string]struct{}, len(sensitiveHeaders)+len(ignore))
+	for _, h := range sensitiveHeaders {
+		excluded[h] = struct{}{}
+	}
+	for _, h := range ignore {
+		excluded[strings.ToLower(h)] = struct{}{}
+	}
+
+	return excluded
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// fixtureName builds a readable file name for the request,
+// with a hash of its normalized form to avoid collisions.
+func fixtureName(request fixtureRequest, options Options) string {
+	sum := sha256.Sum256([]byte(requestKey(request, options)))
+	hash := hex.EncodeToString(sum[:])[:16]
+
+	name := strings.ToLower(request.Method) + "_" + request.Host + request.Path
+	name = strings.Trim(unsafeFilenameChars.ReplaceAllString(name, "_"), "_")
+
+	return name + "_" + hash + ".json"
+}
@@ -0,0 +1,90 @@
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// Recorder is an HTTPClient that delegates the upstream calls
+// to another client and writes every request and response pair
+// as a fixture file in a directory, to be later served by a Replayer.
+type Recorder struct {
+	log     restql.Logger
+	client  domain.HTTPClient
+	dir     string
+	options Options
+}
+
+// NewRecorder constructs a Recorder that writes fixtures
+// into dir, creating it when necessary.
+func NewRecorder(log restql.Logger, client domain.HTTPClient, dir string, options Options) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create recording directory")
+	}
+
+	return &Recorder{log: log, client: client, dir: dir, options: options}, nil
+}
+
+// Do executes the request with the wrapped client and
+// records the result before returning it.
+func (r *Recorder) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	response, err := r.client.Do(ctx, request)
+
+	if recordErr := r.record(request, response, err); recordErr != nil {
+		r.log.Error("failed to record upstream call", recordErr, "url", response.URL)
+	}
+
+	return response, err
+}
+
+// Close closes the wrapped client, when it supports being closed.
+func (r *Recorder) Close() error {
+	if closer, ok := r.client.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func (r *Recorder) record(request restql.HTTPRequest, response restql.HTTPResponse, err error) error {
+	fr, marshalErr := newFixtureResponse(response, err)
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	f := fixture{Request: newFixtureRequest(request, r.options), Response: fr}
+
+	data, marshalErr := json.MarshalIndent(f, "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	path := filepath.Join(r.dir, fixtureName(f.Request, r.options))
+
+	tmp, createErr := ioutil.TempFile(r.dir, ".fixture-*")
+	if createErr != nil {
+		return createErr
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		tmp.Close()
+		return writeErr
+	}
+
+	if closeErr := tmp.Close(); closeErr != nil {
+		return closeErr
+	}
+
+	r.log.Debug("upstream call recorded", "url", response.URL, "fixture", path)
+
+	return os.Rename(tmp.Name(), path)
+}
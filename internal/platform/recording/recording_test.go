@@ -0,0 +1,238 @@
+package recording_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/recording"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+type clientFn func(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error)
+
+func (c clientFn) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	return c(ctx, request)
+}
+
+var upstream = clientFn(func(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	url := "http://" + request.Host + request.Path
+
+	switch request.Path {
+	case "/slow":
+		return restql.HTTPResponse{URL: url, StatusCode: 408}, domain.ErrRequestTimeout
+	case "/text":
+		return restql.HTTPResponse{
+			URL:        url,
+			StatusCode: 200,
+			Body:       restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte("plain text")),
+		}, nil
+	default:
+		return restql.HTTPResponse{
+			URL:        url,
+			StatusCode: 200,
+			Headers:    restql.Headers{"X-Hero": "true"},
+			Duration:   15 * time.Millisecond,
+			Body:       restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(`{"id": 1, "name": "Batman"}`)),
+		}, nil
+	}
+})
+
+func heroRequest(path string) restql.HTTPRequest {
+	return restql.HTTPRequest{
+		Method:  "GET",
+		Schema:  "http",
+		Host:    "hero.api",
+		Path:    path,
+		Query:   map[string]interface{}{"id": 1, "fields": []interface{}{"name", "age"}},
+		Headers: restql.Headers{"X-Tid": "abc"},
+	}
+}
+
+func record(t *testing.T, dir string, requests ...restql.HTTPRequest) {
+	recorder, err := recording.NewRecorder(test.NoOpLogger, upstream, dir, recording.Options{})
+	test.VerifyError(t, err)
+
+	for _, r := range requests {
+		_, _ = recorder.Do(context.Background(), r)
+	}
+}
+
+func TestReplayer_Do(t *testing.T) {
+	dir := t.TempDir()
+	record(t, dir, heroRequest("/heroes"), heroRequest("/slow"), heroRequest("/text"))
+
+	t.Run("should replay recorded response", func(t *testing.T) {
+		replayer, err := recording.NewReplayer(test.NoOpLogger, dir, recording.Options{Matching: recording.DefaultMatching})
+		test.VerifyError(t, err)
+
+		response, err := replayer.Do(context.Background(), heroRequest("/heroes"))
+		test.VerifyError(t, err)
+
+		test.Equal(t, response.StatusCode, 200)
+		test.Equal(t, response.URL, "http://hero.api/heroes")
+		test.Equal(t, response.Headers, restql.Headers{"X-Hero": "true"})
+		test.Equal(t, response.Duration, 15*time.Millisecond)
+		test.Equal(t, response.Body.Unmarshal(), test.Unmarshal(`{"id": 1, "name": "Batman"}`))
+	})
+
+	t.Run("should replay recorded timeout", func(t *testing.T) {
+		replayer, err := recording.NewReplayer(test.NoOpLogger, dir, recording.Options{})
+		test.VerifyError(t, err)
+
+		response, err := replayer.Do(context.Background(), heroRequest("/slow"))
+
+		test.Equal(t, errors.Is(err, domain.ErrRequestTimeout), true)
+		test.Equal(t, response.StatusCode, 408)
+	})
+
+	t.Run("should replay recorded non json body", func(t *testing.T) {
+		replayer, err := recording.NewReplayer(test.NoOpLogger, dir, recording.Options{})
+		test.VerifyError(t, err)
+
+		response, err := replayer.Do(context.Background(), heroRequest("/text"))
+		test.VerifyError(t, err)
+
+		test.Equal(t, response.Body.Unmarshal(), "plain text")
+	})
+
+	t.Run("should report unmatched requests", func(t *testing.T) {
+		replayer, err := recording.NewReplayer(test.NoOpLogger, dir, recording.Options{})
+		test.VerifyError(t, err)
+
+		request := heroRequest("/heroes")
+		request.Query = map[string]interface{}{"id": 2}
+
+		_, err = replayer.Do(context.Background(), request)
+
+		test.Equal(t, errors.Is(err, recording.ErrUnmatchedRequest), true)
+		test.Equal(t, replayer.Unmatched(), []restql.HTTPRequest{request})
+	})
+
+	t.Run("should ignore query and body on loose matching", func(t *testing.T) {
+		replayer, err := recording.NewReplayer(test.NoOpLogger, dir, recording.Options{Matching: recording.LooseMatching})
+		test.VerifyError(t, err)
+
+		request := heroRequest("/heroes")
+		request.Query = map[string]interface{}{"id": 2}
+
+		response, err := replayer.Do(context.Background(), request)
+		test.VerifyError(t, err)
+
+		test.Equal(t, response.StatusCode, 200)
+	})
+
+	t.Run("should compare headers on strict matching", func(t *testing.T) {
+		replayer, err := recording.NewReplayer(test.NoOpLogger, dir, recording.Options{Matching: recording.StrictMatching})
+		test.VerifyError(t, err)
+
+		request := heroRequest("/heroes")
+		request.Headers = restql.Headers{"X-Tid": "def"}
+
+		_, err = replayer.Do(context.Background(), request)
+		test.Equal(t, errors.Is(err, recording.ErrUnmatchedRequest), true)
+
+		request.Headers = restql.Headers{"x-tid": "abc"}
+
+		_, err = replayer.Do(context.Background(), request)
+		test.VerifyError(t, err)
+	})
+
+	t.Run("should skip ignored headers on strict matching", func(t *testing.T) {
+		options := recording.Options{Matching: recording.StrictMatching, IgnoreHeaders: []string{"X-TID"}}
+		replayer, err := recording.NewReplayer(test.NoOpLogger, dir, options)
+		test.VerifyError(t, err)
+
+		request := heroRequest("/heroes")
+		request.Headers = restql.Headers{"X-Tid": "def"}
+
+		_, err = replayer.Do(context.Background(), request)
+		test.VerifyError(t, err)
+	})
+}
+
+func TestRecorder_Do(t *testing.T) {
+	t.Run("should redact sensitive and ignored headers", func(t *testing.T) {
+		dir := t.TempDir()
+		options := recording.Options{Matching: recording.StrictMatching, IgnoreHeaders: []string{"X-TID"}}
+		recorder, err := recording.NewRecorder(test.NoOpLogger, upstream, dir, options)
+		test.VerifyError(t, err)
+
+		request := heroRequest("/heroes")
+		request.Headers = restql.Headers{"Authorization": "Bearer secret-token", "X-Tid": "abc", "X-Hero": "true"}
+
+		_, err = recorder.Do(context.Background(), request)
+		test.VerifyError(t, err)
+
+		paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		test.VerifyError(t, err)
+		test.Equal(t, len(paths), 1)
+
+		data, err := ioutil.ReadFile(paths[0])
+		test.VerifyError(t, err)
+
+		var f struct {
+			Request struct {
+				Headers map[string]string `json:"headers"`
+			} `json:"request"`
+		}
+		test.VerifyError(t, json.Unmarshal(data, &f))
+		test.Equal(t, f.Request.Headers, map[string]string{"Authorization": "[REDACTED]", "X-Tid": "[REDACTED]", "X-Hero": "true"})
+
+		replayer, err := recording.NewReplayer(test.NoOpLogger, dir, options)
+		test.VerifyError(t, err)
+
+		_, err = replayer.Do(context.Background(), request)
+		test.VerifyError(t, err)
+	})
+
+	t.Run("should close the wrapped client", func(t *testing.T) {
+		client := &closableClient{HTTPClient: upstream}
+		recorder, err := recording.NewRecorder(test.NoOpLogger, client, t.TempDir(), recording.Options{})
+		test.VerifyError(t, err)
+
+		test.VerifyError(t, recorder.Close())
+		test.Equal(t, client.closed, true)
+	})
+}
+
+type closableClient struct {
+	restql.HTTPClient
+	closed bool
+}
+
+func (c *closableClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestParseMatching(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected recording.Matching
+	}{
+		{"", recording.DefaultMatching},
+		{"strict", recording.StrictMatching},
+		{"default", recording.DefaultMatching},
+		{"loose", recording.LooseMatching},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := recording.ParseMatching(tt.name)
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+
+	_, err := recording.ParseMatching("unknown")
+	if err == nil {
+		t.Fatalf("expected error for unknown matching")
+	}
+}
@@ -0,0 +1,114 @@
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// ErrUnmatchedRequest is returned by the Replayer when there
+// is no recorded fixture for the upstream request.
+var ErrUnmatchedRequest = errors.New("no recorded fixture matches the request")
+
+// Replayer is an HTTPClient that serves upstream calls from the
+// fixture files written by a Recorder, without network access.
+type Replayer struct {
+	log      restql.Logger
+	options  Options
+	fixtures map[string]fixtureResponse
+
+	unmatchedMu sync.Mutex
+	unmatched   []restql.HTTPRequest
+}
+
+// NewReplayer constructs a Replayer that loads
+// all fixtures in dir, indexed by the matching options.
+// When more than one fixture matches the same key,
+// the first one in lexical file name order is used.
+func NewReplayer(log restql.Logger, dir string, options Options) (*Replayer, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	fixtures := make(map[string]fixtureResponse, len(paths))
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read fixture %s", path)
+		}
+
+		var f fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, errors.Wrapf(err, "invalid fixture %s", path)
+		}
+
+		key := requestKey(f.Request, options)
+		if _, found := fixtures[key]; found {
+			log.Debug("ignoring fixture with duplicated request", "fixture", path)
+			continue
+		}
+
+		fixtures[key] = f.Response
+	}
+
+	log.Info("upstream fixtures loaded", "dir", dir, "count", len(fixtures), "matching", options.Matching)
+
+	return &Replayer{log: log, options: options, fixtures: fixtures}, nil
+}
+
+// Do returns the recorded response for the request, or fails
+// with ErrUnmatchedRequest like an unreachable upstream.
+func (r *Replayer) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	fr, found := r.fixtures[requestKey(newFixtureRequest(request, r.options), r.options)]
+	if !found {
+		url := fmt.Sprintf("%s://%s%s", request.Schema, request.Host, request.Path)
+		r.log.Warn("unmatched upstream request on replay", "method", request.Method, "url", url, "query", request.Query)
+
+		r.unmatchedMu.Lock()
+		r.unmatched = append(r.unmatched, request)
+		r.unmatchedMu.Unlock()
+
+		return restql.HTTPResponse{URL: url}, ErrUnmatchedRequest
+	}
+
+	return fr.toResponse(r.log)
+}
+
+// Unmatched returns the requests that had
+// no recorded fixture since the Replayer creation.
+func (r *Replayer) Unmatched() []restql.HTTPRequest {
+	r.unmatchedMu.Lock()
+	defer r.unmatchedMu.Unlock()
+
+	unmatched := make([]restql.HTTPRequest, len(r.unmatched))
+	copy(unmatched, r.unmatched)
+
+	return unmatched
+}
+
+// Close reports the requests that had no recorded fixture,
+// so that missing fixtures are noticed when the replay ends.
+func (r *Replayer) Close() error {
+	unmatched := r.Unmatched()
+	if len(unmatched) == 0 {
+		return nil
+	}
+
+	urls := make([]string, len(unmatched))
+	for i, request := range unmatched {
+		urls[i] = fmt.Sprintf("%s %s://%s%s", request.Method, request.Schema, request.Host, request.Path)
+	}
+
+	r.log.Warn("upstream requests without recorded fixture on replay", "count", len(unmatched), "requests", urls)
+
+	return nil
+}
@@ -1,13 +1,19 @@
 package web
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
 	"github.com/valyala/fasthttp"
 	"strconv"
+	"time"
 )
 
 type queryRevision struct {
@@ -31,26 +37,69 @@ type mapping struct {
 }
 
 type administrator struct {
-	log               restql.Logger
-	mr                persistence.MappingsReader
-	mw                persistence.MappingsWriter
-	qr                persistence.QueryReader
-	queryWriter       persistence.QueryWriter
-	authorizationCode []byte
+	log         restql.Logger
+	mr          persistence.MappingsReader
+	mw          persistence.MappingsWriter
+	qr          persistence.QueryReader
+	queryWriter persistence.QueryWriter
+	stores      stores
+	auth        *adminAuthorizer
+	audit       persistence.AuditLog
+	parser      parser.Parser
+	engine      *engine.Engine
+	tenant      string
+
+	requestIDHeader string
 }
 
-func newAdmin(log restql.Logger, mr persistence.MappingsReader, mw persistence.MappingsWriter, qr persistence.QueryReader, qw persistence.QueryWriter, authorizationCode string) *administrator {
-	return &administrator{log: log, mr: mr, mw: mw, qr: qr, queryWriter: qw, authorizationCode: []byte(authorizationCode)}
+// adminOptions are the dependencies of the administrative API.
+type adminOptions struct {
+	MappingsReader  persistence.MappingsReader
+	MappingsWriter  persistence.MappingsWriter
+	QueryReader     persistence.QueryReader
+	QueryWriter     persistence.QueryWriter
+	Stores          stores
+	Authorizer      *adminAuthorizer
+	AuditLog        persistence.AuditLog
+	Parser          parser.Parser
+	Engine          *engine.Engine
+	Tenant          string
+	RequestIDHeader string
 }
 
-func (adm *administrator) AllTenants(ctx *fasthttp.RequestCtx) error {
+var (
+	errInvalidAdminBody   = errors.New("invalid request body")
+	errInvalidAuditFilter = errors.New("invalid audit filter")
+)
+
+func newAdmin(log restql.Logger, o adminOptions) *administrator {
+	return &administrator{
+		log:             log,
+		mr:              o.MappingsReader,
+		mw:              o.MappingsWriter,
+		qr:              o.QueryReader,
+		queryWriter:     o.QueryWriter,
+		stores:          o.Stores,
+		auth:            o.Authorizer,
+		audit:           o.AuditLog,
+		parser:          o.Parser,
+		engine:          o.Engine,
+		tenant:          o.Tenant,
+		requestIDHeader: o.RequestIDHeader,
+	}
+}
+
+func (adm *administrator) AllTenants(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
 	tenants, err := adm.mr.ListTenants(ctx)
 	if err != nil {
-		return RespondError(ctx, err, errToStatusCode)
+		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	data := map[string]interface{}{"tenants": tenants}
-	return Respond(ctx, data, fasthttp.StatusOK, nil)
+	data := map[string]interface{}{"tenants": filterReadable(reqCtx, tenantKind, tenants)}
+	return Respond(reqCtx, data, fasthttp.StatusOK, nil)
 }
 
 func (adm *administrator) TenantMappings(reqCtx *fasthttp.RequestCtx) error {
@@ -96,7 +145,7 @@ func (adm administrator) AllNamespaces(reqCtx *fasthttp.RequestCtx) error {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	data := map[string]interface{}{"namespaces": namespaces}
+	data := map[string]interface{}{"namespaces": filterReadable(reqCtx, namespaceKind, namespaces)}
 	return Respond(reqCtx, data, fasthttp.StatusOK, nil)
 }
 
@@ -230,6 +279,91 @@ func (adm *administrator) Query(reqCtx *fasthttp.RequestCtx) error {
 	return Respond(reqCtx, qr, fasthttp.StatusOK, nil)
 }
 
+type queryDiff struct {
+	Namespace  string           `json:"namespace"`
+	Name       string           `json:"name"`
+	From       int              `json:"from"`
+	To         int              `json:"to"`
+	Text       string           `json:"text"`
+	Semantic   domain.QueryDiff `json:"semantic"`
+	Equivalent bool             `json:"equivalent"`
+}
+
+func (adm *administrator) QueryDiff(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	namespace, err := pathParamString(reqCtx, "namespace")
+	if err != nil {
+		adm.log.Error("failed to load namespace path param", err)
+		return err
+	}
+
+	queryName, err := pathParamString(reqCtx, "queryId")
+	if err != nil {
+		adm.log.Error("failed to load query name path param", err)
+		return err
+	}
+
+	from, err := strconv.Atoi(string(reqCtx.QueryArgs().Peek("from")))
+	if err != nil {
+		return RespondError(reqCtx, errInvalidRevisionType, errToStatusCode)
+	}
+
+	to, err := strconv.Atoi(string(reqCtx.QueryArgs().Peek("to")))
+	if err != nil {
+		return RespondError(reqCtx, errInvalidRevisionType, errToStatusCode)
+	}
+
+	fromRevision, err := adm.qr.Get(ctx, namespace, queryName, from)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	toRevision, err := adm.qr.Get(ctx, namespace, queryName, to)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	fromQuery, err := adm.parser.Parse(fromRevision.Text)
+	if err != nil {
+		return RespondError(reqCtx, fmt.Errorf("%w: revision %d: %s", parser.ErrInvalidQuery, from, err), errToStatusCode)
+	}
+
+	toQuery, err := adm.parser.Parse(toRevision.Text)
+	if err != nil {
+		return RespondError(reqCtx, fmt.Errorf("%w: revision %d: %s", parser.ErrInvalidQuery, to, err), errToStatusCode)
+	}
+
+	semantic := domain.DiffQueries(fromQuery, toQuery)
+
+	data := queryDiff{
+		Namespace:  namespace,
+		Name:       queryName,
+		From:       from,
+		To:         to,
+		Text:       unifiedDiff(revisionFileName(namespace, queryName, from), revisionFileName(namespace, queryName, to), fromRevision.Text, toRevision.Text),
+		Semantic:   semantic,
+		Equivalent: semantic.Empty(),
+	}
+	return Respond(reqCtx, data, fasthttp.StatusOK, nil)
+}
+
+func queryArgStrings(reqCtx *fasthttp.RequestCtx, key string) []string {
+	var values []string
+	for _, v := range reqCtx.QueryArgs().PeekMulti(key) {
+		if len(v) > 0 {
+			values = append(values, string(v))
+		}
+	}
+
+	return values
+}
+
+func revisionFileName(namespace string, queryName string, revision int) string {
+	return namespace + "/" + queryName + "/" + strconv.Itoa(revision)
+}
+
 type mapResourceBody struct {
 	Url string `json:"url"`
 }
@@ -263,6 +397,15 @@ func (adm *administrator) CreateResource(reqCtx *fasthttp.RequestCtx) error {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	adm.stores.invalidateTenant(tenantName)
+
+	adm.record(ctx, reqCtx, restql.AuditEvent{
+		Action: restql.AuditMappingCreate,
+		Tenant: tenantName,
+		Name:   resourceName,
+		After:  mrb.Url,
+	})
+
 	return Respond(reqCtx, nil, fasthttp.StatusCreated, nil)
 }
 
@@ -270,11 +413,6 @@ func (adm *administrator) UpdateResource(reqCtx *fasthttp.RequestCtx) error {
 	ctx := middleware.GetNativeContext(reqCtx)
 	ctx = restql.WithLogger(ctx, adm.log)
 
-	if !isAuthorized(reqCtx, adm.authorizationCode) {
-		reqCtx.Response.SetStatusCode(fasthttp.StatusUnauthorized)
-		return nil
-	}
-
 	tenantName, err := pathParamString(reqCtx, "tenantName")
 	if err != nil {
 		adm.log.Error("failed to load tenant name path param", err)
@@ -295,40 +433,110 @@ func (adm *administrator) UpdateResource(reqCtx *fasthttp.RequestCtx) error {
 		return err
 	}
 
+	previousURL := adm.mappingURL(ctx, tenantName, resourceName)
+
 	err = adm.mw.Update(ctx, tenantName, resourceName, mrb.Url)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	adm.stores.invalidateTenant(tenantName)
+
+	adm.record(ctx, reqCtx, restql.AuditEvent{
+		Action: restql.AuditMappingUpdate,
+		Tenant: tenantName,
+		Name:   resourceName,
+		Before: previousURL,
+		After:  mrb.Url,
+	})
+
 	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
 }
 
-func isAuthorized(ctx *fasthttp.RequestCtx, authorizationCode []byte) bool {
-	bearerCode := getBearerToken(ctx)
-	if len(bearerCode) == 0 {
-		return false
+func (adm *administrator) DeleteResource(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	tenantName, err := pathParamString(reqCtx, "tenantName")
+	if err != nil {
+		adm.log.Error("failed to load tenant name path param", err)
+		return err
 	}
 
-	bearerCode = bytes.TrimPrefix(bearerCode, []byte("Bearer"))
-	bearerCode = bytes.TrimPrefix(bearerCode, []byte("bearer"))
-	bearerCode = bytes.TrimSpace(bearerCode)
+	resourceName, err := pathParamString(reqCtx, "resource")
+	if err != nil {
+		adm.log.Error("failed to load resource name path param", err)
+		return err
+	}
+
+	previousURL := adm.mappingURL(ctx, tenantName, resourceName)
+
+	err = adm.mw.Delete(ctx, tenantName, resourceName)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	adm.stores.invalidateTenant(tenantName)
+
+	adm.record(ctx, reqCtx, restql.AuditEvent{
+		Action: restql.AuditMappingDelete,
+		Tenant: tenantName,
+		Name:   resourceName,
+		Before: previousURL,
+	})
 
-	return bytes.Equal(bearerCode, authorizationCode)
+	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
 }
 
-func getBearerToken(ctx *fasthttp.RequestCtx) []byte {
-	bearerCode := ctx.Request.Header.Peek("Authorization")
-	if len(bearerCode) > 0 {
-		return bearerCode
+func (adm *administrator) CreateTenant(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	tenantName, err := pathParamString(reqCtx, "tenantName")
+	if err != nil {
+		adm.log.Error("failed to load tenant name path param", err)
+		return err
 	}
 
-	bearerCode = ctx.Request.Header.Peek("authorization")
+	err = adm.mw.CreateTenant(ctx, tenantName)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
 
-	return bearerCode
+	adm.record(ctx, reqCtx, restql.AuditEvent{Action: restql.AuditTenantCreate, Tenant: tenantName})
+
+	return Respond(reqCtx, nil, fasthttp.StatusCreated, nil)
 }
 
+func (adm *administrator) DeleteTenant(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	tenantName, err := pathParamString(reqCtx, "tenantName")
+	if err != nil {
+		adm.log.Error("failed to load tenant name path param", err)
+		return err
+	}
+
+	err = adm.mw.DeleteTenant(ctx, tenantName)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	adm.stores.invalidateTenant(tenantName)
+
+	adm.record(ctx, reqCtx, restql.AuditEvent{Action: restql.AuditTenantDelete, Tenant: tenantName})
+
+	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
+}
+
+// createRevisionBody has the revision text and,
+// for dry runs, the input used to execute it.
 type createRevisionBody struct {
-	Text string `json:"text"`
+	Text    string                 `json:"text"`
+	Params  map[string]interface{} `json:"params"`
+	Headers map[string]string      `json:"headers"`
+	Body    interface{}            `json:"body"`
 }
 
 func (adm *administrator) CreateQueryRevision(reqCtx *fasthttp.RequestCtx) error {
@@ -353,14 +561,168 @@ func (adm *administrator) CreateQueryRevision(reqCtx *fasthttp.RequestCtx) error
 		return err
 	}
 
+	tenants := queryArgStrings(reqCtx, "tenant")
+
+	executionTenants := tenants
+	if len(executionTenants) == 0 && adm.tenant != "" {
+		executionTenants = []string{adm.tenant}
+	}
+
+	if !adm.readableTenants(reqCtx, executionTenants) {
+		return RespondError(reqCtx, errAdminForbidden, errToStatusCode)
+	}
+
+	problems, err := adm.validateRevision(ctx, crb.Text, tenants)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	if len(problems) > 0 {
+		return respondInvalidRevision(reqCtx, problems)
+	}
+
+	dryRun, _ := strconv.ParseBool(string(reqCtx.QueryArgs().Peek("dryRun")))
+	if dryRun {
+		input := restql.QueryInput{Params: crb.Params, Headers: crb.Headers, Body: crb.Body}
+		data := map[string]interface{}{"results": adm.dryRun(ctx, crb.Text, tenants, input)}
+		return Respond(reqCtx, data, fasthttp.StatusOK, nil)
+	}
+
 	err = adm.queryWriter.Write(ctx, namespace, queryName, crb.Text)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	adm.record(ctx, reqCtx, restql.AuditEvent{
+		Action:    restql.AuditRevisionCreate,
+		Namespace: namespace,
+		Name:      queryName,
+		Revision:  adm.lastRevision(ctx, namespace, queryName),
+		After:     crb.Text,
+	})
+
 	return Respond(reqCtx, nil, fasthttp.StatusCreated, nil)
 }
 
+type renameNamespaceBody struct {
+	Name string `json:"name"`
+}
+
+func (adm *administrator) RenameNamespace(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	namespace, err := pathParamString(reqCtx, "namespace")
+	if err != nil {
+		adm.log.Error("failed to load namespace path param", err)
+		return err
+	}
+
+	var body renameNamespaceBody
+	err = json.Unmarshal(reqCtx.PostBody(), &body)
+	if err != nil || body.Name == "" {
+		return RespondError(reqCtx, errInvalidAdminBody, errToStatusCode)
+	}
+
+	if !adm.auth.allowed(reqCtx, namespaceKind, body.Name, writeAction) {
+		return RespondError(reqCtx, errAdminForbidden, errToStatusCode)
+	}
+
+	err = adm.queryWriter.RenameNamespace(ctx, namespace, body.Name)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	adm.stores.invalidateNamespace(namespace)
+
+	adm.record(ctx, reqCtx, restql.AuditEvent{
+		Action:    restql.AuditNamespaceRename,
+		Namespace: namespace,
+		Before:    namespace,
+		After:     body.Name,
+	})
+
+	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
+}
+
+func (adm *administrator) DeleteNamespace(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	namespace, err := pathParamString(reqCtx, "namespace")
+	if err != nil {
+		adm.log.Error("failed to load namespace path param", err)
+		return err
+	}
+
+	err = adm.queryWriter.DeleteNamespace(ctx, namespace)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	adm.stores.invalidateNamespace(namespace)
+
+	adm.record(ctx, reqCtx, restql.AuditEvent{Action: restql.AuditNamespaceDelete, Namespace: namespace})
+
+	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
+}
+
+type moveQueryBody struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func (adm *administrator) MoveQuery(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	namespace, err := pathParamString(reqCtx, "namespace")
+	if err != nil {
+		adm.log.Error("failed to load namespace path param", err)
+		return err
+	}
+
+	queryName, err := pathParamString(reqCtx, "queryId")
+	if err != nil {
+		adm.log.Error("failed to load query name path param", err)
+		return err
+	}
+
+	var body moveQueryBody
+	err = json.Unmarshal(reqCtx.PostBody(), &body)
+	if err != nil || (body.Namespace == "" && body.Name == "") {
+		return RespondError(reqCtx, errInvalidAdminBody, errToStatusCode)
+	}
+
+	if body.Namespace == "" {
+		body.Namespace = namespace
+	}
+	if body.Name == "" {
+		body.Name = queryName
+	}
+
+	if !adm.auth.allowed(reqCtx, namespaceKind, body.Namespace, writeAction) {
+		return RespondError(reqCtx, errAdminForbidden, errToStatusCode)
+	}
+
+	err = adm.queryWriter.Rename(ctx, namespace, queryName, body.Namespace, body.Name)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	adm.stores.invalidateQuery(namespace, queryName)
+
+	adm.record(ctx, reqCtx, restql.AuditEvent{
+		Action:    restql.AuditQueryMove,
+		Namespace: namespace,
+		Name:      queryName,
+		Before:    namespace + "/" + queryName,
+		After:     body.Namespace + "/" + body.Name,
+	})
+
+	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
+}
+
 type updateArchivingBody struct {
 	Archived bool `json:"archived"`
 }
@@ -389,11 +751,25 @@ func (adm *administrator) UpdateQueryArchiving(reqCtx *fasthttp.RequestCtx) erro
 		return err
 	}
 
+	previous := ""
+	savedQuery, err := adm.currentQuery(ctx, namespace, queryName)
+	if err == nil {
+		previous = strconv.FormatBool(savedQuery.Archived)
+	}
+
 	err = adm.queryWriter.UpdateQueryArchiving(ctx, namespace, queryName, body.Archived)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	adm.record(ctx, reqCtx, restql.AuditEvent{
+		Action:    restql.AuditQueryArchiving,
+		Namespace: namespace,
+		Name:      queryName,
+		Before:    previous,
+		After:     strconv.FormatBool(body.Archived),
+	})
+
 	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
 }
 
@@ -433,14 +809,154 @@ func (adm *administrator) UpdateRevisionArchiving(reqCtx *fasthttp.RequestCtx) e
 		return err
 	}
 
+	previous := ""
+	savedRevision, err := adm.qr.Get(ctx, namespace, queryName, revision)
+	if err == nil {
+		previous = strconv.FormatBool(savedRevision.Archived)
+	}
+
 	err = adm.queryWriter.UpdateRevisionArchiving(ctx, namespace, queryName, revision, body.Archived)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	adm.record(ctx, reqCtx, restql.AuditEvent{
+		Action:    restql.AuditRevisionArchiving,
+		Namespace: namespace,
+		Name:      queryName,
+		Revision:  revision,
+		Before:    previous,
+		After:     strconv.FormatBool(body.Archived),
+	})
+
 	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
 }
 
+func (adm *administrator) AuditEvents(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	filter, err := parseAuditFilter(reqCtx.QueryArgs())
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	events, err := adm.audit.Find(ctx, filter)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	data := map[string]interface{}{"events": events}
+	return Respond(reqCtx, data, fasthttp.StatusOK, nil)
+}
+
+// record stores the audit event of a mutation already applied,
+// identifying the admin key and request that made it. Failures
+// are only logged, since the change cannot be undone.
+func (adm *administrator) record(ctx context.Context, reqCtx *fasthttp.RequestCtx, event restql.AuditEvent) {
+	event.Timestamp = time.Now().UTC()
+	event.Actor = requestAdminKey(reqCtx).name
+	if adm.requestIDHeader != "" {
+		event.RequestID = string(reqCtx.Request.Header.Peek(adm.requestIDHeader))
+	}
+
+	err := adm.audit.Record(ctx, event)
+	if err != nil {
+		adm.log.Error("failed to record admin audit event", err, "action", event.Action, "actor", event.Actor)
+	}
+}
+
+// readableTenants checks that the request key can read every tenant,
+// since validating and running queries expose the tenant mappings
+// and the responses of its resources.
+func (adm *administrator) readableTenants(reqCtx *fasthttp.RequestCtx, tenants []string) bool {
+	for _, t := range tenants {
+		if !adm.auth.allowed(reqCtx, tenantKind, t, readAction) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mappingURL returns the current URL of the resource mapping,
+// or empty when it is not found.
+func (adm *administrator) mappingURL(ctx context.Context, tenantName string, resourceName string) string {
+	mappings, err := adm.mr.FromTenant(ctx, tenantName)
+	if err != nil {
+		return ""
+	}
+
+	m, found := mappings[resourceName]
+	if !found {
+		return ""
+	}
+
+	return m.URL()
+}
+
+// currentQuery returns the query with its revisions
+// whether it is archived or not.
+func (adm *administrator) currentQuery(ctx context.Context, namespace string, queryName string) (restql.SavedQuery, error) {
+	savedQuery, err := adm.qr.ListQueryRevisions(ctx, namespace, queryName, false)
+	if err != nil {
+		return adm.qr.ListQueryRevisions(ctx, namespace, queryName, true)
+	}
+
+	return savedQuery, nil
+}
+
+// lastRevision returns the number of the newest query revision,
+// or zero when it is not found.
+func (adm *administrator) lastRevision(ctx context.Context, namespace string, queryName string) int {
+	savedQuery, err := adm.currentQuery(ctx, namespace, queryName)
+	if err != nil {
+		return 0
+	}
+
+	last := 0
+	for _, r := range savedQuery.Revisions {
+		if r.Revision > last {
+			last = r.Revision
+		}
+	}
+
+	return last
+}
+
+func parseAuditFilter(args *fasthttp.Args) (restql.AuditFilter, error) {
+	filter := restql.AuditFilter{
+		Actor:     string(args.Peek("actor")),
+		Action:    string(args.Peek("action")),
+		Tenant:    string(args.Peek("tenant")),
+		Namespace: string(args.Peek("namespace")),
+	}
+
+	var err error
+	if since := args.Peek("since"); len(since) > 0 {
+		filter.Since, err = time.Parse(time.RFC3339, string(since))
+		if err != nil {
+			return restql.AuditFilter{}, fmt.Errorf("%w: since must be a RFC 3339 timestamp", errInvalidAuditFilter)
+		}
+	}
+
+	if until := args.Peek("until"); len(until) > 0 {
+		filter.Until, err = time.Parse(time.RFC3339, string(until))
+		if err != nil {
+			return restql.AuditFilter{}, fmt.Errorf("%w: until must be a RFC 3339 timestamp", errInvalidAuditFilter)
+		}
+	}
+
+	if limit := args.Peek("limit"); len(limit) > 0 {
+		filter.Limit, err = strconv.Atoi(string(limit))
+		if err != nil || filter.Limit <= 0 {
+			return restql.AuditFilter{}, fmt.Errorf("%w: limit must be a positive number", errInvalidAuditFilter)
+		}
+	}
+
+	return filter, nil
+}
+
 func filterRevisionsBySource(query restql.SavedQuery, source restql.Source) []restql.SavedQueryRevision {
 	if source == "" {
 		return query.Revisions
@@ -0,0 +1,821 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/encoder"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence/sqlite"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+const testAuthorizationCode = "secret"
+
+func TestAdmin_DeleteAndRename(t *testing.T) {
+	ctx := context.Background()
+	cfg := conf.Defaults()
+	cfg.TenantMappings = map[string]map[string]string{"local": {"hero": "http://hero.api"}}
+	cfg.Queries = map[string]map[string][]string{"local": {"hero": {"from hero"}}}
+
+	db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	test.VerifyError(t, db.CreateMapping(ctx, "dc", "hero", "http://hero.api"))
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "dc", "hero", "from hero"))
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "dc", "sidekick", "from sidekick"))
+
+	h := newTestAdminHandler(t, cfg, db)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		authorization  string
+		expectedStatus int
+	}{
+		{"should require authorization", http.MethodDelete, "/admin/tenant/dc", "", "", 401},
+		{"should create tenant", http.MethodPost, "/admin/tenant/marvel", "", "Bearer secret", 201},
+		{"should not create existing tenant", http.MethodPost, "/admin/tenant/marvel", "", "Bearer secret", 400},
+		{"should delete database mapping", http.MethodDelete, "/admin/tenant/dc/mapping/hero", "", "Bearer secret", 204},
+		{"should not delete missing mapping", http.MethodDelete, "/admin/tenant/dc/mapping/hero", "", "Bearer secret", 404},
+		{"should not delete local mapping", http.MethodDelete, "/admin/tenant/local/mapping/hero", "", "Bearer secret", 401},
+		{"should delete tenant", http.MethodDelete, "/admin/tenant/dc", "", "Bearer secret", 204},
+		{"should not delete tenant with local mappings", http.MethodDelete, "/admin/tenant/local", "", "Bearer secret", 401},
+		{"should move query", http.MethodPost, "/admin/namespace/dc/query/sidekick/move", `{"namespace":"heroes","name":"robin"}`, "Bearer secret", 204},
+		{"should not move query without target", http.MethodPost, "/admin/namespace/dc/query/hero/move", `{}`, "Bearer secret", 400},
+		{"should not move local query", http.MethodPost, "/admin/namespace/local/query/hero/move", `{"namespace":"dc"}`, "Bearer secret", 401},
+		{"should rename namespace", http.MethodPost, "/admin/namespace/dc/rename", `{"name":"justice-league"}`, "Bearer secret", 204},
+		{"should not rename over existing namespace", http.MethodPost, "/admin/namespace/justice-league/rename", `{"name":"heroes"}`, "Bearer secret", 400},
+		{"should delete namespace", http.MethodDelete, "/admin/namespace/heroes", "", "Bearer secret", 204},
+		{"should not delete missing namespace", http.MethodDelete, "/admin/namespace/heroes", "", "Bearer secret", 404},
+		{"should not delete local namespace", http.MethodDelete, "/admin/namespace/local", "", "Bearer secret", 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reqCtx fasthttp.RequestCtx
+			reqCtx.Request.Header.SetMethod(tt.method)
+			reqCtx.Request.SetRequestURI(tt.path)
+			reqCtx.Request.SetBodyString(tt.body)
+			if tt.authorization != "" {
+				reqCtx.Request.Header.Set("Authorization", tt.authorization)
+			}
+
+			h(&reqCtx)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), tt.expectedStatus)
+		})
+	}
+}
+
+func TestAdmin_DatabaseOperationNotSupported(t *testing.T) {
+	db, err := persistence.NewDatabase(test.NoOpLogger, persistence.DatabaseOptions{Disabled: true})
+	test.VerifyError(t, err)
+
+	h := newTestAdminHandler(t, conf.Defaults(), db)
+
+	var reqCtx fasthttp.RequestCtx
+	reqCtx.Request.Header.SetMethod(http.MethodPost)
+	reqCtx.Request.SetRequestURI("/admin/tenant/dc")
+	reqCtx.Request.Header.Set("Authorization", "Bearer "+testAuthorizationCode)
+
+	h(&reqCtx)
+
+	test.Equal(t, reqCtx.Response.StatusCode(), 501)
+}
+
+func TestAdmin_AuditEvents(t *testing.T) {
+	ctx := context.Background()
+	cfg := conf.Defaults()
+	cfg.HTTP.Server.Middlewares.RequestID.Enable = true
+	cfg.HTTP.Server.Middlewares.RequestID.Header = "X-TID"
+	cfg.HTTP.Server.Middlewares.RequestID.Strategy = "uuid"
+	cfg.HTTP.Server.Admin.Keys = []conf.AdminKey{
+		{Name: "ops", Key: "ops-key", Scopes: []string{"tenant:*:write", "namespace:*:write"}},
+		{Name: "auditor", Key: "auditor-key", Scopes: []string{"audit:*:read"}},
+	}
+
+	db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	test.VerifyError(t, db.CreateMapping(ctx, "dc", "hero", "http://hero.api"))
+
+	h := newTestAdminHandler(t, cfg, db)
+
+	mutations := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPut, "/admin/tenant/dc/mapping/hero", `{"url":"http://heroes.api"}`},
+		{http.MethodPost, "/admin/namespace/dc/query/hero", `{"text":"from hero"}`},
+		{http.MethodPatch, "/admin/namespace/dc/query/hero/revision/1", `{"archived":true}`},
+		{http.MethodPost, "/admin/tenant/dc/mapping/hero", `{"url":"http://hero.api"}`},
+	}
+	for i, m := range mutations {
+		reqCtx := newAdminRequest(m.method, m.path, "ops-key")
+		reqCtx.Request.Header.Set("X-TID", strconv.Itoa(i))
+		reqCtx.Request.SetBodyString(m.body)
+
+		h(reqCtx)
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		key            string
+		expectedStatus int
+		expected       []restql.AuditEvent
+	}{
+		{"should require audit scope", "", "ops-key", 403, nil},
+		{"should reject invalid filter", "?since=yesterday", "auditor-key", 400, nil},
+		{
+			"should return successful mutations",
+			"",
+			"auditor-key",
+			200,
+			[]restql.AuditEvent{
+				{Actor: "ops", RequestID: "2", Action: restql.AuditRevisionArchiving, Namespace: "dc", Name: "hero", Revision: 1, Before: "false", After: "true"},
+				{Actor: "ops", RequestID: "1", Action: restql.AuditRevisionCreate, Namespace: "dc", Name: "hero", Revision: 1, After: "from hero"},
+				{Actor: "ops", RequestID: "0", Action: restql.AuditMappingUpdate, Tenant: "dc", Name: "hero", Before: "http://hero.api", After: "http://heroes.api"},
+			},
+		},
+		{
+			"should filter events",
+			"?tenant=dc&limit=5",
+			"auditor-key",
+			200,
+			[]restql.AuditEvent{
+				{Actor: "ops", RequestID: "0", Action: restql.AuditMappingUpdate, Tenant: "dc", Name: "hero", Before: "http://hero.api", After: "http://heroes.api"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := newAdminRequest(http.MethodGet, "/admin/audit"+tt.query, tt.key)
+
+			h(reqCtx)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), tt.expectedStatus)
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var body struct{ Events []restql.AuditEvent }
+			test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+			for i := range body.Events {
+				body.Events[i].Timestamp = time.Time{}
+			}
+			test.Equal(t, body.Events, tt.expected)
+		})
+	}
+}
+
+func TestAdmin_QueryDiff(t *testing.T) {
+	ctx := context.Background()
+	cfg := conf.Defaults()
+
+	db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "dc", "hero", "from hero with id = $id"))
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "dc", "hero", "from hero\n  with id = $id"))
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "dc", "hero", "from hero\n  with id = $heroId"))
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "dc", "hero", "from hero with"))
+
+	h := newTestAdminHandler(t, cfg, db)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedText   string
+		expected       domain.QueryDiff
+	}{
+		{
+			"should tell formatting changes apart",
+			"?from=1&to=2",
+			200,
+			"--- dc/hero/1\n+++ dc/hero/2\n@@ -1 +1,2 @@\n-from hero with id = $id\n+from hero\n+  with id = $id\n",
+			domain.QueryDiff{},
+		},
+		{
+			"should show behavioural changes",
+			"?from=2&to=3",
+			200,
+			"--- dc/hero/2\n+++ dc/hero/3\n@@ -1,2 +1,2 @@\n from hero\n-  with id = $id\n+  with id = $heroId\n",
+			domain.QueryDiff{Changed: []domain.StatementDiff{{
+				Resource: "hero",
+				Changes:  []domain.Change{{Field: "with.id", From: "$id", To: "$heroId"}},
+			}}},
+		},
+		{"should require revisions", "?from=1", 400, "", domain.QueryDiff{}},
+		{"should not find missing revision", "?from=1&to=9", 404, "", domain.QueryDiff{}},
+		{"should reject invalid revision", "?from=3&to=4", 422, "", domain.QueryDiff{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := newAdminRequest(http.MethodGet, "/admin/namespace/dc/query/hero/diff"+tt.query, testAuthorizationCode)
+
+			h(reqCtx)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), tt.expectedStatus)
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var body queryDiff
+			test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+			test.Equal(t, body.Text, tt.expectedText)
+			test.Equal(t, body.Semantic, tt.expected)
+			test.Equal(t, body.Equivalent, tt.expected.Empty())
+		})
+	}
+}
+
+func TestAdmin_CreateQueryRevision(t *testing.T) {
+	ctx := context.Background()
+	cfg := conf.Defaults()
+
+	db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	test.VerifyError(t, db.CreateMapping(ctx, "dc", "hero", "http://hero.api/heroes"))
+	test.VerifyError(t, db.CreateMapping(ctx, "marvel", "villain", "http://villain.api/villains"))
+
+	h := newTestAdminHandler(t, cfg, db)
+
+	tests := []struct {
+		name             string
+		query            string
+		body             string
+		expectedStatus   int
+		expectedProblems []revisionProblem
+	}{
+		{
+			"should reject invalid syntax",
+			"",
+			`{"text":"from hero with"}`,
+			422,
+			[]revisionProblem{{Type: syntaxProblem, Line: 1, Column: 15}},
+		},
+		{
+			"should reject resource without mapping",
+			"?tenant=dc&tenant=marvel",
+			`{"text":"from hero\nfrom villain"}`,
+			422,
+			[]revisionProblem{
+				{Type: unknownResourceProblem, Resource: "villain", Tenant: "dc"},
+				{Type: unknownResourceProblem, Resource: "hero", Tenant: "marvel"},
+			},
+		},
+		{
+			"should reject unknown tenant",
+			"?tenant=image",
+			`{"text":"from hero"}`,
+			422,
+			[]revisionProblem{{Type: unknownTenantProblem, Tenant: "image"}},
+		},
+		{"should execute dry run without storing", "?tenant=dc&dryRun=true", `{"text":"from hero with id = $id","params":{"id":"1"}}`, 200, nil},
+		{"should create valid revision", "?tenant=dc", `{"text":"from hero"}`, 201, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := newAdminRequest(http.MethodPost, "/admin/namespace/dc/query/hero"+tt.query, testAuthorizationCode)
+			reqCtx.Request.SetBodyString(tt.body)
+
+			h(reqCtx)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), tt.expectedStatus)
+			if tt.expectedStatus != 422 {
+				return
+			}
+
+			var body invalidRevisionResponse
+			test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+			for i := range body.Problems {
+				body.Problems[i].Message = ""
+			}
+			test.Equal(t, body.Problems, tt.expectedProblems)
+		})
+	}
+
+	t.Run("should return dry run results", func(t *testing.T) {
+		reqCtx := newAdminRequest(http.MethodPost, "/admin/namespace/dc/query/hero?tenant=dc&dryRun=true", testAuthorizationCode)
+		reqCtx.Request.SetBodyString(`{"text":"from hero with id = $id","params":{"id":"1"}}`)
+
+		h(reqCtx)
+
+		var body struct{ Results []dryRunResult }
+		test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+		test.Equal(t, len(body.Results), 1)
+		test.Equal(t, body.Results[0].Tenant, "dc")
+		test.Equal(t, body.Results[0].StatusCode, 200)
+		test.Equal(t, body.Results[0].Error, "")
+
+		saved, err := db.FindQueryWithAllRevisions(ctx, "dc", "hero", false)
+		test.VerifyError(t, err)
+		test.Equal(t, len(saved.Revisions), 1)
+	})
+
+	t.Run("should include debugging on dry run with debug param", func(t *testing.T) {
+		reqCtx := newAdminRequest(http.MethodPost, "/admin/namespace/dc/query/hero?tenant=dc&dryRun=true", testAuthorizationCode)
+		reqCtx.Request.SetBodyString(`{"text":"from hero","params":{"_debug":"true"}}`)
+
+		h(reqCtx)
+
+		var body struct {
+			Results []struct {
+				Body map[string]struct{ Details StatementDetails }
+			}
+		}
+		test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+		test.Equal(t, len(body.Results), 1)
+
+		debug := body.Results[0].Body["hero"].Details.Debug
+		if debug == nil {
+			t.Fatalf("expected statement debugging, got none")
+		}
+		test.Equal(t, debug.URL, "http://hero.api/heroes")
+	})
+}
+
+func TestAdmin_MappingChangesReachQueries(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	test.VerifyError(t, db.CreateMapping(ctx, "dc", "hero", "http://hero.api/heroes"))
+
+	h := newTestAdminHandler(t, conf.Defaults(), db)
+
+	run := func(text string) dryRunResult {
+		reqCtx := newAdminRequest(http.MethodPost, "/admin/validate?tenant=dc&dryRun=true", testAuthorizationCode)
+		reqCtx.Request.SetBodyString(`{"text":"` + text + `","params":{"_debug":"true"}}`)
+
+		h(reqCtx)
+
+		var body struct{ Results []dryRunResult }
+		test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+		test.Equal(t, len(body.Results), 1)
+		return body.Results[0]
+	}
+
+	debugURL := func(result dryRunResult, resource string) interface{} {
+		statement := result.Body.(map[string]interface{})[resource].(map[string]interface{})
+		return statement["details"].(map[string]interface{})["debug"].(map[string]interface{})["url"]
+	}
+
+	test.Equal(t, debugURL(run("from hero"), "hero"), "http://hero.api/heroes")
+
+	t.Run("should use updated mapping", func(t *testing.T) {
+		reqCtx := newAdminRequest(http.MethodPut, "/admin/tenant/dc/mapping/hero", testAuthorizationCode)
+		reqCtx.Request.SetBodyString(`{"url":"http://hero.api/v2/heroes"}`)
+
+		h(reqCtx)
+
+		test.Equal(t, reqCtx.Response.StatusCode(), 204)
+		test.Equal(t, debugURL(run("from hero"), "hero"), "http://hero.api/v2/heroes")
+	})
+
+	t.Run("should use created mapping", func(t *testing.T) {
+		reqCtx := newAdminRequest(http.MethodPost, "/admin/tenant/dc/mapping/villain", testAuthorizationCode)
+		reqCtx.Request.SetBodyString(`{"url":"http://villain.api/villains"}`)
+
+		h(reqCtx)
+
+		test.Equal(t, reqCtx.Response.StatusCode(), 201)
+
+		result := run("from villain")
+		test.Equal(t, result.Error, "")
+		test.Equal(t, debugURL(result, "villain"), "http://villain.api/villains")
+	})
+}
+
+func TestAdmin_ValidateRevision(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	test.VerifyError(t, db.CreateMapping(ctx, "dc", "hero", "http://hero.api/heroes"))
+
+	h := newTestAdminHandler(t, conf.Defaults(), db)
+
+	tests := []struct {
+		name             string
+		query            string
+		body             string
+		expectedStatus   int
+		expectedProblems []revisionProblem
+	}{
+		{"should accept valid text", "?tenant=dc", `{"text":"from hero"}`, 200, []revisionProblem{}},
+		{
+			"should report syntax problem",
+			"",
+			`{"text":"from hero\nfrom villain with"}`,
+			200,
+			[]revisionProblem{{Type: syntaxProblem, Line: 2, Column: 18}},
+		},
+		{
+			"should report unmapped resource",
+			"?tenant=dc",
+			`{"text":"from villain"}`,
+			200,
+			[]revisionProblem{{Type: unknownResourceProblem, Resource: "villain", Tenant: "dc"}},
+		},
+		{"should reject invalid body", "", `{"text":`, 400, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := newAdminRequest(http.MethodPost, "/admin/validate"+tt.query, testAuthorizationCode)
+			reqCtx.Request.SetBodyString(tt.body)
+
+			h(reqCtx)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), tt.expectedStatus)
+			if tt.expectedStatus != 200 {
+				return
+			}
+
+			var body struct {
+				Valid    bool
+				Problems []revisionProblem
+			}
+			test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+			for i := range body.Problems {
+				body.Problems[i].Message = ""
+			}
+			test.Equal(t, body.Problems, tt.expectedProblems)
+			test.Equal(t, body.Valid, len(tt.expectedProblems) == 0)
+		})
+	}
+
+	t.Run("should execute valid text on dry run", func(t *testing.T) {
+		reqCtx := newAdminRequest(http.MethodPost, "/admin/validate?tenant=dc&dryRun=true", testAuthorizationCode)
+		reqCtx.Request.SetBodyString(`{"text":"from hero","params":{"_debug":"true"}}`)
+
+		h(reqCtx)
+
+		test.Equal(t, reqCtx.Response.StatusCode(), 200)
+
+		var body struct {
+			Valid   bool
+			Results []dryRunResult
+		}
+		test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+		test.Equal(t, body.Valid, true)
+		test.Equal(t, len(body.Results), 1)
+		test.Equal(t, body.Results[0].Tenant, "dc")
+		test.Equal(t, body.Results[0].StatusCode, 200)
+	})
+}
+
+func TestAdmin_Console(t *testing.T) {
+	db, err := persistence.NewDatabase(test.NoOpLogger, persistence.DatabaseOptions{Disabled: true})
+	test.VerifyError(t, err)
+
+	h := newTestAdminHandler(t, conf.Defaults(), db)
+
+	tests := []struct {
+		name                string
+		path                string
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{"should redirect to console index", "/admin/console", 301, ""},
+		{"should serve console index", "/admin/console/index.html", 200, "text/html; charset=utf-8"},
+		{"should serve console script", "/admin/console/console.js", 200, "text/javascript; charset=utf-8"},
+		{"should not serve unknown file", "/admin/console/unknown.js", 404, ""},
+		{"should not serve files outside console", "/admin/console/../console.go", 404, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := newAdminRequest(http.MethodGet, tt.path, "")
+
+			h(reqCtx)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), tt.expectedStatus)
+			if tt.expectedContentType != "" {
+				test.Equal(t, string(reqCtx.Response.Header.ContentType()), tt.expectedContentType)
+				test.Equal(t, string(reqCtx.Response.Header.Peek("Content-Security-Policy")), consoleSecurityPolicy)
+			}
+		})
+	}
+}
+
+func TestAdmin_ExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	source, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { source.Close() })
+
+	test.VerifyError(t, source.CreateMapping(ctx, "dc", "hero", "http://hero.api/heroes"))
+	test.VerifyError(t, source.CreateMapping(ctx, "dc", "villain", "http://villain.api/villains"))
+	test.VerifyError(t, source.CreateQueryRevision(ctx, "dc", "hero", "from hero"))
+	test.VerifyError(t, source.CreateQueryRevision(ctx, "dc", "hero", "from hero with id = 1"))
+	test.VerifyError(t, source.UpdateRevisionArchiving(ctx, "dc", "hero", 1, true))
+	test.VerifyError(t, source.CreateQueryRevision(ctx, "dc", "villain", "from villain"))
+
+	exportCtx := newAdminRequest(http.MethodGet, "/admin/export", testAuthorizationCode)
+	exportCtx.Request.Header.Set("Accept", "application/yaml")
+
+	newTestAdminHandler(t, conf.Defaults(), source)(exportCtx)
+
+	test.Equal(t, exportCtx.Response.StatusCode(), 200)
+	test.Equal(t, string(exportCtx.Response.Header.ContentType()), "application/yaml")
+	exported := exportCtx.Response.Body()
+
+	tests := []struct {
+		name                     string
+		query                    string
+		expectedActions          []importAction
+		expectedHeroRevisions    int
+		expectedFirstArchived    bool
+		expectedVillainRevisions int
+	}{
+		{
+			"should skip existing entities",
+			"?strategy=skip",
+			[]importAction{
+				{Kind: "mapping", Action: importSkip, Tenant: "dc", Name: "hero"},
+				{Kind: "mapping", Action: importCreate, Tenant: "dc", Name: "villain"},
+				{Kind: "query", Action: importSkip, Namespace: "dc", Name: "hero"},
+				{Kind: "query", Action: importCreate, Namespace: "dc", Name: "villain", Revisions: []int{1}},
+			},
+			1, false, 1,
+		},
+		{
+			"should overwrite existing entities",
+			"?strategy=overwrite",
+			[]importAction{
+				{Kind: "mapping", Action: importUpdate, Tenant: "dc", Name: "hero"},
+				{Kind: "mapping", Action: importCreate, Tenant: "dc", Name: "villain"},
+				{Kind: "query", Action: importUpdate, Namespace: "dc", Name: "hero", Revisions: []int{2}},
+				{Kind: "query", Action: importCreate, Namespace: "dc", Name: "villain", Revisions: []int{1}},
+			},
+			2, true, 1,
+		},
+		{
+			"should append last revision",
+			"?strategy=new-revision",
+			[]importAction{
+				{Kind: "mapping", Action: importUpdate, Tenant: "dc", Name: "hero"},
+				{Kind: "mapping", Action: importCreate, Tenant: "dc", Name: "villain"},
+				{Kind: "query", Action: importUpdate, Namespace: "dc", Name: "hero", Revisions: []int{2}},
+				{Kind: "query", Action: importCreate, Namespace: "dc", Name: "villain", Revisions: []int{1}},
+			},
+			2, false, 1,
+		},
+		{
+			"should only report on dry run",
+			"?strategy=overwrite&dryRun=true",
+			[]importAction{
+				{Kind: "mapping", Action: importUpdate, Tenant: "dc", Name: "hero"},
+				{Kind: "mapping", Action: importCreate, Tenant: "dc", Name: "villain"},
+				{Kind: "query", Action: importUpdate, Namespace: "dc", Name: "hero", Revisions: []int{2}},
+				{Kind: "query", Action: importCreate, Namespace: "dc", Name: "villain", Revisions: []int{1}},
+			},
+			1, false, 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+			if err != nil {
+				t.Fatalf("failed to open database: %s", err)
+			}
+			t.Cleanup(func() { target.Close() })
+
+			test.VerifyError(t, target.CreateMapping(ctx, "dc", "hero", "http://hero.staging/heroes"))
+			test.VerifyError(t, target.CreateQueryRevision(ctx, "dc", "hero", "from hero"))
+
+			reqCtx := newAdminRequest(http.MethodPost, "/admin/import"+tt.query, testAuthorizationCode)
+			reqCtx.Request.Header.SetContentType("application/yaml")
+			reqCtx.Request.SetBody(exported)
+
+			newTestAdminHandler(t, conf.Defaults(), target)(reqCtx)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), 200)
+
+			var report importReport
+			test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &report))
+			test.Equal(t, report.Actions, tt.expectedActions)
+
+			hero, err := target.FindQueryWithAllRevisions(ctx, "dc", "hero", false)
+			test.VerifyError(t, err)
+			test.Equal(t, len(hero.Revisions), tt.expectedHeroRevisions)
+			test.Equal(t, hero.Revisions[0].Archived, tt.expectedFirstArchived)
+
+			villain, err := target.FindQueryWithAllRevisions(ctx, "dc", "villain", false)
+			if tt.expectedVillainRevisions == 0 {
+				if !errors.Is(err, restql.ErrQueryNotFoundInDatabase) {
+					t.Errorf("expected villain to not be imported, got %v", err)
+				}
+				return
+			}
+			test.VerifyError(t, err)
+			test.Equal(t, len(villain.Revisions), tt.expectedVillainRevisions)
+		})
+	}
+
+	t.Run("should report conflicting revisions on overwrite", func(t *testing.T) {
+		target, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+		if err != nil {
+			t.Fatalf("failed to open database: %s", err)
+		}
+		t.Cleanup(func() { target.Close() })
+
+		test.VerifyError(t, target.CreateQueryRevision(ctx, "dc", "hero", "from hero with id = 2"))
+
+		reqCtx := newAdminRequest(http.MethodPost, "/admin/import?strategy=overwrite", testAuthorizationCode)
+		reqCtx.Request.Header.SetContentType("application/yaml")
+		reqCtx.Request.SetBody(exported)
+
+		newTestAdminHandler(t, conf.Defaults(), target)(reqCtx)
+
+		test.Equal(t, reqCtx.Response.StatusCode(), 200)
+
+		var report importReport
+		test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &report))
+		test.Equal(t, report.Actions[2], importAction{Kind: "query", Action: importConflict, Namespace: "dc", Name: "hero", Revisions: []int{1}})
+
+		hero, err := target.FindQueryWithAllRevisions(ctx, "dc", "hero", false)
+		test.VerifyError(t, err)
+		test.Equal(t, len(hero.Revisions), 1)
+	})
+
+	t.Run("should export every revision of the queries on the source", func(t *testing.T) {
+		cfg := conf.Defaults()
+		cfg.Queries = map[string]map[string][]string{"dc": {"hero": {"from hero", "from hero with id = 1"}}}
+
+		db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+		if err != nil {
+			t.Fatalf("failed to open database: %s", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		test.VerifyError(t, db.CreateQueryRevision(ctx, "dc", "hero", "from hero"))
+
+		exportCtx := newAdminRequest(http.MethodGet, "/admin/export?namespace=dc&source=config", testAuthorizationCode)
+		newTestAdminHandler(t, cfg, db)(exportCtx)
+
+		test.Equal(t, exportCtx.Response.StatusCode(), 200)
+
+		target, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+		if err != nil {
+			t.Fatalf("failed to open database: %s", err)
+		}
+		t.Cleanup(func() { target.Close() })
+
+		importCtx := newAdminRequest(http.MethodPost, "/admin/import", testAuthorizationCode)
+		importCtx.Request.SetBody(exportCtx.Response.Body())
+
+		newTestAdminHandler(t, conf.Defaults(), target)(importCtx)
+
+		test.Equal(t, importCtx.Response.StatusCode(), 200)
+
+		hero, err := target.FindQueryWithAllRevisions(ctx, "dc", "hero", false)
+		test.VerifyError(t, err)
+		test.Equal(t, len(hero.Revisions), 2)
+	})
+
+	t.Run("should reject unsupported archive version", func(t *testing.T) {
+		reqCtx := newAdminRequest(http.MethodPost, "/admin/import", testAuthorizationCode)
+		reqCtx.Request.SetBodyString(`{"version":2}`)
+
+		newTestAdminHandler(t, conf.Defaults(), source)(reqCtx)
+
+		test.Equal(t, reqCtx.Response.StatusCode(), 400)
+	})
+
+	t.Run("should reject unknown strategy", func(t *testing.T) {
+		reqCtx := newAdminRequest(http.MethodPost, "/admin/import?strategy=merge", testAuthorizationCode)
+		reqCtx.Request.SetBodyString(`{"version":1}`)
+
+		newTestAdminHandler(t, conf.Defaults(), source)(reqCtx)
+
+		test.Equal(t, reqCtx.Response.StatusCode(), 400)
+	})
+}
+
+func TestAdmin_AuditNotEnabled(t *testing.T) {
+	db, err := persistence.NewDatabase(test.NoOpLogger, persistence.DatabaseOptions{Disabled: true})
+	test.VerifyError(t, err)
+
+	h := newTestAdminHandler(t, conf.Defaults(), db)
+
+	reqCtx := newAdminRequest(http.MethodGet, "/admin/audit", testAuthorizationCode)
+
+	h(reqCtx)
+
+	test.Equal(t, reqCtx.Response.StatusCode(), 501)
+}
+
+func newTestAdminHandler(t *testing.T, cfg *conf.Config, db persistence.Database) fasthttp.RequestHandler {
+	mr := persistence.NewMappingReader(test.NoOpLogger, cfg.Env, cfg.TenantMappings, db)
+	mw := persistence.NewMappingWriter(test.NoOpLogger, cfg.Env, cfg.TenantMappings, db)
+	qr := persistence.NewQueryReader(test.NoOpLogger, cfg.Queries, db)
+	qw := persistence.NewQueryWriter(test.NoOpLogger, cfg.Queries, db)
+	s := newStores(test.NoOpLogger, cfg, mr, qr, nil)
+
+	cfg.HTTP.Server.Admin.AuthorizationCode = testAuthorizationCode
+	auth, err := newAdminAuthorizer(test.NoOpLogger, cfg)
+	if err != nil {
+		t.Fatalf("failed to create admin authorizer: %s", err)
+	}
+
+	auditLog, err := persistence.NewAuditLog(test.NoOpLogger, db, cfg.HTTP.Server.Admin.Audit.File)
+	if err != nil {
+		t.Fatalf("failed to create audit log: %s", err)
+	}
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("failed to create parser: %s", err)
+	}
+
+	e, err := engine.New(engine.Options{
+		Logger:         test.NoOpLogger,
+		HTTPClient:     echoClient{},
+		Tenant:         cfg.Tenant,
+		MappingsReader: s.engineMappingsReader(),
+		QueryReader:    s.engineQueryReader(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create engine: %s", err)
+	}
+
+	encoders := encoder.New(test.NoOpLogger, nil)
+	a := newApp(test.NoOpLogger, appOptions{Encoders: &encoders})
+	adm := newAdmin(test.NoOpLogger, adminOptions{
+		MappingsReader:  mr,
+		MappingsWriter:  mw,
+		QueryReader:     qr,
+		QueryWriter:     qw,
+		Stores:          s,
+		Authorizer:      auth,
+		AuditLog:        auditLog,
+		Parser:          p,
+		Engine:          e,
+		Tenant:          cfg.Tenant,
+		RequestIDHeader: requestIDHeader(cfg),
+	})
+
+	h := registerAdminEndpoints(adm, a).RequestHandler()
+	return middleware.NewDecorator(test.NoOpLogger, cfg, plugins.NoOpLifecycle).Apply(h)
+}
+
+// echoClient responds to upstream requests with their URL.
+type echoClient struct{}
+
+func (echoClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	url := request.Schema + "://" + request.Host + request.Path
+	return restql.HTTPResponse{
+		URL:        url,
+		StatusCode: 200,
+		Body:       restql.NewResponseBodyFromValue(test.NoOpLogger, map[string]interface{}{"url": url}),
+	}, nil
+}
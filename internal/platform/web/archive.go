@@ -0,0 +1,564 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/valyala/fasthttp"
+	"gopkg.in/yaml.v2"
+)
+
+// archiveVersion is the version of the archive format produced by
+// the export, which must be increased on incompatible changes.
+const archiveVersion = 1
+
+// Strategies to apply an archive entity that already exists.
+const (
+	skipStrategy        = "skip"
+	overwriteStrategy   = "overwrite"
+	newRevisionStrategy = "new-revision"
+)
+
+// Actions reported for each archive entity on import.
+const (
+	importCreate    = "create"
+	importUpdate    = "update"
+	importSkip      = "skip"
+	importUnchanged = "unchanged"
+	importFailed    = "failed"
+	importConflict  = "conflict"
+)
+
+var (
+	errInvalidArchive = errors.New("invalid archive")
+	errInvalidImport  = errors.New("invalid import")
+)
+
+// archive holds namespaces and tenants exported from
+// an environment, to be imported into another one.
+type archive struct {
+	Version    int                `json:"version" yaml:"version"`
+	ExportedAt time.Time          `json:"exportedAt" yaml:"exportedAt"`
+	Namespaces []archiveNamespace `json:"namespaces" yaml:"namespaces"`
+	Tenants    []archiveTenant    `json:"tenants" yaml:"tenants"`
+}
+
+type archiveNamespace struct {
+	Name    string         `json:"name" yaml:"name"`
+	Queries []archiveQuery `json:"queries" yaml:"queries"`
+}
+
+type archiveQuery struct {
+	Name      string            `json:"name" yaml:"name"`
+	Archived  bool              `json:"archived" yaml:"archived"`
+	Revisions []archiveRevision `json:"revisions" yaml:"revisions"`
+}
+
+type archiveRevision struct {
+	Revision int    `json:"revision" yaml:"revision"`
+	Text     string `json:"text" yaml:"text"`
+	Archived bool   `json:"archived" yaml:"archived"`
+}
+
+// archiveTenant holds the tenant mappings, from resource name to URL.
+type archiveTenant struct {
+	Name     string            `json:"name" yaml:"name"`
+	Mappings map[string]string `json:"mappings" yaml:"mappings"`
+}
+
+// importAction is what the import did, or would do on a dry run,
+// with an archive entity. Revisions are the numbers written
+// on the target query.
+type importAction struct {
+	Kind      string `json:"kind"`
+	Action    string `json:"action"`
+	Tenant    string `json:"tenant,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Revisions []int  `json:"revisions,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type importReport struct {
+	Strategy string         `json:"strategy"`
+	DryRun   bool           `json:"dryRun"`
+	Actions  []importAction `json:"actions"`
+}
+
+// ExportArchive writes the namespaces and tenants given on the query
+// arguments, or all the ones readable by the key when none is given,
+// in the encoding negotiated for the request.
+func (adm *administrator) ExportArchive(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	source := restql.Source(reqCtx.QueryArgs().Peek("source"))
+	namespaces := queryArgStrings(reqCtx, "namespace")
+	tenants := queryArgStrings(reqCtx, "tenant")
+	explicit := len(namespaces) > 0 || len(tenants) > 0
+
+	if explicit {
+		for _, n := range namespaces {
+			if !adm.auth.allowed(reqCtx, namespaceKind, n, readAction) {
+				return RespondError(reqCtx, errAdminForbidden, errToStatusCode)
+			}
+		}
+		for _, t := range tenants {
+			if !adm.auth.allowed(reqCtx, tenantKind, t, readAction) {
+				return RespondError(reqCtx, errAdminForbidden, errToStatusCode)
+			}
+		}
+	} else {
+		allNamespaces, err := adm.qr.ListNamespaces(ctx)
+		if err != nil {
+			return RespondError(reqCtx, err, errToStatusCode)
+		}
+
+		allTenants, err := adm.mr.ListTenants(ctx)
+		if err != nil {
+			return RespondError(reqCtx, err, errToStatusCode)
+		}
+
+		namespaces = filterReadable(reqCtx, namespaceKind, allNamespaces)
+		tenants = filterReadable(reqCtx, tenantKind, allTenants)
+	}
+
+	sort.Strings(namespaces)
+	sort.Strings(tenants)
+
+	a := archive{
+		Version:    archiveVersion,
+		ExportedAt: time.Now().UTC(),
+		Namespaces: []archiveNamespace{},
+		Tenants:    []archiveTenant{},
+	}
+
+	for _, n := range namespaces {
+		ns, err := adm.exportNamespace(ctx, n, source, explicit)
+		if err != nil {
+			return RespondError(reqCtx, err, errToStatusCode)
+		}
+
+		a.Namespaces = append(a.Namespaces, ns)
+	}
+
+	for _, t := range tenants {
+		tenant, err := adm.exportTenant(ctx, t, source, explicit)
+		if err != nil {
+			return RespondError(reqCtx, err, errToStatusCode)
+		}
+
+		a.Tenants = append(a.Tenants, tenant)
+	}
+
+	return Respond(reqCtx, a, fasthttp.StatusOK, nil)
+}
+
+// exportNamespace reads the active and archived queries of the namespace.
+// With a source, only the queries with a revision on it are exported,
+// but with all their revisions, since an archive cannot have gaps.
+// A namespace that is not found is exported empty, unless it was
+// explicitly requested.
+func (adm *administrator) exportNamespace(ctx context.Context, namespace string, source restql.Source, explicit bool) (archiveNamespace, error) {
+	ns := archiveNamespace{Name: namespace, Queries: []archiveQuery{}}
+
+	found := false
+	for _, archived := range []bool{false, true} {
+		savedQueries, err := adm.qr.ListQueriesForNamespace(ctx, namespace, archived)
+		switch {
+		case errors.Is(err, restql.ErrNamespaceNotFound):
+			continue
+		case err != nil:
+			return archiveNamespace{}, err
+		}
+
+		found = true
+		for _, savedQuery := range savedQueries {
+			if len(filterRevisionsBySource(savedQuery, source)) == 0 {
+				continue
+			}
+
+			q := archiveQuery{Name: savedQuery.Name, Archived: savedQuery.Archived}
+			for _, r := range savedQuery.Revisions {
+				q.Revisions = append(q.Revisions, archiveRevision{Revision: r.Revision, Text: r.Text, Archived: r.Archived})
+			}
+			ns.Queries = append(ns.Queries, q)
+		}
+	}
+
+	if !found && explicit {
+		return archiveNamespace{}, fmt.Errorf("%w: %s", restql.ErrNamespaceNotFound, namespace)
+	}
+
+	sort.Slice(ns.Queries, func(i, j int) bool { return ns.Queries[i].Name < ns.Queries[j].Name })
+
+	return ns, nil
+}
+
+// exportTenant reads the tenant mappings. A tenant without
+// mappings is exported empty, unless it was explicitly requested.
+func (adm *administrator) exportTenant(ctx context.Context, tenant string, source restql.Source, explicit bool) (archiveTenant, error) {
+	t := archiveTenant{Name: tenant, Mappings: map[string]string{}}
+
+	mappings, err := adm.mr.FromTenant(ctx, tenant)
+	switch {
+	case errors.Is(err, restql.ErrMappingsNotFound) && !explicit:
+		return t, nil
+	case err != nil:
+		return archiveTenant{}, err
+	}
+
+	for resource, m := range filterMappingsBySource(mappings, source) {
+		t.Mappings[resource] = m.URL()
+	}
+
+	return t, nil
+}
+
+// ImportArchive applies the archive on the request body, encoded as
+// JSON or YAML, with the strategy given for entities that already exist.
+// The key must be able to write every namespace and tenant on the archive.
+// Failures on an entity are reported and do not stop the import.
+func (adm *administrator) ImportArchive(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	strategy := string(reqCtx.QueryArgs().Peek("strategy"))
+	switch strategy {
+	case "":
+		strategy = skipStrategy
+	case skipStrategy, overwriteStrategy, newRevisionStrategy:
+	default:
+		return RespondError(reqCtx, fmt.Errorf("%w: unknown strategy %s", errInvalidImport, strategy), errToStatusCode)
+	}
+
+	dryRun, _ := strconv.ParseBool(string(reqCtx.QueryArgs().Peek("dryRun")))
+
+	a, err := decodeArchive(string(reqCtx.Request.Header.ContentType()), reqCtx.PostBody())
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	for _, ns := range a.Namespaces {
+		if !adm.auth.allowed(reqCtx, namespaceKind, ns.Name, writeAction) {
+			return RespondError(reqCtx, errAdminForbidden, errToStatusCode)
+		}
+	}
+	for _, t := range a.Tenants {
+		if !adm.auth.allowed(reqCtx, tenantKind, t.Name, writeAction) {
+			return RespondError(reqCtx, errAdminForbidden, errToStatusCode)
+		}
+	}
+
+	report := importReport{Strategy: strategy, DryRun: dryRun, Actions: []importAction{}}
+
+	for _, t := range a.Tenants {
+		report.Actions = append(report.Actions, adm.importTenant(ctx, reqCtx, t, strategy, dryRun)...)
+	}
+
+	for _, ns := range a.Namespaces {
+		for _, q := range ns.Queries {
+			report.Actions = append(report.Actions, adm.importQuery(ctx, reqCtx, ns.Name, q, strategy, dryRun))
+		}
+	}
+
+	return Respond(reqCtx, report, fasthttp.StatusOK, nil)
+}
+
+// decodeArchive reads the archive as YAML when the content type says
+// so and as JSON otherwise, checking its version and that the query
+// revisions are numbered from one without gaps.
+func decodeArchive(contentType string, body []byte) (archive, error) {
+	var a archive
+	var err error
+	if strings.Contains(contentType, "yaml") {
+		err = yaml.Unmarshal(body, &a)
+	} else {
+		err = json.Unmarshal(body, &a)
+	}
+	if err != nil {
+		return archive{}, fmt.Errorf("%w: %s", errInvalidArchive, err)
+	}
+
+	if a.Version != archiveVersion {
+		return archive{}, fmt.Errorf("%w: unsupported version %d", errInvalidArchive, a.Version)
+	}
+
+	for _, ns := range a.Namespaces {
+		if ns.Name == "" {
+			return archive{}, fmt.Errorf("%w: namespace without name", errInvalidArchive)
+		}
+
+		for _, q := range ns.Queries {
+			if q.Name == "" || len(q.Revisions) == 0 {
+				return archive{}, fmt.Errorf("%w: query without name or revisions on namespace %s", errInvalidArchive, ns.Name)
+			}
+
+			sort.Slice(q.Revisions, func(i, j int) bool { return q.Revisions[i].Revision < q.Revisions[j].Revision })
+			for i, r := range q.Revisions {
+				if r.Revision != i+1 {
+					return archive{}, fmt.Errorf("%w: query %s/%s is missing revision %d", errInvalidArchive, ns.Name, q.Name, i+1)
+				}
+			}
+		}
+	}
+
+	for _, t := range a.Tenants {
+		if t.Name == "" {
+			return archive{}, fmt.Errorf("%w: tenant without name", errInvalidArchive)
+		}
+	}
+
+	return a, nil
+}
+
+// importTenant creates the tenant mappings that do not exist and
+// updates the ones with a different URL, unless skipping them.
+// Since mappings have no revisions, they are overwritten on the
+// new-revision strategy.
+func (adm *administrator) importTenant(ctx context.Context, reqCtx *fasthttp.RequestCtx, t archiveTenant, strategy string, dryRun bool) []importAction {
+	current, err := adm.mr.FromTenant(ctx, t.Name)
+	notFound := errors.Is(err, restql.ErrMappingsNotFound)
+	if err != nil && !notFound {
+		return []importAction{{Kind: tenantKind, Action: importFailed, Tenant: t.Name, Error: err.Error()}}
+	}
+
+	if len(t.Mappings) == 0 {
+		action := importAction{Kind: tenantKind, Action: importUnchanged, Tenant: t.Name}
+		if !notFound {
+			return []importAction{action}
+		}
+
+		action.Action = importCreate
+		if !dryRun {
+			err = adm.mw.CreateTenant(ctx, t.Name)
+			switch {
+			case errors.Is(err, restql.ErrTenantAlreadyExistsInDatabase):
+				action.Action = importUnchanged
+			case err != nil:
+				action.Action, action.Error = importFailed, err.Error()
+			default:
+				adm.record(ctx, reqCtx, restql.AuditEvent{Action: restql.AuditTenantCreate, Tenant: t.Name})
+			}
+		}
+
+		return []importAction{action}
+	}
+
+	resources := make([]string, 0, len(t.Mappings))
+	for r := range t.Mappings {
+		resources = append(resources, r)
+	}
+	sort.Strings(resources)
+
+	actions := make([]importAction, len(resources))
+	changed := false
+	for i, resource := range resources {
+		url := t.Mappings[resource]
+		action := importAction{Kind: "mapping", Tenant: t.Name, Name: resource}
+
+		m, found := current[resource]
+		switch {
+		case !found:
+			action.Action = importCreate
+		case m.URL() == url:
+			action.Action = importUnchanged
+		case strategy == skipStrategy:
+			action.Action = importSkip
+		default:
+			action.Action = importUpdate
+		}
+
+		if !dryRun && (action.Action == importCreate || action.Action == importUpdate) {
+			event := restql.AuditEvent{Action: restql.AuditMappingCreate, Tenant: t.Name, Name: resource, After: url}
+			if action.Action == importCreate {
+				err = adm.mw.Create(ctx, t.Name, resource, url)
+			} else {
+				event.Action, event.Before = restql.AuditMappingUpdate, m.URL()
+				err = adm.mw.Update(ctx, t.Name, resource, url)
+			}
+
+			if err != nil {
+				action.Action, action.Error = importFailed, err.Error()
+			} else {
+				changed = true
+				adm.record(ctx, reqCtx, event)
+			}
+		}
+
+		actions[i] = action
+	}
+
+	if changed {
+		adm.stores.invalidateTenant(t.Name)
+	}
+
+	return actions
+}
+
+// importQuery writes the revisions of a query that does not exist and
+// applies the archive flags. For an existing query, the overwrite
+// strategy appends the revisions it does not have yet and applies the
+// archive flags, while the new-revision strategy appends the archive
+// last revision when it differs from the current one. Revisions are
+// immutable, hence existing ones are never rewritten.
+func (adm *administrator) importQuery(ctx context.Context, reqCtx *fasthttp.RequestCtx, namespace string, q archiveQuery, strategy string, dryRun bool) importAction {
+	action := importAction{Kind: "query", Namespace: namespace, Name: q.Name}
+	failed := func(err error) importAction {
+		action.Action, action.Error = importFailed, err.Error()
+		return action
+	}
+
+	target, err := adm.currentQuery(ctx, namespace, q.Name)
+	notFound := errors.Is(err, restql.ErrQueryNotFound)
+	if err != nil && !notFound {
+		return failed(err)
+	}
+
+	last := len(target.Revisions)
+	pending := q.Revisions
+	applyFlags := true
+
+	switch {
+	case notFound:
+		action.Action = importCreate
+	case strategy == skipStrategy:
+		action.Action = importSkip
+		return action
+	case strategy == newRevisionStrategy:
+		pending = nil
+		applyFlags = false
+
+		latest := q.Revisions[len(q.Revisions)-1]
+		if last == 0 || target.Revisions[last-1].Text != latest.Text {
+			pending = []archiveRevision{{Revision: last + 1, Text: latest.Text}}
+		}
+	default:
+		if conflicts := conflictingRevisions(target.Revisions, q.Revisions); len(conflicts) > 0 {
+			action.Action, action.Revisions = importConflict, conflicts
+			return action
+		}
+
+		pending = nil
+		if len(q.Revisions) > last {
+			pending = q.Revisions[last:]
+		}
+	}
+
+	for _, r := range pending {
+		if _, err := adm.parser.Parse(r.Text); err != nil {
+			return failed(fmt.Errorf("revision %d: %w", r.Revision, err))
+		}
+		action.Revisions = append(action.Revisions, r.Revision)
+	}
+
+	targetArchived := make(map[int]bool, last)
+	for _, r := range target.Revisions {
+		targetArchived[r.Revision] = r.Archived
+	}
+
+	var archiving []archiveRevision
+	if applyFlags {
+		for _, r := range q.Revisions {
+			if r.Archived != targetArchived[r.Revision] {
+				archiving = append(archiving, r)
+			}
+		}
+	}
+
+	// Writing a revision unarchives the query, hence the query
+	// is only left archived when there is no pending revision.
+	archivedAfterWrite := target.Archived && len(pending) == 0
+
+	updateQueryFlag := false
+	if applyFlags {
+		switch {
+		case q.Archived && !archivedAfterWrite:
+			updateQueryFlag = true
+		case !q.Archived && archivedAfterWrite:
+			updateQueryFlag = true
+		}
+	}
+
+	if action.Action == "" {
+		action.Action = importUnchanged
+		if len(pending) > 0 || len(archiving) > 0 || updateQueryFlag {
+			action.Action = importUpdate
+		}
+	}
+
+	if dryRun || action.Action == importUnchanged {
+		return action
+	}
+
+	defer adm.stores.invalidateQuery(namespace, q.Name)
+
+	for _, r := range pending {
+		err = adm.queryWriter.Write(ctx, namespace, q.Name, r.Text)
+		if err != nil {
+			return failed(err)
+		}
+
+		adm.record(ctx, reqCtx, restql.AuditEvent{
+			Action:    restql.AuditRevisionCreate,
+			Namespace: namespace,
+			Name:      q.Name,
+			Revision:  r.Revision,
+			After:     r.Text,
+		})
+	}
+
+	for _, r := range archiving {
+		err = adm.queryWriter.UpdateRevisionArchiving(ctx, namespace, q.Name, r.Revision, r.Archived)
+		if err != nil {
+			return failed(err)
+		}
+
+		adm.record(ctx, reqCtx, restql.AuditEvent{
+			Action:    restql.AuditRevisionArchiving,
+			Namespace: namespace,
+			Name:      q.Name,
+			Revision:  r.Revision,
+			Before:    strconv.FormatBool(!r.Archived),
+			After:     strconv.FormatBool(r.Archived),
+		})
+	}
+
+	if updateQueryFlag {
+		err = adm.queryWriter.UpdateQueryArchiving(ctx, namespace, q.Name, q.Archived)
+		if err != nil {
+			return failed(err)
+		}
+
+		adm.record(ctx, reqCtx, restql.AuditEvent{
+			Action:    restql.AuditQueryArchiving,
+			Namespace: namespace,
+			Name:      q.Name,
+			Before:    strconv.FormatBool(!q.Archived),
+			After:     strconv.FormatBool(q.Archived),
+		})
+	}
+
+	return action
+}
+
+// conflictingRevisions returns the numbers of the existing revisions
+// whose text differs from the archive one, which cannot be rewritten.
+func conflictingRevisions(existing []restql.SavedQueryRevision, archived []archiveRevision) []int {
+	var conflicts []int
+	for i, r := range existing {
+		if i < len(archived) && archived[i].Text != r.Text {
+			conflicts = append(conflicts, r.Revision)
+		}
+	}
+
+	return conflicts
+}
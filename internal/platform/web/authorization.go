@@ -0,0 +1,291 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+// Kinds of entity managed through the administrative API,
+// where the audit log has no named entities.
+const (
+	tenantKind    = "tenant"
+	namespaceKind = "namespace"
+	auditKind     = "audit"
+)
+
+// Actions allowed by a scope, where write also allows read.
+const (
+	readAction  = "read"
+	writeAction = "write"
+)
+
+const (
+	anyScopeValue     = "*"
+	legacyAdminKey    = "authorizationCode"
+	adminKeyUserValue = "restql-admin-key"
+)
+
+var (
+	errAdminUnauthorized = errors.New("unauthorized : missing or unknown admin key")
+	errAdminForbidden    = errors.New("forbidden : admin key has no scope for this operation")
+	errInvalidAdminScope = errors.New("invalid admin key scope")
+)
+
+// adminScope grants an action over the tenants or namespaces
+// with the given name, in the form `<kind>:<name>:<action>`,
+// like `namespace:checkout:write` or `tenant:*:read`.
+type adminScope struct {
+	kind   string
+	name   string
+	action string
+}
+
+func parseAdminScope(s string) (adminScope, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 || parts[1] == "" {
+		return adminScope{}, fmt.Errorf("%w: %s", errInvalidAdminScope, s)
+	}
+
+	scope := adminScope{kind: parts[0], name: parts[1], action: parts[2]}
+
+	switch scope.kind {
+	case tenantKind, namespaceKind, auditKind, anyScopeValue:
+	default:
+		return adminScope{}, fmt.Errorf("%w: unknown kind %s", errInvalidAdminScope, s)
+	}
+
+	switch scope.action {
+	case readAction, writeAction:
+	default:
+		return adminScope{}, fmt.Errorf("%w: unknown action %s", errInvalidAdminScope, s)
+	}
+
+	return scope, nil
+}
+
+func (s adminScope) allows(kind, name, action string) bool {
+	return (s.kind == anyScopeValue || s.kind == kind) &&
+		(s.name == anyScopeValue || s.name == name) &&
+		(s.action == writeAction || s.action == action)
+}
+
+func (s adminScope) String() string {
+	return s.kind + ":" + s.name + ":" + s.action
+}
+
+// adminKey is a named credential of the administrative API,
+// identified by the digest of its secret.
+type adminKey struct {
+	name   string
+	digest [sha256.Size]byte
+	scopes []adminScope
+}
+
+// allows tells if the key can execute the action over the named entity.
+func (k adminKey) allows(kind, name, action string) bool {
+	for _, s := range k.scopes {
+		if s.allows(kind, name, action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowsAny tells if the key can execute the action
+// over at least one entity of the kind.
+func (k adminKey) allowsAny(kind, action string) bool {
+	for _, s := range k.scopes {
+		if (s.kind == anyScopeValue || s.kind == kind) && (s.action == writeAction || s.action == action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// adminAuthorizer authenticates the administrative API requests
+// by their bearer token and checks the key scopes.
+type adminAuthorizer struct {
+	log  restql.Logger
+	keys []adminKey
+}
+
+// newAdminAuthorizer builds the keys from the configuration, where
+// the legacy authorization code is a key with access to everything.
+func newAdminAuthorizer(log restql.Logger, cfg *conf.Config) (*adminAuthorizer, error) {
+	adminCfg := cfg.HTTP.Server.Admin
+	a := &adminAuthorizer{log: log}
+
+	if adminCfg.AuthorizationCode != "" {
+		a.keys = append(a.keys, adminKey{
+			name:   legacyAdminKey,
+			digest: sha256.Sum256([]byte(adminCfg.AuthorizationCode)),
+			scopes: []adminScope{{kind: anyScopeValue, name: anyScopeValue, action: writeAction}},
+		})
+	}
+
+	for _, k := range adminCfg.Keys {
+		if k.Name == "" || k.Key == "" {
+			return nil, errors.New("admin key must have a name and a key")
+		}
+
+		key := adminKey{name: k.Name, digest: sha256.Sum256([]byte(k.Key))}
+		for _, s := range k.Scopes {
+			scope, err := parseAdminScope(s)
+			if err != nil {
+				return nil, errors.Wrapf(err, "admin key %s", k.Name)
+			}
+
+			key.scopes = append(key.scopes, scope)
+		}
+
+		a.keys = append(a.keys, key)
+	}
+
+	if len(a.keys) == 0 {
+		log.Warn("administration api has no keys configured, all requests will be rejected")
+	}
+
+	return a, nil
+}
+
+// authenticate finds the key matching the request bearer token.
+// The digests are compared in constant time and against all keys,
+// so that the response time does not reveal the keys.
+func (a *adminAuthorizer) authenticate(ctx *fasthttp.RequestCtx) (adminKey, bool) {
+	token := bearerToken(ctx)
+	if len(token) == 0 {
+		return adminKey{}, false
+	}
+
+	digest := sha256.Sum256(token)
+
+	var found adminKey
+	matched := 0
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare(digest[:], k.digest[:]) == 1 {
+			found = k
+			matched = 1
+		}
+	}
+
+	return found, matched == 1
+}
+
+// authorize wraps an administrative handler, allowing only requests
+// with a key that can execute the action over the entity named by
+// the path parameter. Without a parameter, the key must be able to
+// execute the action over at least one entity of the kind.
+func (a *adminAuthorizer) authorize(kind, param, action string, h handler) handler {
+	return func(ctx *fasthttp.RequestCtx) error {
+		key, ok := a.authenticate(ctx)
+		if !ok {
+			return a.rejectUnknownKey(ctx)
+		}
+
+		allowed := key.allowsAny(kind, action)
+		name := ""
+		if param != "" {
+			var err error
+			name, err = pathParamString(ctx, param)
+			if err != nil {
+				return err
+			}
+
+			allowed = key.allows(kind, name, action)
+		}
+
+		if !allowed {
+			a.logForbidden(ctx, key, kind, name, action)
+			return RespondError(ctx, errAdminForbidden, errToStatusCode)
+		}
+
+		ctx.SetUserValue(adminKeyUserValue, key)
+		return h(ctx)
+	}
+}
+
+// authorizeAny wraps an administrative handler over tenants and
+// namespaces named on the request, like the bulk import, allowing
+// only requests with a key that can execute the action over at least
+// one of them. The handler must check each entity it touches.
+func (a *adminAuthorizer) authorizeAny(action string, h handler) handler {
+	return func(ctx *fasthttp.RequestCtx) error {
+		key, ok := a.authenticate(ctx)
+		if !ok {
+			return a.rejectUnknownKey(ctx)
+		}
+
+		if !key.allowsAny(tenantKind, action) && !key.allowsAny(namespaceKind, action) {
+			a.logForbidden(ctx, key, anyScopeValue, anyScopeValue, action)
+			return RespondError(ctx, errAdminForbidden, errToStatusCode)
+		}
+
+		ctx.SetUserValue(adminKeyUserValue, key)
+		return h(ctx)
+	}
+}
+
+func (a *adminAuthorizer) rejectUnknownKey(ctx *fasthttp.RequestCtx) error {
+	a.log.Warn("admin request with unknown key rejected",
+		"method", string(ctx.Method()), "path", string(ctx.Path()), "remote-ip", ctx.RemoteIP().String())
+	return RespondError(ctx, errAdminUnauthorized, errToStatusCode)
+}
+
+// allowed checks an additional entity in a handler already authorized,
+// like the target namespace when moving a query, logging when denied.
+func (a *adminAuthorizer) allowed(ctx *fasthttp.RequestCtx, kind, name, action string) bool {
+	key := requestAdminKey(ctx)
+	if key.allows(kind, name, action) {
+		return true
+	}
+
+	a.logForbidden(ctx, key, kind, name, action)
+	return false
+}
+
+func (a *adminAuthorizer) logForbidden(ctx *fasthttp.RequestCtx, key adminKey, kind, name, action string) {
+	a.log.Warn("admin request without scope rejected",
+		"key", key.name, "scope", adminScope{kind: kind, name: name, action: action}.String(),
+		"method", string(ctx.Method()), "path", string(ctx.Path()), "remote-ip", ctx.RemoteIP().String())
+}
+
+func requestAdminKey(ctx *fasthttp.RequestCtx) adminKey {
+	key, _ := ctx.UserValue(adminKeyUserValue).(adminKey)
+	return key
+}
+
+// filterReadable returns the entities of the kind
+// the request key is allowed to read.
+func filterReadable(ctx *fasthttp.RequestCtx, kind string, names []string) []string {
+	key := requestAdminKey(ctx)
+
+	result := []string{}
+	for _, n := range names {
+		if key.allows(kind, n, readAction) {
+			result = append(result, n)
+		}
+	}
+
+	return result
+}
+
+func bearerToken(ctx *fasthttp.RequestCtx) []byte {
+	token := ctx.Request.Header.Peek("Authorization")
+	if len(token) == 0 {
+		token = ctx.Request.Header.Peek("authorization")
+	}
+
+	token = bytes.TrimPrefix(token, []byte("Bearer"))
+	token = bytes.TrimPrefix(token, []byte("bearer"))
+	return bytes.TrimSpace(token)
+}
@@ -0,0 +1,192 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence/sqlite"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestParseAdminScope(t *testing.T) {
+	tests := []struct {
+		scope       string
+		expected    adminScope
+		expectedErr bool
+	}{
+		{"namespace:checkout:write", adminScope{kind: "namespace", name: "checkout", action: "write"}, false},
+		{"tenant:*:read", adminScope{kind: "tenant", name: "*", action: "read"}, false},
+		{"*:*:write", adminScope{kind: "*", name: "*", action: "write"}, false},
+		{"audit:*:read", adminScope{kind: "audit", name: "*", action: "read"}, false},
+		{"namespace:checkout", adminScope{}, true},
+		{"namespace::read", adminScope{}, true},
+		{"query:checkout:read", adminScope{}, true},
+		{"namespace:checkout:delete", adminScope{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			got, err := parseAdminScope(tt.scope)
+			if tt.expectedErr {
+				if !errors.Is(err, errInvalidAdminScope) {
+					t.Errorf("got error = %v, want = %v", err, errInvalidAdminScope)
+				}
+				return
+			}
+
+			test.VerifyError(t, err)
+			test.Equal(t, got.String(), tt.expected.String())
+		})
+	}
+}
+
+func TestAdminAuthorizer(t *testing.T) {
+	ctx := context.Background()
+	cfg := conf.Defaults()
+	cfg.HTTP.Server.Admin.Keys = []conf.AdminKey{
+		{Name: "checkout-team", Key: "checkout-key", Scopes: []string{"namespace:checkout:write", "tenant:prod:read"}},
+		{Name: "auditor", Key: "auditor-key", Scopes: []string{"*:*:read"}},
+	}
+
+	db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	test.VerifyError(t, db.CreateMapping(ctx, "prod", "hero", "http://hero.api"))
+	test.VerifyError(t, db.CreateMapping(ctx, "staging", "hero", "http://hero.api"))
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "checkout", "cart", "from cart"))
+	test.VerifyError(t, db.CreateQueryRevision(ctx, "search", "products", "from products"))
+
+	h := newTestAdminHandler(t, cfg, db)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		key            string
+		expectedStatus int
+	}{
+		{"should reject missing key", http.MethodGet, "/admin/namespace/checkout/query", "", "", 401},
+		{"should reject unknown key", http.MethodGet, "/admin/namespace/checkout/query", "", "unknown-key", 401},
+		{"should allow read with write scope", http.MethodGet, "/admin/namespace/checkout/query", "", "checkout-key", 200},
+		{"should reject read of other namespace", http.MethodGet, "/admin/namespace/search/query", "", "checkout-key", 403},
+		{"should allow write on scoped namespace", http.MethodPost, "/admin/namespace/checkout/query/cart", `{"text":"from cart with id = 1"}`, "checkout-key", 201},
+		{"should reject dry run on tenant without read scope", http.MethodPost, "/admin/namespace/checkout/query/cart?tenant=staging&dryRun=true", `{"text":"from hero"}`, "checkout-key", 403},
+		{"should allow dry run on readable tenant", http.MethodPost, "/admin/namespace/checkout/query/cart?tenant=prod&dryRun=true", `{"text":"from hero"}`, "checkout-key", 200},
+		{"should reject validation on tenant without read scope", http.MethodPost, "/admin/validate?tenant=staging", `{"text":"from hero"}`, "checkout-key", 403},
+		{"should allow validation dry run with read scope", http.MethodPost, "/admin/validate?tenant=staging&dryRun=true", `{"text":"from hero"}`, "auditor-key", 200},
+		{"should reject validation dry run on tenant without read scope", http.MethodPost, "/admin/validate?tenant=staging&dryRun=true", `{"text":"from hero"}`, "checkout-key", 403},
+		{"should reject move to other namespace", http.MethodPost, "/admin/namespace/checkout/query/cart/move", `{"namespace":"search"}`, "checkout-key", 403},
+		{"should allow read of scoped tenant", http.MethodGet, "/admin/tenant/prod/mapping", "", "checkout-key", 200},
+		{"should reject write with read scope", http.MethodPut, "/admin/tenant/prod/mapping/hero", `{"url":"http://heroes.api"}`, "checkout-key", 403},
+		{"should allow read with wildcard scope", http.MethodGet, "/admin/tenant/staging/mapping", "", "auditor-key", 200},
+		{"should reject write with wildcard read scope", http.MethodDelete, "/admin/namespace/search", "", "auditor-key", 403},
+		{"should reject export of other namespace", http.MethodGet, "/admin/export?namespace=search", "", "checkout-key", 403},
+		{"should reject import on tenant without write scope", http.MethodPost, "/admin/import", `{"version":1,"tenants":[{"name":"prod","mappings":{"hero":"http://heroes.api"}}]}`, "checkout-key", 403},
+		{"should reject import with read scope", http.MethodPost, "/admin/import", `{"version":1}`, "auditor-key", 403},
+		{"should allow legacy authorization code", http.MethodDelete, "/admin/namespace/search", "", testAuthorizationCode, 204},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := newAdminRequest(tt.method, tt.path, tt.key)
+			reqCtx.Request.SetBodyString(tt.body)
+
+			h(reqCtx)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), tt.expectedStatus)
+		})
+	}
+
+	t.Run("should list only readable tenants", func(t *testing.T) {
+		reqCtx := newAdminRequest(http.MethodGet, "/admin/tenant", "checkout-key")
+
+		h(reqCtx)
+
+		var body struct{ Tenants []string }
+		test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+		test.Equal(t, body.Tenants, []string{"prod"})
+	})
+
+	t.Run("should export only readable entities", func(t *testing.T) {
+		reqCtx := newAdminRequest(http.MethodGet, "/admin/export", "checkout-key")
+
+		h(reqCtx)
+
+		var body archive
+		test.VerifyError(t, json.Unmarshal(reqCtx.Response.Body(), &body))
+		test.Equal(t, len(body.Namespaces), 1)
+		test.Equal(t, body.Namespaces[0].Name, "checkout")
+		test.Equal(t, len(body.Tenants), 1)
+		test.Equal(t, body.Tenants[0].Name, "prod")
+	})
+}
+
+func TestAdminAuthorizer_DefaultTenant(t *testing.T) {
+	cfg := conf.Defaults()
+	cfg.Tenant = "staging"
+	cfg.HTTP.Server.Admin.Keys = []conf.AdminKey{
+		{Name: "checkout-team", Key: "checkout-key", Scopes: []string{"namespace:checkout:write", "tenant:prod:read"}},
+	}
+
+	db, err := sqlite.New(test.NoOpLogger, sqlite.Options{Path: sqlite.MemoryPath})
+	if err != nil {
+		t.Fatalf("failed to open database: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	h := newTestAdminHandler(t, cfg, db)
+
+	for _, path := range []string{"/admin/namespace/checkout/query/cart?dryRun=true", "/admin/validate?dryRun=true"} {
+		t.Run(path, func(t *testing.T) {
+			reqCtx := newAdminRequest(http.MethodPost, path, "checkout-key")
+			reqCtx.Request.SetBodyString(`{"text":"from hero"}`)
+
+			h(reqCtx)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), 403)
+		})
+	}
+}
+
+func TestNewAdminAuthorizer_InvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		key  conf.AdminKey
+	}{
+		{"should reject key without name", conf.AdminKey{Key: "key", Scopes: []string{"*:*:read"}}},
+		{"should reject key without secret", conf.AdminKey{Name: "name", Scopes: []string{"*:*:read"}}},
+		{"should reject invalid scope", conf.AdminKey{Name: "name", Key: "key", Scopes: []string{"namespace:read"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := conf.Defaults()
+			cfg.HTTP.Server.Admin.Keys = []conf.AdminKey{tt.key}
+
+			_, err := newAdminAuthorizer(test.NoOpLogger, cfg)
+			if err == nil {
+				t.Errorf("newAdminAuthorizer = nil, want error")
+			}
+		})
+	}
+}
+
+func newAdminRequest(method, path, key string) *fasthttp.RequestCtx {
+	reqCtx := &fasthttp.RequestCtx{}
+	reqCtx.Request.Header.SetMethod(method)
+	reqCtx.Request.SetRequestURI(path)
+	if key != "" {
+		reqCtx.Request.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	return reqCtx
+}
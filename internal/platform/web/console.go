@@ -0,0 +1,65 @@
+package web
+
+import (
+	"embed"
+	"errors"
+	"mime"
+	"path"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	consoleDir   = "console"
+	consoleRoot  = "/admin/console/"
+	consoleIndex = "index.html"
+)
+
+// consoleFiles holds the administration console, a single page
+// application that calls the administrative API with the key
+// given by the user on the browser.
+//
+//go:embed console
+var consoleFiles embed.FS
+
+var errConsoleFileNotFound = errors.New("console file not found")
+
+// consoleSecurityPolicy only allows the console to load its own files
+// and call the API it is served from, since it handles admin keys.
+const consoleSecurityPolicy = "default-src 'self'; frame-ancestors 'none'"
+
+// serveConsole serves the embedded administration console files. They
+// hold no data, hence are served without an admin key, while every
+// call the console makes to the administrative API requires one.
+func serveConsole(reqCtx *fasthttp.RequestCtx) error {
+	name, _ := reqCtx.UserValue("filepath").(string)
+	if name == "" {
+		name = consoleIndex
+	}
+
+	content, err := consoleFiles.ReadFile(path.Join(consoleDir, path.Clean("/"+name)))
+	if err != nil {
+		return RespondError(reqCtx, errConsoleFileNotFound, errToStatusCode)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	reqCtx.Response.Header.SetContentType(contentType)
+	reqCtx.Response.Header.Set("Content-Security-Policy", consoleSecurityPolicy)
+	reqCtx.Response.Header.Set("X-Content-Type-Options", "nosniff")
+	reqCtx.Response.Header.Set("Cache-Control", "no-cache")
+	reqCtx.Response.SetStatusCode(fasthttp.StatusOK)
+	reqCtx.Response.SetBody(content)
+
+	return nil
+}
+
+// redirectToConsole sends the console root to its index,
+// so that the console files are resolved relative to it.
+func redirectToConsole(reqCtx *fasthttp.RequestCtx) error {
+	reqCtx.Redirect(consoleRoot+consoleIndex, fasthttp.StatusMovedPermanently)
+	return nil
+}
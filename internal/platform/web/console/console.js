@@ -0,0 +1,516 @@
+// restQL administration console.
+//
+// A single page application backed by the administrative API, which is
+// called with the admin key given by the user. The key is kept only on
+// the browser session storage.
+(function () {
+  "use strict";
+
+  const keyStorage = "restql-admin-key";
+  const validationDelay = 300;
+
+  const keywords = new Set([
+    "from", "to", "into", "update", "delete", "as", "in", "with", "only", "headers",
+    "timeout", "hidden", "ignore-errors", "depends-on", "use", "max-age", "s-max-age",
+    "true", "false", "null",
+  ]);
+  const functions = new Set([
+    "flatten", "no-multiplex", "no-explode", "base64", "json", "as-body", "as-query", "matches",
+  ]);
+  const tokenPattern = /(\/\/.*)|("(?:[^"\\]|\\.)*"?)|(\$[A-Za-z_][\w-]*)|(-?\d+(?:\.\d+)?)|([A-Za-z_][\w-]*)/g;
+
+  const state = {
+    key: sessionStorage.getItem(keyStorage) || "",
+    tenants: [],
+    namespace: "",
+    query: null,
+    revision: 0,
+    validationTimer: 0,
+  };
+
+  const $ = (id) => document.getElementById(id);
+
+  // api calls the administrative API, resolved relative to the
+  // console, returning the decoded body or throwing its error.
+  async function api(method, path, body) {
+    const options = {
+      method: method,
+      headers: { "Accept": "application/json", "Authorization": "Bearer " + state.key },
+    };
+    if (body !== undefined) {
+      options.headers["Content-Type"] = "application/json";
+      options.body = JSON.stringify(body);
+    }
+
+    const response = await fetch(new URL("../" + path, window.location.href), options);
+    const text = await response.text();
+    const data = text ? JSON.parse(text) : null;
+
+    if (!response.ok && response.status !== 422) {
+      const reason = data && (data.error || data.detail || data.title);
+      throw new Error(response.status + " " + (reason || response.statusText));
+    }
+
+    return { status: response.status, data: data };
+  }
+
+  function segment(value) {
+    return encodeURIComponent(value);
+  }
+
+  function tenantQuery() {
+    const tenant = $("tenant").value;
+    return tenant ? "tenant=" + segment(tenant) : "";
+  }
+
+  function showMessage(err) {
+    const message = $("message");
+    message.textContent = err ? err.message : "";
+    message.hidden = !err;
+  }
+
+  function showView(id) {
+    for (const view of ["mappings-view", "queries-view", "query-view"]) {
+      $(view).hidden = view !== id;
+    }
+  }
+
+  function clear(element) {
+    while (element.firstChild) {
+      element.removeChild(element.firstChild);
+    }
+  }
+
+  function element(tag, text, className) {
+    const e = document.createElement(tag);
+    if (text !== undefined) {
+      e.textContent = text;
+    }
+    if (className) {
+      e.className = className;
+    }
+    return e;
+  }
+
+  function renderList(list, items, onSelect) {
+    clear(list);
+    for (const item of items) {
+      const li = element("li", item.label, item.className);
+      li.addEventListener("click", () => {
+        for (const other of list.children) {
+          other.classList.remove("selected");
+        }
+        li.classList.add("selected");
+        onSelect(item.value);
+      });
+      list.appendChild(li);
+    }
+  }
+
+  // Connection
+
+  async function connect() {
+    showMessage(null);
+    $("key").value = "";
+    $("disconnect").hidden = !state.key;
+    if (!state.key) {
+      return;
+    }
+
+    try {
+      await Promise.all([loadTenants(), loadNamespaces()]);
+    } catch (err) {
+      showMessage(err);
+    }
+  }
+
+  function disconnect() {
+    state.key = "";
+    sessionStorage.removeItem(keyStorage);
+    clear($("tenants"));
+    clear($("namespaces"));
+    showView("");
+    connect();
+  }
+
+  // Tenants and mappings
+
+  async function loadTenants() {
+    const { data } = await api("GET", "tenant");
+    state.tenants = data.tenants.sort();
+
+    renderList($("tenants"), state.tenants.map((t) => ({ label: t, value: t })), showTenant);
+
+    const select = $("tenant");
+    clear(select);
+    select.appendChild(element("option", "default tenant"));
+    select.firstChild.value = "";
+    for (const t of state.tenants) {
+      select.appendChild(element("option", t));
+    }
+  }
+
+  async function showTenant(tenant) {
+    showMessage(null);
+    try {
+      const { data } = await api("GET", "tenant/" + segment(tenant) + "/mapping");
+
+      $("mappings-title").textContent = "Mappings of " + tenant;
+      const tbody = $("mappings");
+      clear(tbody);
+      for (const resource of Object.keys(data.mappings).sort()) {
+        const m = data.mappings[resource];
+        const tr = element("tr");
+        tr.appendChild(element("td", resource));
+        tr.appendChild(element("td", m.url));
+        tr.appendChild(element("td", m.source));
+        tbody.appendChild(tr);
+      }
+
+      showView("mappings-view");
+    } catch (err) {
+      showMessage(err);
+    }
+  }
+
+  // Namespaces and queries
+
+  async function loadNamespaces() {
+    const { data } = await api("GET", "namespace");
+    const namespaces = data.namespaces.sort();
+    renderList($("namespaces"), namespaces.map((n) => ({ label: n, value: n })), showNamespace);
+  }
+
+  async function listQueries(namespace, archived) {
+    try {
+      const { data } = await api("GET", "namespace/" + segment(namespace) + "/query?archived=" + archived);
+      return data.queries;
+    } catch (err) {
+      if (err.message.startsWith("404")) {
+        return [];
+      }
+      throw err;
+    }
+  }
+
+  async function showNamespace(namespace) {
+    showMessage(null);
+    state.namespace = namespace;
+    try {
+      const [active, archived] = await Promise.all([listQueries(namespace, false), listQueries(namespace, true)]);
+      const queries = active.concat(archived).sort((a, b) => a.name.localeCompare(b.name));
+
+      $("queries-title").textContent = "Queries of " + namespace;
+      renderList(
+        $("queries"),
+        queries.map((q) => ({ label: q.name, value: q, className: q.archived ? "archived" : "" })),
+        (q) => showQuery(q.name, q.archived),
+      );
+
+      showView("queries-view");
+    } catch (err) {
+      showMessage(err);
+    }
+  }
+
+  function queryPath() {
+    return "namespace/" + segment(state.namespace) + "/query/" + segment(state.query.name);
+  }
+
+  async function showQuery(name, archived) {
+    showMessage(null);
+    try {
+      const { data } = await api("GET", "namespace/" + segment(state.namespace) + "/query/" + segment(name) + "?archived=" + archived);
+      state.query = data;
+      renderQuery(data.revisions.length);
+    } catch (err) {
+      showMessage(err);
+    }
+  }
+
+  function newQuery(name) {
+    state.query = { namespace: state.namespace, name: name, archived: false, revisions: [] };
+    renderQuery(0);
+  }
+
+  function renderQuery(revision) {
+    const q = state.query;
+    $("query-title").textContent = q.namespace + "/" + q.name;
+    $("query-archived").checked = q.archived;
+    $("query-archived").disabled = q.revisions.length === 0;
+    $("run-view").hidden = true;
+
+    const tbody = $("revisions");
+    clear(tbody);
+    for (const r of q.revisions) {
+      const tr = element("tr", undefined, r.revision === revision ? "selected" : "");
+      tr.appendChild(element("td", String(r.revision)));
+      tr.appendChild(element("td", r.source));
+
+      const archived = element("input");
+      archived.type = "checkbox";
+      archived.checked = r.archived;
+      archived.addEventListener("click", (event) => event.stopPropagation());
+      archived.addEventListener("change", () => updateRevisionArchiving(r, archived));
+      const td = element("td");
+      td.appendChild(archived);
+      tr.appendChild(td);
+
+      tr.addEventListener("click", () => renderQuery(r.revision));
+      tbody.appendChild(tr);
+    }
+
+    const selected = q.revisions.find((r) => r.revision === revision);
+    state.revision = revision;
+    setText(selected ? selected.text : "");
+    showView("query-view");
+  }
+
+  async function updateQueryArchiving() {
+    const checkbox = $("query-archived");
+    try {
+      await api("PATCH", queryPath(), { archived: checkbox.checked });
+      state.query.archived = checkbox.checked;
+    } catch (err) {
+      checkbox.checked = !checkbox.checked;
+      showMessage(err);
+    }
+  }
+
+  async function updateRevisionArchiving(revision, checkbox) {
+    try {
+      await api("PATCH", queryPath() + "/revision/" + revision.revision, { archived: checkbox.checked });
+      revision.archived = checkbox.checked;
+    } catch (err) {
+      checkbox.checked = !checkbox.checked;
+      showMessage(err);
+    }
+  }
+
+  // Editor
+
+  function setText(text) {
+    $("text").value = text;
+    renderHighlight([]);
+    renderProblems([]);
+    scheduleValidation();
+  }
+
+  // highlight splits the line in tokens styled by their kind.
+  function highlight(line, target) {
+    let last = 0;
+    let afterArrow = false;
+    for (const match of line.matchAll(tokenPattern)) {
+      const plain = line.slice(last, match.index);
+      if (plain) {
+        target.appendChild(document.createTextNode(plain));
+        afterArrow = plain.trim().endsWith("->") || (afterArrow && plain.trim() === "");
+      }
+
+      let kind = "";
+      if (match[1]) {
+        kind = "comment";
+      } else if (match[2]) {
+        kind = "string";
+      } else if (match[3]) {
+        kind = "variable";
+      } else if (match[4]) {
+        kind = "number";
+      } else if (afterArrow && functions.has(match[5])) {
+        kind = "function";
+      } else if (keywords.has(match[5])) {
+        kind = "keyword";
+      }
+
+      target.appendChild(kind ? element("span", match[0], "tok-" + kind) : document.createTextNode(match[0]));
+      last = match.index + match[0].length;
+      afterArrow = false;
+    }
+    target.appendChild(document.createTextNode(line.slice(last)));
+  }
+
+  function renderHighlight(problems) {
+    const errorLines = new Set(problems.filter((p) => p.line).map((p) => p.line));
+    const pre = $("highlight");
+    clear(pre);
+
+    $("text").value.split("\n").forEach((line, i) => {
+      const span = element("span", undefined, errorLines.has(i + 1) ? "line error" : "line");
+      highlight(line, span);
+      pre.appendChild(span);
+    });
+
+    syncScroll();
+  }
+
+  function syncScroll() {
+    $("highlight").scrollTop = $("text").scrollTop;
+    $("highlight").scrollLeft = $("text").scrollLeft;
+  }
+
+  function renderProblems(problems) {
+    const list = $("problems");
+    clear(list);
+    for (const p of problems) {
+      const position = p.line ? " (line " + p.line + ", column " + p.column + ")" : "";
+      list.appendChild(element("li", p.message + position));
+    }
+  }
+
+  function scheduleValidation() {
+    clearTimeout(state.validationTimer);
+    state.validationTimer = setTimeout(validate, validationDelay);
+  }
+
+  // validate checks the editor text with the restQL parser and
+  // the selected tenant mappings, marking the lines with errors.
+  async function validate() {
+    const text = $("text").value;
+    const status = $("validation");
+    if (!text.trim()) {
+      status.textContent = "";
+      return;
+    }
+
+    try {
+      const { data } = await api("POST", "validate?" + tenantQuery(), { text: text });
+      status.textContent = data.valid ? "valid" : data.problems.length + " problem(s)";
+      status.className = "validation " + (data.valid ? "valid" : "invalid");
+      renderHighlight(data.problems);
+      renderProblems(data.problems);
+    } catch (err) {
+      status.textContent = err.message;
+      status.className = "validation invalid";
+    }
+  }
+
+  async function save() {
+    showMessage(null);
+    try {
+      const { status, data } = await api("POST", queryPath() + "?" + tenantQuery(), { text: $("text").value });
+      if (status === 422) {
+        renderHighlight(data.problems);
+        renderProblems(data.problems);
+        return;
+      }
+
+      await showQuery(state.query.name, false);
+    } catch (err) {
+      showMessage(err);
+    }
+  }
+
+  // Query execution
+
+  function parseInput(id) {
+    const text = $(id).value.trim();
+    return text ? JSON.parse(text) : {};
+  }
+
+  // run executes the editor text as a dry run on the selected
+  // tenant, with debugging, through the validation endpoint,
+  // which is available to keys with read scope.
+  async function run() {
+    showMessage(null);
+    try {
+      const params = Object.assign(parseInput("params"), { _debug: "true" });
+      const body = { text: $("text").value, params: params, headers: parseInput("headers") };
+
+      const { data } = await api("POST", "validate?dryRun=true&" + tenantQuery(), body);
+      renderHighlight(data.problems);
+      renderProblems(data.problems);
+      if (!data.valid) {
+        return;
+      }
+
+      renderRun(data.results[0]);
+    } catch (err) {
+      showMessage(err);
+    }
+  }
+
+  // statementTimings collects the debugging of each statement
+  // execution, where multiplexed statements have one per request.
+  function statementTimings(body) {
+    const timings = [];
+    const collect = (resource, details) => {
+      if (Array.isArray(details)) {
+        details.forEach((d, i) => collect(resource + "[" + i + "]", d));
+        return;
+      }
+      if (!details) {
+        return;
+      }
+
+      const debug = details.debug || {};
+      timings.push({
+        resource: resource,
+        status: details.status,
+        success: details.success,
+        url: debug.url || "",
+        time: debug["response-time"] || 0,
+      });
+    };
+
+    for (const resource of Object.keys(body || {})) {
+      collect(resource, body[resource].details);
+    }
+
+    return timings;
+  }
+
+  function renderRun(result) {
+    $("run-view").hidden = false;
+    $("run-status").textContent = result.error
+      ? "Failed: " + result.error
+      : "Status " + result.statusCode + (result.tenant ? " on tenant " + result.tenant : "");
+    $("run-body").textContent = JSON.stringify(result.body, null, 2);
+
+    const timings = statementTimings(result.body);
+    const slowest = Math.max(1, ...timings.map((t) => t.time));
+
+    const timeline = $("timeline");
+    clear(timeline);
+    for (const t of timings) {
+      const li = element("li");
+      li.title = t.url;
+      li.appendChild(element("span", t.resource + " " + t.status, "label"));
+
+      const track = element("span", undefined, "track");
+      const bar = element("span", undefined, t.success ? "bar" : "bar failed");
+      bar.style.display = "block";
+      bar.style.width = (100 * t.time / slowest) + "%";
+      track.appendChild(bar);
+      li.appendChild(track);
+
+      li.appendChild(element("span", t.time + " ms"));
+      timeline.appendChild(li);
+    }
+  }
+
+  // Wiring
+
+  $("key-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    state.key = $("key").value.trim();
+    sessionStorage.setItem(keyStorage, state.key);
+    connect();
+  });
+  $("disconnect").addEventListener("click", disconnect);
+  $("new-query-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    newQuery($("new-query-name").value.trim());
+    $("new-query-name").value = "";
+  });
+  $("query-archived").addEventListener("change", updateQueryArchiving);
+  $("text").addEventListener("input", () => {
+    renderHighlight([]);
+    scheduleValidation();
+  });
+  $("text").addEventListener("scroll", syncScroll);
+  $("tenant").addEventListener("change", scheduleValidation);
+  $("save").addEventListener("click", save);
+  $("run").addEventListener("click", run);
+
+  connect();
+})();
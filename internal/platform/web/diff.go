@@ -0,0 +1,171 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines
+// shown around each change of a unified diff.
+const diffContextLines = 3
+
+type diffOperation int
+
+const (
+	diffEqual diffOperation = iota
+	diffDelete
+	diffInsert
+)
+
+type diffLine struct {
+	op   diffOperation
+	text string
+}
+
+// unifiedDiff returns the line difference between the texts in the
+// unified format, or an empty string when they are equal. Saved queries
+// are small, so the longest common subsequence is computed directly.
+func unifiedDiff(fromName, toName, from, to string) string {
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	var hunks []string
+	for start := 0; start < len(lines); {
+		if lines[start].op == diffEqual {
+			start++
+			continue
+		}
+
+		hunkStart := max(start-diffContextLines, 0)
+		end := start
+		for end < len(lines) {
+			if lines[end].op != diffEqual {
+				end++
+				continue
+			}
+
+			next := end
+			for next < len(lines) && lines[next].op == diffEqual {
+				next++
+			}
+
+			if next == len(lines) || next-end > 2*diffContextLines {
+				break
+			}
+			end = next
+		}
+		hunkEnd := min(end+diffContextLines, len(lines))
+
+		hunks = append(hunks, formatHunk(lines, hunkStart, hunkEnd))
+		start = hunkEnd
+	}
+
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	return "--- " + fromName + "\n+++ " + toName + "\n" + strings.Join(hunks, "")
+}
+
+func formatHunk(lines []diffLine, start, end int) string {
+	fromLine, toLine := 1, 1
+	for _, l := range lines[:start] {
+		if l.op != diffInsert {
+			fromLine++
+		}
+		if l.op != diffDelete {
+			toLine++
+		}
+	}
+
+	var body strings.Builder
+	fromCount, toCount := 0, 0
+	for _, l := range lines[start:end] {
+		switch l.op {
+		case diffEqual:
+			fromCount++
+			toCount++
+			body.WriteString(" ")
+		case diffDelete:
+			fromCount++
+			body.WriteString("-")
+		case diffInsert:
+			toCount++
+			body.WriteString("+")
+		}
+		body.WriteString(l.text)
+		body.WriteString("\n")
+	}
+
+	return fmt.Sprintf("@@ -%s +%s @@\n%s", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount), body.String())
+}
+
+// hunkRange formats the hunk position, where an empty
+// range refers to the line before it.
+func hunkRange(line, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", line-1)
+	case 1:
+		return fmt.Sprintf("%d", line)
+	default:
+		return fmt.Sprintf("%d,%d", line, count)
+	}
+}
+
+func diffLines(from, to []string) []diffLine {
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			lines = append(lines, diffLine{op: diffEqual, text: from[i]})
+			i++
+			j++
+		case i < len(from) && (j == len(to) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{op: diffDelete, text: from[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{op: diffInsert, text: to[j]})
+			j++
+		}
+	}
+
+	return lines
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
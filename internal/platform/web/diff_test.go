@@ -0,0 +1,48 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{"should be empty for equal texts", "from hero\nfrom villain", "from hero\nfrom villain", ""},
+		{
+			"should show changed line",
+			"from hero\n  with id = 1\nonly name",
+			"from hero\n  with id = 2\nonly name",
+			"--- a\n+++ b\n@@ -1,3 +1,3 @@\n from hero\n-  with id = 1\n+  with id = 2\n only name\n",
+		},
+		{
+			"should show added lines at the end",
+			"from hero",
+			"from hero\nfrom villain",
+			"--- a\n+++ b\n@@ -1 +1,2 @@\n from hero\n+from villain\n",
+		},
+		{
+			"should show text created from empty",
+			"",
+			"from hero",
+			"--- a\n+++ b\n@@ -0,0 +1 @@\n+from hero\n",
+		},
+		{
+			"should split distant changes in hunks",
+			"a\nb\nc\nd\ne\nf\ng\nh\ni\nj",
+			"A\nb\nc\nd\ne\nf\ng\nh\ni\nJ",
+			"--- a\n+++ b\n@@ -1,4 +1,4 @@\n-a\n+A\n b\n c\n d\n@@ -7,4 +7,4 @@\n g\n h\n i\n-j\n+J\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, unifiedDiff("a", "b", tt.from, tt.to), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	connectionContextKey = "connection-context"
+	detachedCancelKey    = "detached-context-cancel"
+)
+
+// DetachedContext returns a context for work that continues after
+// the handler returns, like writing a streamed response body, which
+// the native context does not outlive since middlewares cancel it
+// on return. It keeps the native context values and deadline, and
+// is cancelled when the client connection is closed, or when the
+// handler returns without leaving a body stream to release it.
+func DetachedContext(reqCtx *fasthttp.RequestCtx) (context.Context, context.CancelFunc) {
+	native, ok := reqCtx.UserValue("context").(context.Context)
+	if !ok {
+		return context.WithCancel(context.Background())
+	}
+
+	var ctx context.Context = detachedContext{parent: native}
+	var cancel context.CancelFunc
+	if deadline, ok := native.Deadline(); ok {
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	if conn, ok := reqCtx.UserValue(connectionContextKey).(context.Context); ok && conn.Done() != nil {
+		go func() {
+			select {
+			case <-conn.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	reqCtx.SetUserValue(detachedCancelKey, cancel)
+
+	return ctx, cancel
+}
+
+// releaseDetachedContext cancels the detached context of a request
+// whose response is not a body stream, as the stream writer that
+// would otherwise cancel it is never run.
+func releaseDetachedContext(reqCtx *fasthttp.RequestCtx) {
+	cancel, ok := reqCtx.UserValue(detachedCancelKey).(context.CancelFunc)
+	if !ok || reqCtx.IsBodyStream() {
+		return
+	}
+
+	cancel()
+}
+
+// detachedContext has the values of its parent,
+// but neither its deadline nor its cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (d detachedContext) Deadline() (time.Time, bool)       { return time.Time{}, false }
+func (d detachedContext) Done() <-chan struct{}             { return nil }
+func (d detachedContext) Err() error                        { return nil }
+func (d detachedContext) Value(key interface{}) interface{} { return d.parent.Value(key) }
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestDetachedContext_Release(t *testing.T) {
+	native := newNativeContext(NewConnManager(test.NoOpLogger, false, time.Second))
+
+	t.Run("should cancel when the handler does not leave a body stream", func(t *testing.T) {
+		var detached context.Context
+		handler := native.Apply(func(reqCtx *fasthttp.RequestCtx) {
+			detached, _ = DetachedContext(reqCtx)
+			reqCtx.SetBodyString("error")
+		})
+
+		handler(&fasthttp.RequestCtx{})
+
+		test.Equal(t, detached.Err() == context.Canceled, true)
+	})
+
+	t.Run("should leave the cancellation to the body stream writer", func(t *testing.T) {
+		var detached context.Context
+		release := make(chan struct{})
+		done := make(chan struct{})
+
+		handler := native.Apply(func(reqCtx *fasthttp.RequestCtx) {
+			ctx, cancel := DetachedContext(reqCtx)
+			detached = ctx
+
+			reqCtx.SetBodyStreamWriter(func(w *bufio.Writer) {
+				defer close(done)
+				defer cancel()
+				<-release
+			})
+		})
+
+		handler(&fasthttp.RequestCtx{})
+		test.Equal(t, detached.Err() == nil, true)
+
+		close(release)
+		<-done
+		test.Equal(t, detached.Err() == context.Canceled, true)
+	})
+}
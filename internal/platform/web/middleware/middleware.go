@@ -2,6 +2,9 @@ package middleware
 
 import (
 	"fmt"
+	"sync"
+	"sync/atomic"
+
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
@@ -24,9 +27,23 @@ func (nm noopMiddleware) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandle
 // with default and config enabled middlewares.
 type Decorator struct {
 	log restql.Logger
-	cfg *conf.Config
 	pm  plugins.Lifecycle
 	cm  *ConnManager
+
+	mu        sync.Mutex
+	cfg       *conf.Config
+	decorated []*decoratedHandler
+}
+
+// decoratedHandler is a handler wrapped with the middlewares,
+// which is rebuilt when the configuration is reloaded.
+type decoratedHandler struct {
+	base    fasthttp.RequestHandler
+	current atomic.Value
+}
+
+func (dh *decoratedHandler) handle(ctx *fasthttp.RequestCtx) {
+	dh.current.Load().(fasthttp.RequestHandler)(ctx)
 }
 
 // NewDecorator creates a middleware Decorator
@@ -45,6 +62,34 @@ func NewDecorator(log restql.Logger, cfg *conf.Config, pm plugins.Lifecycle) *De
 // Apply takes a base handler and fetches all middlewares enabled in configuration
 // decorating the argument with each one.
 func (d *Decorator) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dh := &decoratedHandler{base: h}
+	dh.current.Store(d.decorate(h))
+	d.decorated = append(d.decorated, dh)
+
+	return dh.handle
+}
+
+// Reload applies the timeout and CORS middlewares configuration
+// to the handlers already decorated, keeping the other middlewares
+// as they were configured on start.
+func (d *Decorator) Reload(cfg *conf.Config) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	reloaded := *d.cfg
+	reloaded.HTTP.Server.Middlewares.Timeout = cfg.HTTP.Server.Middlewares.Timeout
+	reloaded.HTTP.Server.Middlewares.Cors = cfg.HTTP.Server.Middlewares.Cors
+	d.cfg = &reloaded
+
+	for _, dh := range d.decorated {
+		dh.current.Store(d.decorate(dh.base))
+	}
+}
+
+func (d *Decorator) decorate(h fasthttp.RequestHandler) fasthttp.RequestHandler {
 	mws := d.fetchEnabled()
 	handler := h
 
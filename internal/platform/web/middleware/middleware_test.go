@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestDecorator_Reload(t *testing.T) {
+	cfg := conf.Defaults()
+	cfg.HTTP.Server.Middlewares.RequestID.Enable = true
+	cfg.HTTP.Server.Middlewares.RequestID.Header = "X-Request-Id"
+	cfg.HTTP.Server.Middlewares.RequestID.Strategy = "uuid"
+
+	d := NewDecorator(test.NoOpLogger, cfg, plugins.NoOpLifecycle)
+	handler := d.Apply(testHandler)
+
+	ctx := newOriginRequest()
+	handler(ctx)
+	test.Equal(t, string(ctx.Response.Header.Peek("Access-Control-Allow-Origin")), "")
+	test.Equal(t, len(ctx.Response.Header.Peek("X-Request-Id")) > 0, true)
+
+	reloaded := conf.Defaults()
+	reloaded.HTTP.Server.Middlewares.Cors.Enable = true
+	reloaded.HTTP.Server.Middlewares.Cors.AllowOrigin = "*"
+	d.Reload(reloaded)
+
+	ctx = newOriginRequest()
+	handler(ctx)
+	test.Equal(t, string(ctx.Response.Header.Peek("Access-Control-Allow-Origin")), "*")
+	test.Equal(t, len(ctx.Response.Header.Peek("X-Request-Id")) > 0, true)
+}
+
+func newOriginRequest() *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(fasthttp.MethodGet)
+	ctx.Request.Header.Set("Origin", "http://hero.api")
+	return ctx
+}
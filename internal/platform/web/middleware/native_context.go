@@ -18,8 +18,11 @@ func (n nativeContext) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler
 		ctx := n.cm.ContextForConnection(reqCtx.Conn())
 
 		WithNativeContext(reqCtx, ctx)
+		reqCtx.SetUserValue(connectionContextKey, ctx)
 
 		h(reqCtx)
+
+		releaseDetachedContext(reqCtx)
 	}
 }
 
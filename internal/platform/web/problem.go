@@ -0,0 +1,135 @@
+package web
+
+import (
+	"errors"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser/ast"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/encoder"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:restql:problem:"
+	defaultProblemType = "about:blank"
+)
+
+type problemType struct {
+	name  string
+	title string
+}
+
+// errToProblemType is ordered from the most specific sentinel to
+// the most generic one, so an error wrapping many of them, like a
+// syntax error wrapped as an invalid query, always gets the same type.
+var errToProblemType = []struct {
+	err error
+	problemType
+}{
+	{errPathParamNotFound, problemType{"path-param-not-found", "Path parameter not found"}},
+	{errInvalidTenant, problemType{"invalid-tenant", "Invalid tenant"}},
+	{errInvalidRevisionType, problemType{"invalid-revision", "Invalid revision"}},
+	{errFailedToReadRequestBody, problemType{"invalid-request-body", "Invalid request body"}},
+	{errNotAcceptable, problemType{"not-acceptable", "Not acceptable"}},
+	{persistence.ErrSetResourceMappingNotAllowed, problemType{"mapping-update-not-allowed", "Mapping update not allowed"}},
+	{persistence.ErrUpdateQueryNotAllowed, problemType{"query-update-not-allowed", "Query update not allowed"}},
+	{restql.ErrMappingAlreadyExistsInDatabase, problemType{"mapping-already-exists", "Mapping already exists"}},
+	{restql.ErrQueryNotFoundInDatabase, problemType{"query-not-found", "Query not found"}},
+	{restql.ErrMappingsNotFoundInDatabase, problemType{"mappings-not-found", "Mappings not found"}},
+	{restql.ErrNamespaceNotFound, problemType{"namespace-not-found", "Namespace not found"}},
+	{restql.ErrQueryNotFound, problemType{"query-not-found", "Query not found"}},
+	{restql.ErrMappingsNotFound, problemType{"mappings-not-found", "Mappings not found"}},
+	{restql.ErrDatabaseCommunicationFailed, problemType{"database-communication-failed", "Database communication failed"}},
+	{parser.ErrInvalidQuery, problemType{"invalid-query", "Invalid query syntax"}},
+	{runner.ErrMaxQueryDenied, problemType{"max-query-denied", "Maximum concurrent queries reached"}},
+	{runner.ErrMaxGoroutineDenied, problemType{"max-goroutine-denied", "Maximum concurrent goroutines reached"}},
+	{eval.ErrTimeout, problemType{"timeout", "Query timed out"}},
+	{eval.ErrMapping, problemType{"mapping", "Unknown mapping"}},
+	{eval.ErrValidation, problemType{"validation", "Invalid query request"}},
+	{eval.ErrParser, problemType{"parser", "Invalid query syntax"}},
+}
+
+// Problem is the form used for API responses from failures in the API
+// when problem details are enabled, following the RFC 7807.
+type Problem struct {
+	Type      string           `json:"type"`
+	Title     string           `json:"title"`
+	Status    int              `json:"status"`
+	Detail    string           `json:"detail,omitempty"`
+	Instance  string           `json:"instance,omitempty"`
+	RequestID string           `json:"requestId,omitempty"`
+	Position  *ProblemPosition `json:"position,omitempty"`
+}
+
+// ProblemPosition represents the location in the
+// query text where a syntax error was found.
+type ProblemPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
+}
+
+// MakeProblem translate the error into a problem details document.
+func MakeProblem(err error, status int) Problem {
+	p := Problem{
+		Type:   defaultProblemType,
+		Title:  fasthttp.StatusMessage(status),
+		Status: status,
+		Detail: err.Error(),
+	}
+
+	if pt, found := findProblemType(err); found {
+		p.Type = problemTypePrefix + pt.name
+		p.Title = pt.title
+	}
+
+	var syntaxErr *ast.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		p.Position = &ProblemPosition{Line: syntaxErr.Line, Column: syntaxErr.Column, Offset: syntaxErr.Offset}
+	}
+
+	return p
+}
+
+func findProblemType(err error) (problemType, bool) {
+	for _, e := range errToProblemType {
+		if errors.Is(err, e.err) {
+			return e.problemType, true
+		}
+	}
+
+	return problemType{}, false
+}
+
+func respondProblem(ctx *fasthttp.RequestCtx, err error, status int, requestIDHeader string) error {
+	p := MakeProblem(err, status)
+	p.Instance = string(ctx.Path())
+	if requestIDHeader != "" {
+		p.RequestID = string(ctx.Request.Header.Peek(requestIDHeader))
+	}
+
+	ctx.Response.Header.SetContentType(problemContentType)
+	ctx.Response.SetStatusCode(status)
+
+	return encoder.JSON().Encode(ctx.Response.BodyWriter(), p)
+}
+
+const problemDetailsKey = "problem-details"
+
+type problemDetailsOptions struct {
+	requestIDHeader string
+}
+
+func withProblemDetails(ctx *fasthttp.RequestCtx, o problemDetailsOptions) {
+	ctx.SetUserValue(problemDetailsKey, o)
+}
+
+func getProblemDetails(ctx *fasthttp.RequestCtx) (problemDetailsOptions, bool) {
+	o, ok := ctx.UserValue(problemDetailsKey).(problemDetailsOptions)
+	return o, ok
+}
@@ -0,0 +1,66 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+// sentinelError is a sentinel failure caused by another error,
+// which is unwrapped as the next one in the chain.
+type sentinelError struct {
+	sentinel error
+	cause    error
+}
+
+func (s sentinelError) Error() string        { return fmt.Sprintf("%s: %s", s.sentinel, s.cause) }
+func (s sentinelError) Is(target error) bool { return target == s.sentinel }
+func (s sentinelError) Unwrap() error        { return s.cause }
+
+func TestMakeProblem_Type(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			"should use the type of the only sentinel",
+			fmt.Errorf("%w: hero", restql.ErrNamespaceNotFound),
+			"urn:restql:problem:namespace-not-found",
+		},
+		{
+			"should prefer the invalid query over the parser error",
+			sentinelError{sentinel: eval.ErrParser, cause: fmt.Errorf("%w: unexpected token", parser.ErrInvalidQuery)},
+			"urn:restql:problem:invalid-query",
+		},
+		{
+			"should prefer the invalid query wrapping a parser error",
+			sentinelError{sentinel: parser.ErrInvalidQuery, cause: eval.ErrParser},
+			"urn:restql:problem:invalid-query",
+		},
+		{
+			"should prefer the missing query over the database failure",
+			sentinelError{sentinel: restql.ErrDatabaseCommunicationFailed, cause: restql.ErrQueryNotFoundInDatabase},
+			"urn:restql:problem:query-not-found",
+		},
+		{
+			"should use blank type for unknown errors",
+			errors.New("boom"),
+			"about:blank",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				test.Equal(t, MakeProblem(tt.err, http.StatusBadRequest).Type, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package web
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/logger"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+	"github.com/pkg/errors"
+)
+
+// reloader applies a reloaded configuration to the running API,
+// replacing the mappings and queries from the configuration file,
+// the logging level and the timeout and CORS middlewares.
+type reloader struct {
+	log           restql.Logger
+	engine        *engine.Engine
+	stores        stores
+	mappingWriter *persistence.MappingsWriter
+	queryWriter   *persistence.QueryWriter
+	decorator     *middleware.Decorator
+}
+
+// Apply validates the current configuration before changing anything,
+// so that an invalid file is rejected as a whole.
+func (r *reloader) Apply(previous, current *conf.Config, changes []conf.Change) error {
+	err := r.validate(current)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(previous.TenantMappings, current.TenantMappings) {
+		r.stores.mappingReader.SetLocal(current.TenantMappings)
+		if r.mappingWriter != nil {
+			r.mappingWriter.SetLocal(current.TenantMappings)
+		}
+
+		for _, tenant := range changedKeys(previous.TenantMappings, current.TenantMappings) {
+			r.stores.invalidateTenant(tenant)
+		}
+	}
+
+	if !reflect.DeepEqual(previous.Queries, current.Queries) {
+		r.stores.queryReader.SetLocal(current.Queries)
+		if r.queryWriter != nil {
+			r.queryWriter.SetLocal(current.Queries)
+		}
+
+		for namespace := range unionKeys(previous.Queries, current.Queries) {
+			for _, name := range changedKeys(previous.Queries[namespace], current.Queries[namespace]) {
+				r.stores.invalidateQuery(namespace, name)
+			}
+		}
+	}
+
+	if previous.Logging.Level != current.Logging.Level {
+		err = logger.SetLevel(r.log, current.Logging.Level)
+		if err != nil {
+			return err
+		}
+	}
+
+	if previous.HTTP.QueryResourceTimeout != current.HTTP.QueryResourceTimeout || previous.HTTP.GlobalQueryTimeout != current.HTTP.GlobalQueryTimeout {
+		r.engine.SetTimeouts(current.HTTP.QueryResourceTimeout, current.HTTP.GlobalQueryTimeout)
+	}
+
+	for _, c := range changes {
+		if strings.HasPrefix(c.Path, "http.server.middlewares.") {
+			r.decorator.Reload(current)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (r *reloader) validate(cfg *conf.Config) error {
+	for tenant, mappings := range cfg.TenantMappings {
+		for resource, url := range mappings {
+			_, err := restql.NewMapping(resource, url)
+			if err != nil {
+				return errors.Wrapf(err, "invalid mapping %s on tenant %s", resource, tenant)
+			}
+		}
+	}
+
+	for namespace, queries := range cfg.Queries {
+		for name, revisions := range queries {
+			for i, text := range revisions {
+				err := r.engine.Validate(text)
+				if err != nil {
+					return errors.Wrapf(err, "invalid query %s/%s revision %d", namespace, name, i+1)
+				}
+			}
+		}
+	}
+
+	err := logger.ValidateLevel(cfg.Logging.Level)
+	if err != nil {
+		return err
+	}
+
+	timeout := cfg.HTTP.Server.Middlewares.Timeout
+	if timeout.Enable {
+		_, err := time.ParseDuration(timeout.Duration)
+		if err != nil {
+			return errors.Wrap(err, "invalid timeout middleware duration")
+		}
+	}
+
+	return nil
+}
+
+// changedKeys returns the keys which were added,
+// removed or have a different value between the maps.
+func changedKeys(previous, current interface{}) []string {
+	p, c := reflect.ValueOf(previous), reflect.ValueOf(current)
+
+	var changed []string
+	for k := range unionKeys(previous, current) {
+		key := reflect.ValueOf(k)
+		pv, cv := mapIndex(p, key), mapIndex(c, key)
+		if !reflect.DeepEqual(pv, cv) {
+			changed = append(changed, k)
+		}
+	}
+
+	return changed
+}
+
+func unionKeys(maps ...interface{}) map[string]struct{} {
+	keys := make(map[string]struct{})
+	for _, m := range maps {
+		v := reflect.ValueOf(m)
+		if v.Kind() != reflect.Map {
+			continue
+		}
+
+		for _, k := range v.MapKeys() {
+			keys[k.String()] = struct{}{}
+		}
+	}
+
+	return keys
+}
+
+func mapIndex(m reflect.Value, key reflect.Value) interface{} {
+	if m.Kind() != reflect.Map {
+		return nil
+	}
+
+	v := m.MapIndex(key)
+	if !v.IsValid() {
+		return nil
+	}
+
+	return v.Interface()
+}
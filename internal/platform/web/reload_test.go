@@ -0,0 +1,102 @@
+package web
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestReloader_Apply(t *testing.T) {
+	previous := conf.Defaults()
+	previous.TenantMappings = map[string]map[string]string{"default": {"hero": "http://hero.api"}}
+	previous.Queries = map[string]map[string][]string{"demo": {"hero": {"from hero"}}}
+
+	r := newTestReloader(t, previous)
+	ctx := context.Background()
+
+	_, err := r.stores.engineMappingsReader().FromTenant(ctx, "default")
+	test.VerifyError(t, err)
+	_, err = r.stores.engineQueryReader().Get(ctx, "demo", "hero", 1)
+	test.VerifyError(t, err)
+
+	current := conf.Defaults()
+	current.TenantMappings = map[string]map[string]string{"default": {"hero": "http://heroes.api"}}
+	current.Queries = map[string]map[string][]string{"demo": {"hero": {"from hero with id = 1"}}}
+
+	err = r.Apply(previous, current, conf.Diff(previous, current))
+	test.VerifyError(t, err)
+
+	mappings, err := r.stores.engineMappingsReader().FromTenant(ctx, "default")
+	test.VerifyError(t, err)
+	test.Equal(t, mappings["hero"].Host(), "heroes.api")
+
+	query, err := r.stores.engineQueryReader().Get(ctx, "demo", "hero", 1)
+	test.VerifyError(t, err)
+	test.Equal(t, query.Text, "from hero with id = 1")
+}
+
+func TestReloader_ApplyRejectsInvalidConfiguration(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(cfg *conf.Config)
+	}{
+		{"should reject invalid mapping", func(cfg *conf.Config) {
+			cfg.TenantMappings = map[string]map[string]string{"default": {"hero": "://hero"}}
+		}},
+		{"should reject invalid query", func(cfg *conf.Config) {
+			cfg.Queries = map[string]map[string][]string{"demo": {"hero": {"from"}}}
+		}},
+		{"should reject unknown logging level", func(cfg *conf.Config) {
+			cfg.Logging.Level = "loud"
+		}},
+		{"should reject invalid timeout duration", func(cfg *conf.Config) {
+			cfg.HTTP.Server.Middlewares.Timeout.Enable = true
+			cfg.HTTP.Server.Middlewares.Timeout.Duration = "soon"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := conf.Defaults()
+			previous.Queries = map[string]map[string][]string{"demo": {"hero": {"from hero"}}}
+			r := newTestReloader(t, previous)
+
+			current := conf.Defaults()
+			current.Queries = previous.Queries
+			tt.change(current)
+
+			err := r.Apply(previous, current, conf.Diff(previous, current))
+			if err == nil {
+				t.Fatalf("Apply = nil, want error")
+			}
+
+			query, err := r.stores.engineQueryReader().Get(context.Background(), "demo", "hero", 1)
+			test.VerifyError(t, err)
+			test.Equal(t, query.Text, "from hero")
+		})
+	}
+}
+
+func newTestReloader(t *testing.T, cfg *conf.Config) *reloader {
+	e, err := engine.New(engine.Options{Logger: test.NoOpLogger})
+	test.VerifyError(t, err)
+
+	db, err := persistence.NewDatabase(test.NoOpLogger, persistence.DatabaseOptions{Disabled: true})
+	test.VerifyError(t, err)
+
+	mr := persistence.NewMappingReader(test.NoOpLogger, cfg.Env, cfg.TenantMappings, db)
+	qr := persistence.NewQueryReader(test.NoOpLogger, cfg.Queries, db)
+
+	return &reloader{
+		log:       test.NoOpLogger,
+		engine:    e,
+		stores:    newStores(test.NoOpLogger, cfg, mr, qr, nil),
+		decorator: middleware.NewDecorator(test.NoOpLogger, cfg, plugins.NoOpLifecycle),
+	}
+}
@@ -2,15 +2,17 @@ package web
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/encoder"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
 	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/valyala/fasthttp"
 )
@@ -23,6 +25,12 @@ var errToStatusCode = map[error]int{
 	restql.ErrMappingsNotFoundInDatabase:        fasthttp.StatusNotFound,
 	restql.ErrMappingAlreadyExistsInDatabase:    fasthttp.StatusBadRequest,
 	restql.ErrDatabaseCommunicationFailed:       fasthttp.StatusInsufficientStorage,
+	restql.ErrTenantNotFoundInDatabase:          fasthttp.StatusNotFound,
+	restql.ErrTenantAlreadyExistsInDatabase:     fasthttp.StatusBadRequest,
+	restql.ErrNamespaceAlreadyExistsInDatabase:  fasthttp.StatusBadRequest,
+	restql.ErrQueryAlreadyExistsInDatabase:      fasthttp.StatusBadRequest,
+	restql.ErrDatabaseOperationNotSupported:     fasthttp.StatusNotImplemented,
+	restql.ErrAuditNotEnabled:                   fasthttp.StatusNotImplemented,
 	eval.ErrValidation:                          fasthttp.StatusUnprocessableEntity,
 	eval.ErrParser:                              fasthttp.StatusInternalServerError,
 	eval.ErrTimeout:                             fasthttp.StatusRequestTimeout,
@@ -32,10 +40,22 @@ var errToStatusCode = map[error]int{
 	parser.ErrInvalidQuery:                      fasthttp.StatusUnprocessableEntity,
 	persistence.ErrSetResourceMappingNotAllowed: fasthttp.StatusUnauthorized,
 	persistence.ErrUpdateQueryNotAllowed:        fasthttp.StatusUnauthorized,
+	persistence.ErrSetTenantNotAllowed:          fasthttp.StatusUnauthorized,
+	persistence.ErrUpdateNamespaceNotAllowed:    fasthttp.StatusUnauthorized,
+	errInvalidAdminBody:                         fasthttp.StatusBadRequest,
+	errInvalidAuditFilter:                       fasthttp.StatusBadRequest,
+	errInvalidArchive:                           fasthttp.StatusBadRequest,
+	errInvalidImport:                            fasthttp.StatusBadRequest,
+	errConsoleFileNotFound:                      fasthttp.StatusNotFound,
+	errAdminUnauthorized:                        fasthttp.StatusUnauthorized,
+	errAdminForbidden:                           fasthttp.StatusForbidden,
 	errPathParamNotFound:                        fasthttp.StatusUnprocessableEntity,
 	errInvalidTenant:                            fasthttp.StatusBadRequest,
 	errInvalidRevisionType:                      fasthttp.StatusBadRequest,
+	errInvalidResponseMode:                      fasthttp.StatusBadRequest,
+	errReservedStatementName:                    fasthttp.StatusBadRequest,
 	errFailedToReadRequestBody:                  fasthttp.StatusBadRequest,
+	errNotAcceptable:                            fasthttp.StatusNotAcceptable,
 }
 
 // ErrorResponse is the form used for API responses from failures in the API.
@@ -43,17 +63,19 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
-// Respond write the information back to the client.
+// Respond write the information back to the client,
+// using the encoder negotiated for the request.
 func Respond(ctx *fasthttp.RequestCtx, data interface{}, statusCode int, headers map[string]string) error {
-	ctx.Response.Header.SetContentType("application/json; charset=utf-8")
+	e := getEncoder(ctx)
+
+	ctx.Response.Header.SetContentType(e.ContentType())
 	ctx.Response.SetStatusCode(statusCode)
 	for k, v := range headers {
 		ctx.Response.Header.Set(k, v)
 	}
 
 	if data != nil {
-		encoder := json.NewEncoder(ctx.Response.BodyWriter())
-		if err := encoder.Encode(&data); err != nil {
+		if err := e.Encode(ctx.Response.BodyWriter(), data); err != nil {
 			return err
 		}
 	}
@@ -61,10 +83,31 @@ func Respond(ctx *fasthttp.RequestCtx, data interface{}, statusCode int, headers
 	return nil
 }
 
+const encoderKey = "response-encoder"
+
+func withEncoder(ctx *fasthttp.RequestCtx, e encoder.Encoder) {
+	ctx.SetUserValue(encoderKey, e)
+}
+
+func getEncoder(ctx *fasthttp.RequestCtx) encoder.Encoder {
+	e, ok := ctx.UserValue(encoderKey).(encoder.Encoder)
+	if !ok {
+		return encoder.JSON()
+	}
+
+	return e
+}
+
 // RespondError translate the error and write it back to the client.
+// If problem details are enabled, the error is written as an
+// application/problem+json document.
 func RespondError(ctx *fasthttp.RequestCtx, err error, toStatusCode map[error]int) error {
 	status := findStatusCode(toStatusCode, err)
 
+	if o, ok := getProblemDetails(ctx); ok {
+		return respondProblem(ctx, err, status, o.requestIDHeader)
+	}
+
 	er := ErrorResponse{Error: err.Error()}
 	if err := Respond(ctx, er, status, nil); err != nil {
 		return err
@@ -114,10 +157,36 @@ type StatementResult struct {
 // QueryResponse represents the client format of the query result
 type QueryResponse struct {
 	StatusCode int
-	Body       map[string]StatementResult
+	Body       interface{}
 	Headers    map[string]string
 }
 
+// StatementError represents the client format of a failed
+// statement when the query response has no details envelope.
+type StatementError struct {
+	Statement string      `json:"statement"`
+	Status    int         `json:"status"`
+	Result    interface{} `json:"result,omitempty"`
+}
+
+// Response modes define the shape of the query response body:
+//• envelope: the default, every statement result is wrapped with its details.
+//• data: only the statements results, failures are reported in an `errors` field.
+//• root: the result of the only statement in the query is returned as the body.
+const (
+	envelopeResponseMode = "envelope"
+	dataResponseMode     = "data"
+	rootResponseMode     = "root"
+)
+
+const failedStatementsHeader = "X-Failed-Statements"
+
+// errorsField holds the failed statements on data responses,
+// hence it cannot be used as a statement name in this mode.
+const errorsField = "errors"
+
+var errReservedStatementName = errors.New("invalid statement name : errors is reserved in the data response mode")
+
 // MakeQueryResponse create a query execution response for the client.
 func MakeQueryResponse(queryResult domain.Resources, debug bool) (QueryResponse, error) {
 	m := make(map[string]StatementResult)
@@ -135,6 +204,67 @@ func MakeQueryResponse(queryResult domain.Resources, debug bool) (QueryResponse,
 	return QueryResponse{Body: m, StatusCode: statusCode, Headers: headers}, nil
 }
 
+// MakeDataQueryResponse create a query execution response for the client
+// without the details envelope, where the body maps each statement to its result.
+//
+// Failed statements have a null result and are listed in the `errors` field
+// of the body and in the X-Failed-Statements header. If root is true and the
+// query has only one statement, its result is used as the body and failures
+// are only reported through the header. A statement named `errors`
+// fails with errReservedStatementName, unless it is the root body.
+func MakeDataQueryResponse(queryResult domain.Resources, root bool) (QueryResponse, error) {
+	if _, found := queryResult[errorsField]; found && !(root && len(queryResult) == 1) {
+		return QueryResponse{}, errReservedStatementName
+	}
+
+	results := make(map[string]interface{})
+	data := make(map[string]interface{})
+	var errs []StatementError
+
+	for key, resource := range queryResult {
+		r, err := parseResource(resource, false)
+		if err != nil {
+			return QueryResponse{}, err
+		}
+
+		statement := string(key)
+		results[statement] = r.Result
+
+		status := calculateResultStatusCode(resource)
+		if status >= 400 {
+			data[statement] = nil
+			errs = append(errs, StatementError{Statement: statement, Status: status, Result: r.Result})
+			continue
+		}
+
+		data[statement] = r.Result
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Statement < errs[j].Statement })
+
+	headers := makeHeaders(queryResult)
+	if len(errs) > 0 {
+		failed := make([]string, len(errs))
+		for i, e := range errs {
+			failed[i] = e.Statement
+		}
+		headers[failedStatementsHeader] = strings.Join(failed, ",")
+	}
+
+	var body interface{} = data
+	switch {
+	case root && len(results) == 1:
+		for _, r := range results {
+			body = r
+		}
+	case len(errs) > 0:
+		data[errorsField] = errs
+	}
+
+	statusCode := CalculateStatusCode(queryResult)
+	return QueryResponse{Body: body, StatusCode: statusCode, Headers: headers}, nil
+}
+
 func parseResource(resource interface{}, debug bool) (StatementResult, error) {
 	switch resource := resource.(type) {
 	case restql.DoneResource:
@@ -210,23 +340,89 @@ func parseDebug(resource restql.DoneResource) *StatementDebugging {
 }
 
 // CalculateStatusCode returns the greater status in all
-// statement results to be used as the response status code.
+// statement results to be used as the response status code,
+// skipping optional statements.
 // It applies the following normalization to statement result status codes:
 //
 // 0 => 500
 // 204 => 200
 // 201 => 200
 func CalculateStatusCode(queryResult domain.Resources) int {
-	results := make([]interface{}, len(queryResult))
-	index := 0
+	return CalculateStatusCodeWithPolicy(queryResult, maxOfRequiredStatusPolicy, nil)
+}
+
+// Status policies define how statement results status codes
+// are aggregated into the response status code:
+//• max-of-required: the default, uses the greater status of the statements that are not optional.
+//• first-failure: uses the status of the first failed statement, in order of declaration, that is not optional.
+//• always-200: responds 200 regardless of statements failures, unless a critical statement fails.
+const (
+	maxOfRequiredStatusPolicy = "max-of-required"
+	firstFailureStatusPolicy  = "first-failure"
+	always200StatusPolicy     = "always-200"
+)
+
+// CalculateStatusCodeWithPolicy returns the response status code
+// defined by the given status policy. Critical statements are always
+// considered and optional ones never are, regardless of the policy.
+// The statements order is only used by the first-failure policy.
+func CalculateStatusCodeWithPolicy(queryResult domain.Resources, policy string, order []domain.ResourceID) int {
+	var required []interface{}
 	for _, r := range queryResult {
-		results[index] = r
-		index++
+		if isStatusRequired(r, policy) {
+			required = append(required, r)
+		}
 	}
 
-	maxStatusCode := findMaxStatusCode(results)
+	if policy == firstFailureStatusPolicy {
+		if status, found := findFirstFailureStatusCode(queryResult, order); found {
+			return status
+		}
+	}
 
-	return maxStatusCode
+	return findMaxStatusCode(required)
+}
+
+func findFirstFailureStatusCode(queryResult domain.Resources, order []domain.ResourceID) (int, bool) {
+	for _, id := range order {
+		r, found := queryResult[id]
+		if !found || !isStatusRequired(r, firstFailureStatusPolicy) {
+			continue
+		}
+
+		status := calculateResultStatusCode(r)
+		if status >= 400 {
+			return status, true
+		}
+	}
+
+	return 0, false
+}
+
+func isStatusRequired(result interface{}, policy string) bool {
+	switch findCriticality(result) {
+	case restql.Critical:
+		return true
+	case restql.Optional:
+		return false
+	default:
+		return policy != always200StatusPolicy
+	}
+}
+
+func findCriticality(result interface{}) restql.Criticality {
+	switch r := result.(type) {
+	case restql.DoneResource:
+		return r.Criticality
+	case restql.DoneResources:
+		for _, item := range r {
+			if c := findCriticality(item); c != restql.DefaultCriticality {
+				return c
+			}
+		}
+	}
+
+	return restql.DefaultCriticality
 }
 
 var statusNormalization = map[int]int{0: 500, 204: 200, 201: 200}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
 	"github.com/b2wdigital/restQL-golang/v6/test"
 )
 
@@ -432,6 +433,211 @@ func TestMakeQueryResponse(t *testing.T) {
 
 }
 
+func TestMakeDataQueryResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		queryResult domain.Resources
+		root        bool
+		expected    web.QueryResponse
+	}{
+		{
+			"should make response with only the statement results",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"id": "12345abcde"}`)),
+				},
+				"villain": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"name": "Joker"}`)),
+				},
+			},
+			false,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body: map[string]interface{}{
+					"hero":    rawResult(`{"id": "12345abcde"}`),
+					"villain": rawResult(`{"name": "Joker"}`),
+				},
+				Headers: map[string]string{},
+			},
+		},
+		{
+			"should move failed statements into errors and headers",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"id": "12345abcde"}`)),
+				},
+				"villain": restql.DoneResource{
+					Status:       404,
+					Success:      false,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"message": "not found"}`)),
+				},
+				"sidekick": restql.DoneResource{
+					Status:       500,
+					Success:      false,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"message": "boom"}`)),
+				},
+			},
+			false,
+			web.QueryResponse{
+				StatusCode: 500,
+				Body: map[string]interface{}{
+					"hero":     rawResult(`{"id": "12345abcde"}`),
+					"villain":  nil,
+					"sidekick": nil,
+					"errors": []web.StatementError{
+						{Statement: "sidekick", Status: 500, Result: rawResult(`{"message": "boom"}`)},
+						{Statement: "villain", Status: 404, Result: rawResult(`{"message": "not found"}`)},
+					},
+				},
+				Headers: map[string]string{"X-Failed-Statements": "sidekick,villain"},
+			},
+		},
+		{
+			"should keep failed statements with ignore errors as data",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       500,
+					Success:      false,
+					IgnoreErrors: true,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"message": "boom"}`)),
+				},
+			},
+			false,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body: map[string]interface{}{
+					"hero": rawResult(`{"message": "boom"}`),
+				},
+				Headers: map[string]string{},
+			},
+		},
+		{
+			"should make response with single statement result at root",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"id": "12345abcde"}`)),
+				},
+			},
+			true,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body:       rawResult(`{"id": "12345abcde"}`),
+				Headers:    map[string]string{},
+			},
+		},
+		{
+			"should make response with failed statement result at root",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       404,
+					Success:      false,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"message": "not found"}`)),
+				},
+			},
+			true,
+			web.QueryResponse{
+				StatusCode: 404,
+				Body:       rawResult(`{"message": "not found"}`),
+				Headers:    map[string]string{"X-Failed-Statements": "hero"},
+			},
+		},
+		{
+			"should keep statements keyed by alias at root when there are many",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"id": "12345abcde"}`)),
+				},
+				"villain": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"name": "Joker"}`)),
+				},
+			},
+			true,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body: map[string]interface{}{
+					"hero":    rawResult(`{"id": "12345abcde"}`),
+					"villain": rawResult(`{"name": "Joker"}`),
+				},
+				Headers: map[string]string{},
+			},
+		},
+		{
+			"should keep cache control headers",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					CacheControl: restql.ResourceCacheControl{MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 400}},
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"id": "12345abcde"}`)),
+				},
+			},
+			true,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body:       rawResult(`{"id": "12345abcde"}`),
+				Headers:    map[string]string{"Cache-Control": "max-age=400"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := web.MakeDataQueryResponse(tt.queryResult, tt.root)
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestMakeDataQueryResponse_ReservedStatementName(t *testing.T) {
+	queryResult := domain.Resources{
+		"errors": restql.DoneResource{Status: 200, Success: true, ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"id": 1}`))},
+		"hero":   restql.DoneResource{Status: 500, Success: false, ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"id": 2}`))},
+	}
+
+	_, err := web.MakeDataQueryResponse(queryResult, false)
+	if err == nil {
+		t.Fatalf("MakeDataQueryResponse() expected error for statement named errors")
+	}
+
+	_, err = web.MakeDataQueryResponse(domain.Resources{"errors": queryResult["errors"]}, true)
+	test.VerifyError(t, err)
+}
+
+func TestMakeResultResponse_UnknownResponseMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		modifiers map[string]interface{}
+		params    map[string]interface{}
+	}{
+		{"should reject unknown request parameter", nil, map[string]interface{}{"_response": "table"}},
+		{"should reject unknown use clause", map[string]interface{}{"response": "table"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := engine.Result{Modifiers: tt.modifiers}
+
+			_, err := web.MakeResultResponse(result, restql.QueryInput{Params: tt.params}, false)
+			if err == nil {
+				t.Fatalf("MakeResultResponse() expected error for unknown response mode")
+			}
+		})
+	}
+}
+
 func TestCalculateStatusCode(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -491,6 +697,104 @@ func TestCalculateStatusCode(t *testing.T) {
 	}
 }
 
+func TestCalculateStatusCodeWithPolicy(t *testing.T) {
+	order := []domain.ResourceID{"hero", "sidekick", "villain"}
+
+	tests := []struct {
+		name        string
+		queryResult domain.Resources
+		policy      string
+		expected    int
+	}{
+		{
+			"should return max status code of required statements",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 404},
+				"sidekick": restql.DoneResource{Status: 500, Criticality: restql.Optional},
+				"villain":  restql.DoneResource{Status: 200},
+			},
+			"max-of-required",
+			404,
+		},
+		{
+			"should use max of required as default policy",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 200},
+				"sidekick": restql.DoneResource{Status: 500, Criticality: restql.Optional},
+			},
+			"",
+			200,
+		},
+		{
+			"should return status code of first failed statement",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 200},
+				"sidekick": restql.DoneResource{Status: 404},
+				"villain":  restql.DoneResource{Status: 500},
+			},
+			"first-failure",
+			404,
+		},
+		{
+			"should skip optional statements when looking for first failure",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 200},
+				"sidekick": restql.DoneResource{Status: 404, Criticality: restql.Optional},
+				"villain":  restql.DoneResource{Status: 503},
+			},
+			"first-failure",
+			503,
+		},
+		{
+			"should return max status code when no statement failed on first failure policy",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 200},
+				"sidekick": restql.DoneResource{Status: 301},
+			},
+			"first-failure",
+			301,
+		},
+		{
+			"should always return 200",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 200},
+				"sidekick": restql.DoneResource{Status: 500},
+			},
+			"always-200",
+			200,
+		},
+		{
+			"should return status code of critical statements on always 200 policy",
+			domain.Resources{
+				"hero":     restql.DoneResource{Status: 404, Criticality: restql.Critical},
+				"sidekick": restql.DoneResource{Status: 500},
+			},
+			"always-200",
+			404,
+		},
+		{
+			"should use multiplexed statement criticality",
+			domain.Resources{
+				"hero": restql.DoneResources{
+					restql.DoneResource{Status: 200, Criticality: restql.Optional},
+					restql.DoneResource{Status: 500, Criticality: restql.Optional},
+				},
+				"sidekick": restql.DoneResource{Status: 200},
+			},
+			"max-of-required",
+			200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := web.CalculateStatusCodeWithPolicy(tt.queryResult, tt.policy, order)
+
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
 func rawResult(s string) json.RawMessage {
 	b, err := json.Marshal(test.Unmarshal(s))
 	if err != nil {
@@ -1,16 +1,17 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
 
-	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
-	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
 	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
 )
@@ -21,27 +22,25 @@ var (
 	errInvalidRevisionType     = errors.New("invalid revision : must be an integer")
 	errInvalidTenant           = errors.New("invalid tenant : no value provided")
 	errFailedToReadRequestBody = errors.New("failed to read and unmarshal request body")
+	errInvalidResponseMode     = errors.New("invalid response mode : must be envelope, data or root")
 )
 
 type restQl struct {
-	config    *conf.Config
-	log       restql.Logger
-	evaluator eval.Evaluator
-	parser    parser.Parser
+	config *conf.Config
+	log    restql.Logger
+	engine *engine.Engine
 }
 
-func newRestQl(l restql.Logger, cfg *conf.Config, e eval.Evaluator, p parser.Parser) restQl {
-	return restQl{config: cfg, log: l, evaluator: e, parser: p}
+func newRestQl(l restql.Logger, cfg *conf.Config, e *engine.Engine) restQl {
+	return restQl{config: cfg, log: l, engine: e}
 }
 
 func (r restQl) ValidateQuery(ctx *fasthttp.RequestCtx) error {
 	queryTxt := string(ctx.PostBody())
-	_, err := r.parser.Parse(queryTxt)
+	err := r.engine.Validate(queryTxt)
 	if err != nil {
 		r.log.Error("an error occurred when parsing query", err)
-		e := fmt.Errorf("%w: %s", parser.ErrInvalidQuery, err)
-
-		return RespondError(ctx, e, errToStatusCode)
+		return RespondError(ctx, err, errToStatusCode)
 	}
 
 	return Respond(ctx, nil, http.StatusOK, nil)
@@ -55,31 +54,39 @@ func (r restQl) RunAdHocQuery(reqCtx *fasthttp.RequestCtx) error {
 		r.log.Error("failed to build query options", err)
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
-	options := restql.QueryOptions{Tenant: tenant}
-
 	input, err := makeQueryInput(reqCtx, r.log)
 	if err != nil {
 		r.log.Error("failed to build query input", err)
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	if _, err := responseMode(nil, input); err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
 	queryTxt := string(reqCtx.PostBody())
+	debugEnabled := isDebugEnabled(input)
 
-	result, err := r.evaluator.AdHocQuery(ctx, queryTxt, options, input)
+	adhocErrToStatusCode := make(map[error]int)
+	for err, status := range errToStatusCode {
+		adhocErrToStatusCode[err] = status
+	}
+	adhocErrToStatusCode[engine.ErrParser] = http.StatusBadRequest
+
+	if format, ok := negotiateStreamFormat(reqCtx); ok {
+		return respondStream(reqCtx, r.log, format, debugEnabled, adhocErrToStatusCode, func(ctx context.Context, emit engine.EmitFunc) (engine.Result, error) {
+			return r.engine.Stream(ctx, queryTxt, makeEngineInput(tenant, input), emit)
+		})
+	}
+
+	result, err := r.engine.Run(ctx, queryTxt, makeEngineInput(tenant, input))
 	if err != nil {
 		r.log.Error("failed to evaluated adhoc query", err)
 
-		adhocErrToStatusCode := make(map[error]int)
-		for err, status := range errToStatusCode {
-			adhocErrToStatusCode[err] = status
-		}
-		adhocErrToStatusCode[eval.ErrParser] = http.StatusBadRequest
-
 		return RespondError(reqCtx, err, adhocErrToStatusCode)
 	}
 
-	debugEnabled := isDebugEnabled(input)
-	response, err := MakeQueryResponse(result, debugEnabled)
+	response, err := MakeResultResponse(result, input, debugEnabled)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
@@ -106,15 +113,26 @@ func (r restQl) RunSavedQuery(reqCtx *fasthttp.RequestCtx) error {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	result, err := r.evaluator.SavedQuery(ctx, options, input)
+	if _, err := responseMode(nil, input); err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	debugEnabled := isDebugEnabled(input)
+
+	if format, ok := negotiateStreamFormat(reqCtx); ok {
+		return respondStream(reqCtx, log, format, debugEnabled, errToStatusCode, func(ctx context.Context, emit engine.EmitFunc) (engine.Result, error) {
+			return r.engine.StreamSaved(ctx, options.Namespace, options.Id, options.Revision, makeEngineInput(options.Tenant, input), emit)
+		})
+	}
+
+	result, err := r.engine.RunSaved(ctx, options.Namespace, options.Id, options.Revision, makeEngineInput(options.Tenant, input))
 	if err != nil {
 		log.Error("failed to evaluated saved query", err)
 
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	debugEnabled := isDebugEnabled(input)
-	response, err := MakeQueryResponse(result, debugEnabled)
+	response, err := MakeResultResponse(result, input, debugEnabled)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
@@ -228,6 +246,83 @@ func makeQueryInput(ctx *fasthttp.RequestCtx, log restql.Logger) (restql.QueryIn
 	return input, nil
 }
 
+const responseModeParamName = "_response"
+
+// MakeResultResponse builds the response in the shape defined by the
+// request parameter or, in its absence, by the query `use` clause,
+// with the status code given by the query status policy.
+func MakeResultResponse(result engine.Result, queryInput restql.QueryInput, debug bool) (QueryResponse, error) {
+	var response QueryResponse
+
+	mode, err := responseMode(result.Modifiers, queryInput)
+	if err != nil {
+		return QueryResponse{}, err
+	}
+
+	resources, _ := makeResources(result)
+	switch mode {
+	case dataResponseMode:
+		response, err = MakeDataQueryResponse(resources, false)
+	case rootResponseMode:
+		response, err = MakeDataQueryResponse(resources, true)
+	default:
+		response, err = MakeQueryResponse(resources, debug)
+	}
+	if err != nil {
+		return QueryResponse{}, err
+	}
+
+	response.StatusCode = calculateStatusCode(result)
+	return response, nil
+}
+
+// calculateStatusCode applies the status policy defined
+// by the query `use` clause on the statements results.
+func calculateStatusCode(result engine.Result) int {
+	policy, _ := result.Modifiers["status-policy"].(string)
+	resources, order := makeResources(result)
+	return CalculateStatusCodeWithPolicy(resources, policy, order)
+}
+
+// makeResources returns the statements results indexed
+// by their identifier, along with the declaration order.
+func makeResources(result engine.Result) (domain.Resources, []domain.ResourceID) {
+	resources := make(domain.Resources, len(result.Statements))
+	order := make([]domain.ResourceID, len(result.Statements))
+	for i, s := range result.Statements {
+		id := domain.ResourceID(s.Name)
+		resources[id] = s.Value()
+		order[i] = id
+	}
+
+	return resources, order
+}
+
+func makeEngineInput(tenant string, input restql.QueryInput) engine.Input {
+	return engine.Input{Tenant: tenant, Params: input.Params, Headers: input.Headers, Body: input.Body}
+}
+
+// responseMode returns the response shape asked by the request
+// parameter or the query `use` clause, failing with an
+// errInvalidResponseMode when it is unknown.
+func responseMode(modifiers domain.Modifiers, queryInput restql.QueryInput) (string, error) {
+	mode := envelopeResponseMode
+	if m, found := modifiers["response"]; found {
+		mode = fmt.Sprintf("%v", m)
+	}
+
+	if m, ok := queryInput.Params[responseModeParamName].(string); ok && m != "" {
+		mode = m
+	}
+
+	switch mode {
+	case envelopeResponseMode, dataResponseMode, rootResponseMode:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w : %s", errInvalidResponseMode, mode)
+	}
+}
+
 const debugParamName = "_debug"
 
 func isDebugEnabled(queryInput restql.QueryInput) bool {
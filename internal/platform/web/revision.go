@@ -0,0 +1,224 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser/ast"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/encoder"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/valyala/fasthttp"
+)
+
+// Kinds of problem that make a query revision invalid.
+const (
+	syntaxProblem          = "syntax"
+	unknownTenantProblem   = "unknown-tenant"
+	unknownResourceProblem = "unknown-resource"
+)
+
+var errInvalidRevision = errors.New("invalid query revision")
+
+// revisionProblem describes why a query revision was rejected,
+// with the position of syntax errors or the resource and
+// tenant of mapping errors.
+type revisionProblem struct {
+	Type     string `json:"type"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	Tenant   string `json:"tenant,omitempty"`
+}
+
+type invalidRevisionResponse struct {
+	Error    string            `json:"error"`
+	Problems []revisionProblem `json:"problems"`
+}
+
+type invalidRevisionProblem struct {
+	Problem
+	Problems []revisionProblem `json:"problems"`
+}
+
+// validateRevision parses the query text and checks that every
+// statement resource is mapped on the given tenants. The problems
+// found are returned, while the error is only for failures that
+// prevent the validation, like the database being unavailable.
+func (adm *administrator) validateRevision(ctx context.Context, text string, tenants []string) ([]revisionProblem, error) {
+	query, err := adm.parser.Parse(text)
+	if err != nil {
+		p := revisionProblem{Type: syntaxProblem, Message: err.Error()}
+
+		var syntaxErr *ast.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			p.Line = syntaxErr.Line
+			p.Column = syntaxErr.Column
+		}
+
+		return []revisionProblem{p}, nil
+	}
+
+	var problems []revisionProblem
+	for _, tenant := range tenants {
+		mappings, err := adm.mr.FromTenant(ctx, tenant)
+		switch {
+		case errors.Is(err, restql.ErrMappingsNotFound):
+			problems = append(problems, revisionProblem{
+				Type:    unknownTenantProblem,
+				Message: fmt.Sprintf("tenant %s has no mappings", tenant),
+				Tenant:  tenant,
+			})
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		problems = append(problems, unmappedResources(query, tenant, mappings)...)
+	}
+
+	return problems, nil
+}
+
+// unmappedResources returns a problem for each statement
+// resource without mapping, reporting a resource once.
+func unmappedResources(query domain.Query, tenant string, mappings map[string]restql.Mapping) []revisionProblem {
+	var problems []revisionProblem
+	reported := make(map[string]bool)
+	for _, stmt := range query.Statements {
+		if _, found := mappings[stmt.Resource]; found || reported[stmt.Resource] {
+			continue
+		}
+
+		reported[stmt.Resource] = true
+		problems = append(problems, revisionProblem{
+			Type:     unknownResourceProblem,
+			Message:  fmt.Sprintf("resource %s has no mapping on tenant %s", stmt.Resource, tenant),
+			Resource: stmt.Resource,
+			Tenant:   tenant,
+		})
+	}
+
+	return problems
+}
+
+// respondInvalidRevision writes the problems in the error
+// response, as an extension when problem details are enabled.
+func respondInvalidRevision(reqCtx *fasthttp.RequestCtx, problems []revisionProblem) error {
+	status := fasthttp.StatusUnprocessableEntity
+
+	o, ok := getProblemDetails(reqCtx)
+	if !ok {
+		return Respond(reqCtx, invalidRevisionResponse{Error: errInvalidRevision.Error(), Problems: problems}, status, nil)
+	}
+
+	p := MakeProblem(errInvalidRevision, status)
+	p.Instance = string(reqCtx.Path())
+	if o.requestIDHeader != "" {
+		p.RequestID = string(reqCtx.Request.Header.Peek(o.requestIDHeader))
+	}
+
+	reqCtx.Response.Header.SetContentType(problemContentType)
+	reqCtx.Response.SetStatusCode(status)
+
+	return encoder.JSON().Encode(reqCtx.Response.BodyWriter(), invalidRevisionProblem{Problem: p, Problems: problems})
+}
+
+type validateRevisionBody struct {
+	Text    string                 `json:"text"`
+	Params  map[string]interface{} `json:"params"`
+	Headers map[string]string      `json:"headers"`
+	Body    interface{}            `json:"body"`
+}
+
+// ValidateRevision checks the query text like the creation of a revision
+// does, without storing it, so that editors can validate while typing.
+// With the dryRun param, a valid text is also executed with the body
+// input, allowing keys with read scope to try queries out.
+// The tenants given, or the default one on dry runs without
+// tenants, must be readable by the request key.
+func (adm *administrator) ValidateRevision(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	var body validateRevisionBody
+	err := json.Unmarshal(reqCtx.PostBody(), &body)
+	if err != nil {
+		return RespondError(reqCtx, errInvalidAdminBody, errToStatusCode)
+	}
+
+	tenants := queryArgStrings(reqCtx, "tenant")
+	dryRun, _ := strconv.ParseBool(string(reqCtx.QueryArgs().Peek("dryRun")))
+
+	checkedTenants := tenants
+	if dryRun && len(checkedTenants) == 0 && adm.tenant != "" {
+		checkedTenants = []string{adm.tenant}
+	}
+
+	if !adm.readableTenants(reqCtx, checkedTenants) {
+		return RespondError(reqCtx, errAdminForbidden, errToStatusCode)
+	}
+
+	problems, err := adm.validateRevision(ctx, body.Text, tenants)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	if problems == nil {
+		problems = []revisionProblem{}
+	}
+
+	data := map[string]interface{}{"valid": len(problems) == 0, "problems": problems}
+	if dryRun && len(problems) == 0 {
+		input := restql.QueryInput{Params: body.Params, Headers: body.Headers, Body: body.Body}
+		data["results"] = adm.dryRun(ctx, body.Text, tenants, input)
+	}
+
+	return Respond(reqCtx, data, fasthttp.StatusOK, nil)
+}
+
+// dryRunResult is the response of a query revision
+// executed on a tenant, or the failure to execute it.
+type dryRunResult struct {
+	Tenant     string      `json:"tenant,omitempty"`
+	StatusCode int         `json:"statusCode,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// dryRun executes the query text on each tenant, or on the
+// default tenant when none is given, with the request input.
+// The statements debugging is included when the input has
+// the _debug param.
+func (adm *administrator) dryRun(ctx context.Context, text string, tenants []string, input restql.QueryInput) []dryRunResult {
+	if len(tenants) == 0 {
+		tenants = []string{""}
+	}
+
+	results := make([]dryRunResult, len(tenants))
+	for i, tenant := range tenants {
+		results[i] = dryRunResult{Tenant: tenant}
+
+		result, err := adm.engine.Run(ctx, text, makeEngineInput(tenant, input))
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		response, err := MakeResultResponse(result, input, isDebugEnabled(input))
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		results[i].StatusCode = response.StatusCode
+		results[i].Body = response.Body
+	}
+
+	return results
+}
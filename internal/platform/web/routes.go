@@ -1,86 +1,293 @@
 package web
 
 import (
+	"context"
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"io"
 	"net/http"
+	"time"
 
-	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/cache"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/decoder"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/encoder"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/graphqlclient"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/grpcclient"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/httpclient"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
-	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/recording"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
 )
 
-// API constructs a handler for the restQL query related endpoints
-func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
+// API constructs a handler for the restQL query related endpoints,
+// the function that applies a reloaded configuration to it and
+// the closer that releases its upstream clients on shutdown.
+func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, conf.ApplyFunc, io.Closer, error) {
 	log.Debug("starting api")
-	defaultParser, err := parser.New()
-	if err != nil {
-		log.Error("failed to compile parser", err)
-		return nil, err
-	}
-	parserCacheLoader := cache.New(log, cfg.Cache.Parser.MaxSize, cache.ParserCacheLoader(defaultParser))
-	parserCache := cache.NewParserCache(log, parserCacheLoader)
 
-	databaseDisabled := cfg.Plugins.DisableDatabase
-	db, err := persistence.NewDatabase(log, databaseDisabled)
+	db, err := persistence.NewDatabase(log, databaseOptions(cfg))
 	if err != nil {
 		log.Error("failed to establish connection to database", err)
-		return nil, err
+		return nil, nil, nil, err
 	}
 
-	lifecycle, err := plugins.NewLifecycle(log)
+	lifecyclePlugins := loadLifecyclePlugins(log)
+	lifecycle := plugins.NewLifecycle(log, lifecyclePlugins)
+
+	queryDir, err := newQueryDirectory(log, cfg)
 	if err != nil {
-		log.Error("failed to initialize plugins", err)
+		return nil, nil, nil, err
 	}
 
-	client := httpclient.New(log, lifecycle, cfg)
-	executor := runner.NewExecutor(log, client, cfg.HTTP.QueryResourceTimeout, cfg.HTTP.ForwardPrefix)
-	r := runner.NewRunner(log, executor, runner.Options{
-		GlobalQueryTimeout:      cfg.HTTP.GlobalQueryTimeout,
-		MaxConcurrentQueries:    cfg.HTTP.Client.MaxConcurrentQueries,
-		MaxConcurrentGoroutines: cfg.HTTP.Client.MaxConcurrentGoroutines,
-	})
-
 	mappingReader := persistence.NewMappingReader(log, cfg.Env, cfg.TenantMappings, db)
-	cacheMr := addMappingsReaderCache(log, cfg, mappingReader)
+	queryReader := persistence.NewQueryReader(log, cfg.Queries, db).WithDirectory(queryDir)
+	stores := newStores(log, cfg, mappingReader, queryReader, queryDir)
+	functions := plugins.NewFunctions(log)
 
-	queryReader := persistence.NewQueryReader(log, cfg.Queries, db)
-	cacheQr := addQueryReaderCache(log, cfg, queryReader)
-
-	e := eval.NewEvaluator(log, cacheMr, cacheQr, r, parserCache, lifecycle)
+	e, err := newEngine(log, cfg, lifecyclePlugins, lifecycle, functions, stores)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	restQl := newRestQl(log, cfg, e, defaultParser)
+	restQl := newRestQl(log, cfg, e)
 
 	md := middleware.NewDecorator(log, cfg, lifecycle)
-	app := newApp(log, appOptions{MiddlewareDecorator: md})
+	encoders := encoder.New(log, plugins.NewResponseEncoders(log))
+	app := newApp(log, appOptions{
+		MiddlewareDecorator: md,
+		Encoders:            &encoders,
+		ProblemDetails:      cfg.HTTP.Server.ProblemDetails.Enable,
+		RequestIDHeader:     requestIDHeader(cfg),
+	})
 	app.Handle(http.MethodPost, "/validate-query", restQl.ValidateQuery)
 	app.Handle(http.MethodPost, "/run-query", restQl.RunAdHocQuery)
 	app.Handle(http.MethodGet, "/run-query/{namespace}/{queryId}/{revision}", restQl.RunSavedQuery)
 	app.Handle(http.MethodPost, "/run-query/{namespace}/{queryId}/{revision}", restQl.RunSavedQuery)
 
+	r := &reloader{log: log, engine: e, stores: stores, decorator: md}
+
 	if cfg.HTTP.Server.Admin.Enable {
 		log.Info("administration api enabled")
 		mw := persistence.NewMappingWriter(log, cfg.Env, cfg.TenantMappings, db)
-		qw := persistence.NewQueryWriter(log, cfg.Queries, db)
+		qw := persistence.NewQueryWriter(log, cfg.Queries, db).WithDirectory(queryDir)
+		r.mappingWriter = &mw
+		r.queryWriter = &qw
+
+		auth, err := newAdminAuthorizer(log, cfg)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 
-		adm := newAdmin(log, mappingReader, mw, queryReader, qw, cfg.HTTP.Server.Admin.AuthorizationCode)
+		auditLog, err := persistence.NewAuditLog(log, db, cfg.HTTP.Server.Admin.Audit.File)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		p, err := parser.New(functions...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		adm := newAdmin(log, adminOptions{
+			MappingsReader:  mappingReader,
+			MappingsWriter:  mw,
+			QueryReader:     queryReader,
+			QueryWriter:     qw,
+			Stores:          stores,
+			Authorizer:      auth,
+			AuditLog:        auditLog,
+			Parser:          p,
+			Engine:          e,
+			Tenant:          cfg.Tenant,
+			RequestIDHeader: requestIDHeader(cfg),
+		})
 		app = registerAdminEndpoints(adm, app)
 	}
 
-	return app.RequestHandler(), nil
+	return app.RequestHandler(), r.Apply, e, nil
+}
+
+// NewEngine constructs a restQL engine with the same upstream
+// clients, plugins and stores used by the API, to execute queries in-process.
+func NewEngine(log restql.Logger, cfg *conf.Config) (*engine.Engine, error) {
+	db, err := persistence.NewDatabase(log, databaseOptions(cfg))
+	if err != nil {
+		log.Error("failed to establish connection to database", err)
+		return nil, err
+	}
+
+	lifecyclePlugins := loadLifecyclePlugins(log)
+	lifecycle := plugins.NewLifecycle(log, lifecyclePlugins)
+
+	queryDir, err := newQueryDirectory(log, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	mappingReader := persistence.NewMappingReader(log, cfg.Env, cfg.TenantMappings, db)
+	queryReader := persistence.NewQueryReader(log, cfg.Queries, db).WithDirectory(queryDir)
+	stores := newStores(log, cfg, mappingReader, queryReader, queryDir)
+
+	return newEngine(log, cfg, lifecyclePlugins, lifecycle, plugins.NewFunctions(log), stores)
+}
+
+// newQueryDirectory loads the queries directory, if configured,
+// returning nil otherwise.
+func newQueryDirectory(log restql.Logger, cfg *conf.Config) (*persistence.QueryDirectory, error) {
+	path := cfg.QueriesDirectory.Path
+	if path == "" {
+		return nil, nil
+	}
+
+	dir, err := persistence.NewQueryDirectory(log, path)
+	if err != nil {
+		log.Error("failed to load queries directory", err, "path", path)
+		return nil, err
+	}
+
+	log.Info("queries directory enabled", "path", path)
+	return dir, nil
+}
+
+func loadLifecyclePlugins(log restql.Logger) []restql.LifecyclePlugin {
+	lifecyclePlugins := plugins.LoadLifecyclePlugins(log)
+	if len(lifecyclePlugins) == 0 {
+		log.Info("no lifecycle hook provided")
+	}
+
+	return lifecyclePlugins
+}
+
+func newEngine(log restql.Logger, cfg *conf.Config, lifecyclePlugins []restql.LifecyclePlugin, lifecycle plugins.Lifecycle, functions []restql.Function, stores stores) (*engine.Engine, error) {
+	decoders := decoder.New(log, plugins.NewResponseDecoders(log))
+
+	client, err := newHTTPClient(log, cfg, lifecycle, decoders)
+	if err != nil {
+		log.Error("failed to initialize http client", err)
+		return nil, err
+	}
+
+	e, err := engine.New(engine.Options{
+		Logger:                  log,
+		HTTPClient:              client,
+		Tenant:                  cfg.Tenant,
+		MappingsReader:          stores.engineMappingsReader(),
+		QueryReader:             stores.engineQueryReader(),
+		LifecyclePlugins:        lifecyclePlugins,
+		Functions:               functions,
+		ResourceTimeout:         cfg.HTTP.QueryResourceTimeout,
+		GlobalQueryTimeout:      cfg.HTTP.GlobalQueryTimeout,
+		ForwardPrefix:           cfg.HTTP.ForwardPrefix,
+		MaxConcurrentQueries:    cfg.HTTP.Client.MaxConcurrentQueries,
+		MaxConcurrentGoroutines: cfg.HTTP.Client.MaxConcurrentGoroutines,
+		ParserCacheSize:         cfg.Cache.Parser.MaxSize,
+	})
+	if err != nil {
+		log.Error("failed to compile parser", err)
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func newHTTPClient(log restql.Logger, cfg *conf.Config, lifecycle plugins.Lifecycle, decoders decoder.Registry) (domain.HTTPClient, error) {
+	client, err := newUpstreamClient(log, cfg, lifecycle, decoders)
+	if err != nil {
+		return nil, err
+	}
+
+	return withRecording(log, cfg, client)
+}
+
+func newUpstreamClient(log restql.Logger, cfg *conf.Config, lifecycle plugins.Lifecycle, decoders decoder.Registry) (domain.HTTPClient, error) {
+	client := httpclient.New(log, lifecycle, decoders, cfg)
+
+	graphQLClient := graphqlclient.New(log, client)
+	transports := map[string]domain.HTTPClient{
+		"graphql":  graphQLClient,
+		"graphqls": graphQLClient,
+	}
+
+	descriptorSets := cfg.HTTP.Client.GRPC.DescriptorSets
+	if len(descriptorSets) == 0 {
+		return httpclient.NewRouter(client, transports), nil
+	}
+
+	files, err := grpcclient.LoadDescriptorSets(descriptorSets)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("grpc resources enabled", "descriptor-sets", descriptorSets)
+
+	grpcClient := grpcclient.New(log, lifecycle, files)
+	transports["grpc"] = grpcClient
+	transports["grpcs"] = grpcClient
+
+	return httpclient.NewRouter(client, transports), nil
+}
+
+// stores are the mappings and queries readers used by the engine,
+// with the caches in front of them, which are nil when disabled.
+type stores struct {
+	mappingReader persistence.MappingsReader
+	queryReader   persistence.QueryReader
+	mappingsCache *cache.MappingsReaderCache
+	queryCache    *cache.QueryReaderCache
+}
+
+func newStores(log restql.Logger, cfg *conf.Config, mappingReader persistence.MappingsReader, queryReader persistence.QueryReader, queryDir *persistence.QueryDirectory) stores {
+	s := stores{mappingReader: mappingReader, queryReader: queryReader}
+	if !cfg.Cache.Disable {
+		s.mappingsCache = addMappingsReaderCache(log, cfg, mappingReader)
+		s.queryCache = addQueryReaderCache(log, cfg, queryReader)
+	}
+
+	watchQueryDirectory(log, cfg, queryDir, s.invalidateQuery)
+	return s
+}
+
+func (s stores) engineMappingsReader() engine.MappingsReader {
+	if s.mappingsCache == nil {
+		return s.mappingReader
+	}
+
+	return s.mappingsCache
+}
+
+func (s stores) engineQueryReader() engine.QueryReader {
+	if s.queryCache == nil {
+		return s.queryReader
+	}
+
+	return s.queryCache
+}
+
+func (s stores) invalidateTenant(tenant string) {
+	if s.mappingsCache != nil {
+		s.mappingsCache.Invalidate(tenant)
+	}
+}
+
+func (s stores) invalidateQuery(namespace, name string) {
+	if s.queryCache != nil {
+		s.queryCache.Invalidate(namespace, name)
+	}
 }
 
-func addMappingsReaderCache(log restql.Logger, cfg *conf.Config, mappingReader persistence.MappingsReader) eval.MappingsReader {
-	if cfg.Cache.Disable {
-		return mappingReader
+func (s stores) invalidateNamespace(namespace string) {
+	if s.queryCache != nil {
+		s.queryCache.InvalidateNamespace(namespace)
 	}
+}
 
+func addMappingsReaderCache(log restql.Logger, cfg *conf.Config, mappingReader persistence.MappingsReader) *cache.MappingsReaderCache {
 	log.Info("mappings cache enabled")
 
 	tenantCache := cache.New(log, cfg.Cache.Mappings.MaxSize,
@@ -93,11 +300,7 @@ func addMappingsReaderCache(log restql.Logger, cfg *conf.Config, mappingReader p
 	return cacheMr
 }
 
-func addQueryReaderCache(log restql.Logger, cfg *conf.Config, queryReader persistence.QueryReader) eval.QueryReader {
-	if cfg.Cache.Disable {
-		return queryReader
-	}
-
+func addQueryReaderCache(log restql.Logger, cfg *conf.Config, queryReader persistence.QueryReader) *cache.QueryReaderCache {
 	log.Info("queries cache enabled")
 
 	queryCache := cache.New(log, cfg.Cache.Query.MaxSize, cache.QueryCacheLoader(queryReader))
@@ -105,20 +308,53 @@ func addQueryReaderCache(log restql.Logger, cfg *conf.Config, queryReader persis
 	return cacheQr
 }
 
-// registerAdminEndpoints adds handlers for administrative operations
+// watchQueryDirectory reloads the queries directory in background,
+// calling onChange for every query created, updated or removed.
+func watchQueryDirectory(log restql.Logger, cfg *conf.Config, queryDir *persistence.QueryDirectory, onChange func(namespace, name string)) {
+	interval := cfg.QueriesDirectory.PollInterval
+	if queryDir == nil || interval <= 0 {
+		return
+	}
+
+	log.Info("watching queries directory", "interval", interval)
+	go queryDir.Watch(context.Background(), interval, onChange)
+}
+
+// registerAdminEndpoints adds handlers for administrative operations,
+// each one requiring an admin key with a scope over the tenant or
+// namespace it reads or writes, or over the audit log, and the
+// administration console files.
 func registerAdminEndpoints(adm *administrator, apiApp app) app {
-	apiApp.Handle(http.MethodGet, "/admin/tenant", adm.AllTenants)
-	apiApp.Handle(http.MethodGet, "/admin/tenant/{tenantName}/mapping", adm.TenantMappings)
-	apiApp.Handle(http.MethodPost, "/admin/tenant/{tenantName}/mapping/{resource}", adm.CreateResource)
-	apiApp.Handle(http.MethodPut, "/admin/tenant/{tenantName}/mapping/{resource}", adm.UpdateResource)
-
-	apiApp.Handle(http.MethodGet, "/admin/namespace", adm.AllNamespaces)
-	apiApp.Handle(http.MethodGet, "/admin/namespace/{namespace}/query", adm.NamespaceQueries)
-	apiApp.Handle(http.MethodGet, "/admin/namespace/{namespace}/query/{queryId}", adm.QueryRevisions)
-	apiApp.Handle(http.MethodGet, "/admin/namespace/{namespace}/query/{queryId}/revision/{revision}", adm.Query)
-	apiApp.Handle(http.MethodPatch, "/admin/namespace/{namespace}/query/{queryId}/revision/{revision}", adm.UpdateRevisionArchiving)
-	apiApp.Handle(http.MethodPatch, "/admin/namespace/{namespace}/query/{queryId}", adm.UpdateQueryArchiving)
-	apiApp.Handle(http.MethodPost, "/admin/namespace/{namespace}/query/{queryId}", adm.CreateQueryRevision)
+	auth := adm.auth
+
+	apiApp.Handle(http.MethodGet, "/admin/tenant", auth.authorize(tenantKind, "", readAction, adm.AllTenants))
+	apiApp.Handle(http.MethodGet, "/admin/tenant/{tenantName}/mapping", auth.authorize(tenantKind, "tenantName", readAction, adm.TenantMappings))
+	apiApp.Handle(http.MethodPost, "/admin/tenant/{tenantName}/mapping/{resource}", auth.authorize(tenantKind, "tenantName", writeAction, adm.CreateResource))
+	apiApp.Handle(http.MethodPut, "/admin/tenant/{tenantName}/mapping/{resource}", auth.authorize(tenantKind, "tenantName", writeAction, adm.UpdateResource))
+	apiApp.Handle(http.MethodDelete, "/admin/tenant/{tenantName}/mapping/{resource}", auth.authorize(tenantKind, "tenantName", writeAction, adm.DeleteResource))
+	apiApp.Handle(http.MethodPost, "/admin/tenant/{tenantName}", auth.authorize(tenantKind, "tenantName", writeAction, adm.CreateTenant))
+	apiApp.Handle(http.MethodDelete, "/admin/tenant/{tenantName}", auth.authorize(tenantKind, "tenantName", writeAction, adm.DeleteTenant))
+
+	apiApp.Handle(http.MethodGet, "/admin/namespace", auth.authorize(namespaceKind, "", readAction, adm.AllNamespaces))
+	apiApp.Handle(http.MethodGet, "/admin/namespace/{namespace}/query", auth.authorize(namespaceKind, "namespace", readAction, adm.NamespaceQueries))
+	apiApp.Handle(http.MethodGet, "/admin/namespace/{namespace}/query/{queryId}", auth.authorize(namespaceKind, "namespace", readAction, adm.QueryRevisions))
+	apiApp.Handle(http.MethodGet, "/admin/namespace/{namespace}/query/{queryId}/revision/{revision}", auth.authorize(namespaceKind, "namespace", readAction, adm.Query))
+	apiApp.Handle(http.MethodGet, "/admin/namespace/{namespace}/query/{queryId}/diff", auth.authorize(namespaceKind, "namespace", readAction, adm.QueryDiff))
+	apiApp.Handle(http.MethodPatch, "/admin/namespace/{namespace}/query/{queryId}/revision/{revision}", auth.authorize(namespaceKind, "namespace", writeAction, adm.UpdateRevisionArchiving))
+	apiApp.Handle(http.MethodPatch, "/admin/namespace/{namespace}/query/{queryId}", auth.authorize(namespaceKind, "namespace", writeAction, adm.UpdateQueryArchiving))
+	apiApp.Handle(http.MethodPost, "/admin/namespace/{namespace}/query/{queryId}", auth.authorize(namespaceKind, "namespace", writeAction, adm.CreateQueryRevision))
+	apiApp.Handle(http.MethodPost, "/admin/namespace/{namespace}/query/{queryId}/move", auth.authorize(namespaceKind, "namespace", writeAction, adm.MoveQuery))
+	apiApp.Handle(http.MethodPost, "/admin/namespace/{namespace}/rename", auth.authorize(namespaceKind, "namespace", writeAction, adm.RenameNamespace))
+	apiApp.Handle(http.MethodDelete, "/admin/namespace/{namespace}", auth.authorize(namespaceKind, "namespace", writeAction, adm.DeleteNamespace))
+
+	apiApp.Handle(http.MethodGet, "/admin/audit", auth.authorize(auditKind, "", readAction, adm.AuditEvents))
+
+	apiApp.Handle(http.MethodGet, "/admin/export", auth.authorizeAny(readAction, adm.ExportArchive))
+	apiApp.Handle(http.MethodPost, "/admin/import", auth.authorizeAny(writeAction, adm.ImportArchive))
+	apiApp.Handle(http.MethodPost, "/admin/validate", auth.authorizeAny(readAction, adm.ValidateRevision))
+
+	apiApp.Handle(http.MethodGet, "/admin/console", redirectToConsole)
+	apiApp.Handle(http.MethodGet, consoleRoot+"{filepath:*}", serveConsole)
 
 	return apiApp
 }
@@ -149,3 +385,61 @@ func Debug(log restql.Logger) fasthttp.RequestHandler {
 
 	return app.RequestHandler()
 }
+
+func requestIDHeader(cfg *conf.Config) string {
+	requestID := cfg.HTTP.Server.Middlewares.RequestID
+	if !requestID.Enable {
+		return ""
+	}
+
+	return requestID.Header
+}
+
+func databaseOptions(cfg *conf.Config) persistence.DatabaseOptions {
+	return persistence.DatabaseOptions{
+		Disabled:   cfg.Plugins.DisableDatabase,
+		SQLitePath: cfg.Database.SQLite.Path,
+		Timeout:    time.Duration(cfg.Database.Timeout) * time.Millisecond,
+	}
+}
+
+const (
+	recordMode = "record"
+	replayMode = "replay"
+)
+
+// withRecording wraps the upstream client to record its calls
+// or replaces it to serve recorded calls, as configured.
+func withRecording(log restql.Logger, cfg *conf.Config, client domain.HTTPClient) (domain.HTTPClient, error) {
+	recordingCfg := cfg.HTTP.Client.Recording
+	if recordingCfg.Mode == "" {
+		return client, nil
+	}
+
+	matching, err := recording.ParseMatching(recordingCfg.Matching)
+	if err != nil {
+		return nil, err
+	}
+	options := recording.Options{Matching: matching, IgnoreHeaders: recordingCfg.IgnoreHeaders}
+
+	switch recordingCfg.Mode {
+	case recordMode:
+		recorder, err := recording.NewRecorder(log, client, recordingCfg.Dir, options)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Warn("recording upstream calls", "dir", recordingCfg.Dir)
+		return recorder, nil
+	case replayMode:
+		replayer, err := recording.NewReplayer(log, recordingCfg.Dir, options)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Warn("replaying recorded upstream calls", "dir", recordingCfg.Dir)
+		return replayer, nil
+	default:
+		return nil, errors.Errorf("unknown recording mode: %s", recordingCfg.Mode)
+	}
+}
@@ -0,0 +1,180 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+	"github.com/valyala/fasthttp"
+)
+
+// Types of the events sent when streaming a query result.
+const (
+	statementEvent = "statement"
+	summaryEvent   = "summary"
+	errorEvent     = "error"
+)
+
+// StreamEvent represents the client format of
+// a query result sent in streaming mode.
+type StreamEvent struct {
+	Type     string            `json:"type"`
+	Resource string            `json:"resource,omitempty"`
+	Details  interface{}       `json:"details,omitempty"`
+	Result   interface{}       `json:"result,omitempty"`
+	Status   int               `json:"status,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+type streamFormat struct {
+	contentType string
+	write       func(w *bufio.Writer, eventType string, data []byte) error
+}
+
+var streamFormats = []streamFormat{
+	{contentType: "text/event-stream", write: writeServerSentEvent},
+	{contentType: "application/x-ndjson", write: writeNewlineDelimitedJSON},
+}
+
+func writeServerSentEvent(w *bufio.Writer, eventType string, data []byte) error {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, data)
+	return err
+}
+
+func writeNewlineDelimitedJSON(w *bufio.Writer, _ string, data []byte) error {
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+
+	return w.WriteByte('\n')
+}
+
+// negotiateStreamFormat returns the streaming format asked by
+// the client in the Accept header, if there is any.
+func negotiateStreamFormat(ctx *fasthttp.RequestCtx) (streamFormat, bool) {
+	accept := string(ctx.Request.Header.Peek("Accept"))
+	if accept == "" {
+		return streamFormat{}, false
+	}
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(mediaRange, ";", 2)[0])
+
+		for _, f := range streamFormats {
+			if strings.EqualFold(mediaType, f.contentType) {
+				return f, true
+			}
+		}
+	}
+
+	return streamFormat{}, false
+}
+
+type streamQueryFn func(ctx context.Context, emit engine.EmitFunc) (engine.Result, error)
+
+// respondStream executes the query in streaming mode, writing each
+// statement result as soon as it is done, followed by a summary with
+// the status code and headers that would be returned in regular mode.
+//
+// Since the response status is sent before the query execution,
+// failures are reported as an error event. The query runs after the
+// handler returns, hence on a context detached from the native one,
+// keeping its values, deadline and the client connection cancellation.
+func respondStream(reqCtx *fasthttp.RequestCtx, log restql.Logger, format streamFormat, debug bool, toStatusCode map[error]int, run streamQueryFn) error {
+	reqCtx.Response.Header.SetContentType(format.contentType)
+	reqCtx.Response.Header.Set("Cache-Control", "no-cache")
+	reqCtx.Response.SetStatusCode(http.StatusOK)
+
+	ctx, cancel := middleware.DetachedContext(reqCtx)
+
+	reqCtx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		ctx = restql.WithLogger(ctx, log)
+		sw := &streamWriter{log: log, w: w, format: format, cancel: cancel}
+		defer sw.Close()
+
+		result, err := run(ctx, func(statements []engine.StatementResult) {
+			sw.WriteStatements(statements, debug)
+		})
+		if err != nil {
+			log.Error("failed to evaluate streamed query", err)
+			sw.Write(StreamEvent{Type: errorEvent, Status: findStatusCode(toStatusCode, err), Error: err.Error()})
+			return
+		}
+
+		resources, _ := makeResources(result)
+		sw.Write(StreamEvent{
+			Type:    summaryEvent,
+			Status:  calculateStatusCode(result),
+			Headers: makeHeaders(resources),
+		})
+	})
+
+	return nil
+}
+
+// streamWriter serializes the events written from the query
+// execution process, cancelling it when the client goes away.
+type streamWriter struct {
+	log    restql.Logger
+	w      *bufio.Writer
+	format streamFormat
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func (sw *streamWriter) WriteStatements(statements []engine.StatementResult, debug bool) {
+	for _, s := range statements {
+		r, err := parseResource(s.Value(), debug)
+		if err != nil {
+			sw.log.Error("failed to parse streamed statement result", err, "resource", s.Name)
+			continue
+		}
+
+		sw.Write(StreamEvent{Type: statementEvent, Resource: s.Name, Details: r.Details, Result: r.Result})
+	}
+}
+
+func (sw *streamWriter) Write(event StreamEvent) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if sw.closed {
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		sw.log.Error("failed to marshal stream event", err, "type", event.Type)
+		return
+	}
+
+	err = sw.format.write(sw.w, event.Type, data)
+	if err == nil {
+		err = sw.w.Flush()
+	}
+
+	if err != nil {
+		sw.log.Debug("failed to write stream event, cancelling query", "error", err)
+		sw.closed = true
+		sw.cancel()
+	}
+}
+
+func (sw *streamWriter) Close() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	sw.closed = true
+}
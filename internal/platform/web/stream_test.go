@@ -0,0 +1,101 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestNegotiateStreamFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		accept        string
+		expected      string
+		expectedFound bool
+	}{
+		{"should not stream without accept header", "", "", false},
+		{"should not stream for json", "application/json", "", false},
+		{"should stream server sent events", "text/event-stream", "text/event-stream", true},
+		{"should stream ndjson among other media types", "application/json;q=0.9, application/x-ndjson", "application/x-ndjson", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx fasthttp.RequestCtx
+			if tt.accept != "" {
+				ctx.Request.Header.Set("Accept", tt.accept)
+			}
+
+			format, found := negotiateStreamFormat(&ctx)
+
+			test.Equal(t, found, tt.expectedFound)
+			test.Equal(t, format.contentType, tt.expected)
+		})
+	}
+}
+
+func TestRespondStream(t *testing.T) {
+	hero := restql.DoneResource{
+		Status:       200,
+		Success:      true,
+		CacheControl: restql.ResourceCacheControl{MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 60}},
+		ResponseBody: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(`{"name":"Batman"}`)),
+	}
+
+	t.Run("should write statements and summary as server sent events", func(t *testing.T) {
+		var ctx fasthttp.RequestCtx
+		format, _ := negotiateStreamFormat(acceptCtx("text/event-stream"))
+
+		err := respondStream(&ctx, test.NoOpLogger, format, false, errToStatusCode, func(ctx context.Context, emit engine.EmitFunc) (engine.Result, error) {
+			statements := []engine.StatementResult{{Name: "hero", Done: hero}}
+			emit(statements)
+			return engine.Result{Statements: statements}, nil
+		})
+		test.VerifyError(t, err)
+
+		expected := "event: statement\n" +
+			`data: {"type":"statement","resource":"hero","details":{"status":200,"success":true,"metadata":{}},"result":{"name":"Batman"}}` + "\n\n" +
+			"event: summary\n" +
+			`data: {"type":"summary","status":200,"headers":{"Cache-Control":"max-age=60"}}` + "\n\n"
+
+		test.Equal(t, string(ctx.Response.Header.ContentType()), "text/event-stream")
+		test.Equal(t, string(ctx.Response.Body()), expected)
+	})
+
+	t.Run("should write error as ndjson", func(t *testing.T) {
+		var ctx fasthttp.RequestCtx
+		format, _ := negotiateStreamFormat(acceptCtx("application/x-ndjson"))
+
+		err := respondStream(&ctx, test.NoOpLogger, format, false, errToStatusCode, func(ctx context.Context, emit engine.EmitFunc) (engine.Result, error) {
+			return engine.Result{}, runner.ErrMaxQueryDenied
+		})
+		test.VerifyError(t, err)
+
+		expected := `{"type":"error","status":507,"error":"` + runner.ErrMaxQueryDenied.Error() + `"}` + "\n"
+
+		test.Equal(t, string(ctx.Response.Body()), expected)
+	})
+
+	t.Run("should map unknown errors to internal server error", func(t *testing.T) {
+		var ctx fasthttp.RequestCtx
+		format, _ := negotiateStreamFormat(acceptCtx("application/x-ndjson"))
+
+		_ = respondStream(&ctx, test.NoOpLogger, format, false, errToStatusCode, func(ctx context.Context, emit engine.EmitFunc) (engine.Result, error) {
+			return engine.Result{}, errors.New("boom")
+		})
+
+		test.Equal(t, string(ctx.Response.Body()), `{"type":"error","status":500,"error":"boom"}`+"\n")
+	})
+}
+
+func acceptCtx(accept string) *fasthttp.RequestCtx {
+	var ctx fasthttp.RequestCtx
+	ctx.Request.Header.Set("Accept", accept)
+	return &ctx
+}
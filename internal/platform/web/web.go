@@ -1,14 +1,24 @@
 package web
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/encoder"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/fasthttp/router"
+	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
 )
 
+var errNotAcceptable = errors.New("not acceptable")
+
 type appOptions struct {
 	MiddlewareDecorator *middleware.Decorator
+	Encoders            *encoder.Registry
+	ProblemDetails      bool
+	RequestIDHeader     string
 }
 
 type handler func(ctx *fasthttp.RequestCtx) error
@@ -28,6 +38,14 @@ func newApp(log restql.Logger, o appOptions) app {
 
 func (a app) Handle(method, url string, handler handler) {
 	fn := func(ctx *fasthttp.RequestCtx) {
+		if a.options.ProblemDetails {
+			withProblemDetails(ctx, problemDetailsOptions{requestIDHeader: a.options.RequestIDHeader})
+		}
+
+		if !a.negotiateEncoder(ctx) {
+			return
+		}
+
 		err := handler(ctx)
 
 		if err != nil {
@@ -42,6 +60,35 @@ func (a app) Handle(method, url string, handler handler) {
 	a.router.Handle(method, url, fn)
 }
 
+// negotiateEncoder selects the response encoder based on the
+// Accept header, responding with 406 Not Acceptable when the
+// client does not accept any of the supported media types.
+// Streaming media types are handled by the query endpoints.
+func (a app) negotiateEncoder(ctx *fasthttp.RequestCtx) bool {
+	encoders := a.options.Encoders
+	if encoders == nil {
+		return true
+	}
+
+	accept := string(ctx.Request.Header.Peek("Accept"))
+	e, ok := encoders.Negotiate(accept)
+	if ok {
+		withEncoder(ctx, e)
+		return true
+	}
+
+	if _, ok := negotiateStreamFormat(ctx); ok {
+		return true
+	}
+
+	err := fmt.Errorf("%w: supported media types are %s", errNotAcceptable, strings.Join(encoders.MediaTypes(), ", "))
+	if err := RespondError(ctx, err, errToStatusCode); err != nil {
+		a.log.Error("failed to send error response", err)
+	}
+
+	return false
+}
+
 func (a app) RequestHandler() fasthttp.RequestHandler {
 	h := a.router.Handler
 	md := a.options.MiddlewareDecorator
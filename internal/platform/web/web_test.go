@@ -0,0 +1,136 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser/ast"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/encoder"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestApp_ContentNegotiation(t *testing.T) {
+	encoders := encoder.New(test.NoOpLogger, nil)
+	a := newApp(test.NoOpLogger, appOptions{Encoders: &encoders})
+	a.Handle(http.MethodGet, "/hero", func(ctx *fasthttp.RequestCtx) error {
+		return Respond(ctx, map[string]interface{}{"name": "Batman"}, http.StatusOK, nil)
+	})
+	h := a.RequestHandler()
+
+	tests := []struct {
+		name                string
+		accept              string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{"should respond json by default", "", 200, "application/json; charset=utf-8", `{"name":"Batman"}` + "\n"},
+		{"should respond yaml", "application/yaml", 200, "application/yaml", "name: Batman\n"},
+		{"should respond not acceptable", "text/html", 406, "application/json; charset=utf-8", `{"error":"not acceptable: supported media types are application/json, application/msgpack, application/x-msgpack, application/vnd.msgpack, application/cbor, application/yaml, application/x-yaml, text/yaml"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx fasthttp.RequestCtx
+			ctx.Request.Header.SetMethod(http.MethodGet)
+			ctx.Request.SetRequestURI("/hero")
+			if tt.accept != "" {
+				ctx.Request.Header.Set("Accept", tt.accept)
+			}
+
+			h(&ctx)
+
+			test.Equal(t, ctx.Response.StatusCode(), tt.expectedStatus)
+			test.Equal(t, string(ctx.Response.Header.ContentType()), tt.expectedContentType)
+			test.Equal(t, string(ctx.Response.Body()), tt.expectedBody)
+		})
+	}
+}
+
+func TestApp_ProblemDetails(t *testing.T) {
+	syntaxErr := &ast.SyntaxError{Line: 2, Column: 15, Offset: 24, Err: errors.New("no match found")}
+	handlers := map[string]handler{
+		"/parser": func(ctx *fasthttp.RequestCtx) error {
+			return parser.WrapSyntaxError(fmt.Errorf("%w: invalid query syntax %s", eval.ErrParser, syntaxErr), syntaxErr)
+		},
+		"/limit": func(ctx *fasthttp.RequestCtx) error {
+			return runner.ErrMaxQueryDenied
+		},
+		"/unknown": func(ctx *fasthttp.RequestCtx) error {
+			return errors.New("something went wrong")
+		},
+	}
+
+	newHandler := func(o appOptions) fasthttp.RequestHandler {
+		a := newApp(test.NoOpLogger, o)
+		for path, h := range handlers {
+			a.Handle(http.MethodGet, path, h)
+		}
+		return a.RequestHandler()
+	}
+
+	tests := []struct {
+		name                string
+		options             appOptions
+		path                string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			"should respond problem with parser error position",
+			appOptions{ProblemDetails: true, RequestIDHeader: "X-TID"},
+			"/parser",
+			500,
+			"application/problem+json",
+			`{"type":"urn:restql:problem:parser","title":"Invalid query syntax","status":500,"detail":"parsing error: invalid query syntax no match found","instance":"/parser","requestId":"abc","position":{"line":2,"column":15,"offset":24}}` + "\n",
+		},
+		{
+			"should respond problem for limiter denial",
+			appOptions{ProblemDetails: true},
+			"/limit",
+			507,
+			"application/problem+json",
+			`{"type":"urn:restql:problem:max-query-denied","title":"Maximum concurrent queries reached","status":507,"detail":"max concurrent query reached: query execution denied","instance":"/limit"}` + "\n",
+		},
+		{
+			"should respond blank problem for unknown error",
+			appOptions{ProblemDetails: true},
+			"/unknown",
+			500,
+			"application/problem+json",
+			`{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"something went wrong","instance":"/unknown"}` + "\n",
+		},
+		{
+			"should respond legacy error when disabled",
+			appOptions{},
+			"/limit",
+			507,
+			"application/json; charset=utf-8",
+			`{"error":"max concurrent query reached: query execution denied"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler(tt.options)
+
+			var ctx fasthttp.RequestCtx
+			ctx.Request.Header.SetMethod(http.MethodGet)
+			ctx.Request.Header.Set("X-TID", "abc")
+			ctx.Request.SetRequestURI(tt.path)
+
+			h(&ctx)
+
+			test.Equal(t, ctx.Response.StatusCode(), tt.expectedStatus)
+			test.Equal(t, string(ctx.Response.Header.ContentType()), tt.expectedContentType)
+			test.Equal(t, string(ctx.Response.Body()), tt.expectedBody)
+		})
+	}
+}
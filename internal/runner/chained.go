@@ -7,6 +7,7 @@ import (
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/pkg/errors"
 	"math"
+	"sort"
 	"strings"
 )
 
@@ -28,6 +29,69 @@ func ResolveChainedValues(resources domain.Resources, doneResources domain.Resou
 	return resources
 }
 
+// GetChainedParams returns the names of the parameters
+// with chain values for each statement in the collection.
+func GetChainedParams(resources domain.Resources) map[domain.ResourceID][]string {
+	result := make(map[domain.ResourceID][]string)
+	for resourceID, stmt := range resources {
+		names := make(map[string]struct{})
+		collectChainedParams(stmt, names)
+		if len(names) == 0 {
+			continue
+		}
+
+		params := make([]string, 0, len(names))
+		for name := range names {
+			params = append(params, name)
+		}
+		sort.Strings(params)
+
+		result[resourceID] = params
+	}
+
+	return result
+}
+
+func collectChainedParams(stmt interface{}, names map[string]struct{}) {
+	switch stmt := stmt.(type) {
+	case domain.Statement:
+		for paramName, value := range stmt.With.Values {
+			if hasChain(value) {
+				names[paramName] = struct{}{}
+			}
+		}
+	case []interface{}:
+		for _, s := range stmt {
+			collectChainedParams(s, names)
+		}
+	}
+}
+
+func hasChain(value interface{}) bool {
+	switch value := value.(type) {
+	case domain.Chain:
+		return true
+	case domain.Function:
+		return hasChain(value.Target())
+	case []interface{}:
+		for _, v := range value {
+			if hasChain(v) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		for _, v := range value {
+			if hasChain(v) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
 func resolveStatement(stmt interface{}, doneResources domain.Resources) interface{} {
 	switch stmt := stmt.(type) {
 	case domain.Statement:
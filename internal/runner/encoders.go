@@ -7,6 +7,7 @@ import (
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
 )
 
 // ApplyEncoders transform parameter values with encoder functions applied
@@ -45,6 +46,8 @@ func applyEncoderToBody(log restql.Logger, body interface{}) interface{} {
 		return applyEncoderToBody(log, body.Target())
 	case domain.Flatten:
 		return applyFlattenEncoder(log, applyEncoderToBody(log, body.Target()))
+	case domain.CustomFunction:
+		return applyCustomFunction(log, body, applyEncoderToBody(log, body.Target()))
 	case domain.Function:
 		return body.Map(func(target interface{}) interface{} {
 			return applyEncoderToBody(log, target)
@@ -77,6 +80,13 @@ func applyEncoderToValue(log restql.Logger, value interface{}) interface{} {
 		}
 
 		return applyFlattenEncoder(log, applyEncoderToValue(log, value.Target()))
+	case domain.CustomFunction:
+		target := value.Target()
+		if _, ok := target.(domain.Chain); ok {
+			return value
+		}
+
+		return applyCustomFunction(log, value, applyEncoderToValue(log, target))
 	case domain.Function:
 		return value.Map(func(target interface{}) interface{} {
 			return applyEncoderToValue(log, target)
@@ -121,6 +131,23 @@ func applyFlattenEncoder(log restql.Logger, value interface{}) interface{} {
 	return value
 }
 
+// applyCustomFunction calls the plugin function on the value,
+// which is kept unchanged if the function fails.
+func applyCustomFunction(log restql.Logger, fn domain.CustomFunction, value interface{}) interface{} {
+	result, err := fn.Call(value)
+	if errors.Is(err, domain.ErrFunctionPanicked) {
+		log.Error("plugin function produced a panic", err, "function", fn.Fn.Name)
+		return value
+	}
+
+	if err != nil {
+		log.Warn("failed to apply function", "function", fn.Fn.Name, "value", value, "error", err)
+		return value
+	}
+
+	return result
+}
+
 func flatten(ii []interface{}) []interface{} {
 	var res []interface{}
 	for _, i := range ii {
@@ -2,9 +2,11 @@ package runner
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 )
 
@@ -14,28 +16,50 @@ import (
 type Executor struct {
 	client          domain.HTTPClient
 	log             restql.Logger
-	resourceTimeout time.Duration
+	lifecycle       plugins.Lifecycle
+	resourceTimeout *atomic.Int64
 	forwardPrefix   string
 }
 
 // NewExecutor constructs an instance of Executor.
-func NewExecutor(log restql.Logger, client domain.HTTPClient, resourceTimeout time.Duration, forwardPrefix string) Executor {
-	return Executor{client: client, log: log, resourceTimeout: resourceTimeout, forwardPrefix: forwardPrefix}
+func NewExecutor(log restql.Logger, client domain.HTTPClient, lifecycle plugins.Lifecycle, resourceTimeout time.Duration, forwardPrefix string) Executor {
+	e := Executor{client: client, log: log, lifecycle: lifecycle, resourceTimeout: &atomic.Int64{}, forwardPrefix: forwardPrefix}
+	e.SetResourceTimeout(resourceTimeout)
+
+	return e
+}
+
+// SetResourceTimeout changes the default timeout of the upstream
+// requests, including the ones of running queries that did not
+// start yet. The change is shared by every copy of the Executor.
+func (e Executor) SetResourceTimeout(timeout time.Duration) {
+	e.resourceTimeout.Store(int64(timeout))
+}
+
+// StatementInfo holds the details of a statement
+// execution that are not part of its definition.
+type StatementInfo struct {
+	MultiplexIndex []int
+	ChainedParams  []string
 }
 
 // DoStatement process a single statement into a result by executing the relevant HTTP calls to the upstream dependency.
-func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, queryCtx restql.QueryContext) restql.DoneResource {
+func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, info StatementInfo, queryCtx restql.QueryContext) restql.DoneResource {
 	log := restql.GetLogger(ctx)
 
 	drOptions := DoneResourceOptions{
 		IgnoreErrors: statement.IgnoreErrors,
+		Criticality:  statement.Criticality,
 		MaxAge:       statement.CacheControl.MaxAge,
 		SMaxAge:      statement.CacheControl.SMaxAge,
 	}
 
+	descriptor := newStatementDescriptor(statement, info)
+
 	if !statement.DependsOn.Resolved {
 		failedDependsOnResponse := NewNewDependsOnUnresolvedResponse(log, statement, drOptions)
 		log.Debug("request execution skipped due to unresolved dependency", "resource", statement.Resource, "method", statement.Method)
+		e.lifecycle.OnStatementSkipped(ctx, descriptor, restql.DependsOnUnresolved)
 		return failedDependsOnResponse
 	}
 
@@ -43,17 +67,30 @@ func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, q
 	if len(emptyChainedParams) > 0 {
 		emptyChainedResponse := NewEmptyChainedResponse(log, emptyChainedParams, drOptions)
 		log.Debug("request execution skipped due to empty chained parameters", "resource", statement.Resource, "method", statement.Method)
+		e.lifecycle.OnStatementSkipped(ctx, descriptor, restql.EmptyChainedParams)
 		return emptyChainedResponse
 	}
 
-	request := MakeRequest(e.resourceTimeout, e.forwardPrefix, statement, queryCtx)
+	if queryCtx.Mappings[statement.Resource].IsGraphQL() {
+		if _, ok := graphQLBodyVariables(statement); !ok {
+			invalidBodyResponse := NewInvalidGraphQLBodyResponse(log, drOptions)
+			log.Debug("request execution skipped due to graphql body not being an object", "resource", statement.Resource, "method", statement.Method)
+			e.lifecycle.OnStatementSkipped(ctx, descriptor, restql.InvalidGraphQLBody)
+			return invalidBodyResponse
+		}
+	}
+
+	statementCtx := e.lifecycle.BeforeStatement(ctx, descriptor)
+
+	request := MakeRequest(time.Duration(e.resourceTimeout.Load()), e.forwardPrefix, statement, queryCtx)
 
 	log.Debug("executing request for statement", "resource", statement.Resource, "method", statement.Method, "request", request)
 
-	response, err := e.client.Do(ctx, request)
+	response, err := e.client.Do(statementCtx, request)
 	if err != nil {
 		errorResponse := NewErrorResponse(log, err, request, response, drOptions)
 		log.Debug("request execution failed", "error", err, "resource", statement.Resource, "method", statement.Method, "response", errorResponse)
+		e.lifecycle.AfterStatement(statementCtx, descriptor, errorResponse)
 		return errorResponse
 	}
 
@@ -61,5 +98,64 @@ func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, q
 
 	log.Debug("request execution done", "resource", statement.Resource, "method", statement.Method, "response", dr)
 
+	e.lifecycle.AfterStatement(statementCtx, descriptor, dr)
+
 	return dr
 }
+
+// newStatementDescriptor builds the public description
+// of the statement given to the lifecycle plugins.
+func newStatementDescriptor(statement domain.Statement, info StatementInfo) restql.Statement {
+	params := make(map[string]interface{}, len(statement.With.Values))
+	for key, value := range statement.With.Values {
+		params[key] = plainValue(value)
+	}
+
+	var multiplexIndex []int
+	if len(info.MultiplexIndex) > 0 {
+		multiplexIndex = make([]int, len(info.MultiplexIndex))
+		copy(multiplexIndex, info.MultiplexIndex)
+	}
+
+	var chainedParams []string
+	if len(info.ChainedParams) > 0 {
+		chainedParams = make([]string, len(info.ChainedParams))
+		copy(chainedParams, info.ChainedParams)
+	}
+
+	return restql.Statement{
+		Name:           string(domain.NewResourceID(statement)),
+		Method:         statement.Method,
+		Resource:       statement.Resource,
+		Alias:          statement.Alias,
+		DependsOn:      statement.DependsOn.Target,
+		MultiplexIndex: multiplexIndex,
+		Params:         params,
+		ChainedParams:  chainedParams,
+		IgnoreErrors:   statement.IgnoreErrors,
+		Criticality:    statement.Criticality,
+	}
+}
+
+// plainValue copies the value removing the
+// internal wrappers, like functions.
+func plainValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case domain.Function:
+		return plainValue(value.Target())
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			m[k] = plainValue(v)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(value))
+		for i, v := range value {
+			l[i] = plainValue(v)
+		}
+		return l
+	default:
+		return value
+	}
+}
@@ -0,0 +1,179 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+// statementEvent is a statement hook call recorded by statementRecorder.
+type statementEvent struct {
+	Hook      string
+	Statement restql.Statement
+	Status    int
+	Reason    restql.SkipReason
+}
+
+type statementRecorder struct {
+	mu     sync.Mutex
+	events []statementEvent
+}
+
+func (sr *statementRecorder) record(event statementEvent) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.events = append(sr.events, event)
+}
+
+func (sr *statementRecorder) sortedEvents() []statementEvent {
+	events := append([]statementEvent{}, sr.events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i].Statement, events[j].Statement
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if len(a.MultiplexIndex) > 0 && len(b.MultiplexIndex) > 0 && a.MultiplexIndex[0] != b.MultiplexIndex[0] {
+			return a.MultiplexIndex[0] < b.MultiplexIndex[0]
+		}
+		return events[i].Hook > events[j].Hook
+	})
+	return events
+}
+
+func (sr *statementRecorder) Name() string { return "recorder" }
+func (sr *statementRecorder) BeforeTransaction(ctx context.Context, tr restql.TransactionRequest) context.Context {
+	return ctx
+}
+func (sr *statementRecorder) AfterTransaction(ctx context.Context, tr restql.TransactionResponse) context.Context {
+	return ctx
+}
+func (sr *statementRecorder) BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) context.Context {
+	return ctx
+}
+func (sr *statementRecorder) AfterQuery(ctx context.Context, query string, result map[string]interface{}) context.Context {
+	return ctx
+}
+func (sr *statementRecorder) BeforeRequest(ctx context.Context, request restql.HTTPRequest) context.Context {
+	return ctx
+}
+func (sr *statementRecorder) AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) context.Context {
+	return ctx
+}
+func (sr *statementRecorder) BeforeStatement(ctx context.Context, statement restql.Statement) context.Context {
+	sr.record(statementEvent{Hook: "BeforeStatement", Statement: statement})
+	return ctx
+}
+func (sr *statementRecorder) AfterStatement(ctx context.Context, statement restql.Statement, result restql.DoneResource) context.Context {
+	sr.record(statementEvent{Hook: "AfterStatement", Statement: statement, Status: result.Status})
+	return ctx
+}
+func (sr *statementRecorder) OnStatementSkipped(ctx context.Context, statement restql.Statement, reason restql.SkipReason) context.Context {
+	sr.record(statementEvent{Hook: "OnStatementSkipped", Statement: statement, Reason: reason})
+	return ctx
+}
+func (sr *statementRecorder) OnQueryError(ctx context.Context, query string, err error) context.Context {
+	return ctx
+}
+
+type statusClient struct {
+	responses map[string]string
+}
+
+func (s statusClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	body, found := s.responses[request.Path]
+	if !found {
+		return restql.HTTPResponse{StatusCode: 500, Body: restql.NewResponseBodyFromValue(test.NoOpLogger, nil)}, errors.New("upstream failed")
+	}
+
+	return restql.HTTPResponse{StatusCode: 200, Body: restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(body))}, nil
+}
+
+func TestExecutor_StatementHooks(t *testing.T) {
+	client := statusClient{responses: map[string]string{
+		"/hero":     `{"name": "Batman", "sidekickIds": [1, 2]}`,
+		"/sidekick": `{"name": "Robin"}`,
+	}}
+	recorder := &statementRecorder{}
+	lifecycle := plugins.NewLifecycle(test.NoOpLogger, []restql.LifecyclePlugin{recorder})
+
+	executor := runner.NewExecutor(test.NoOpLogger, client, lifecycle, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{GlobalQueryTimeout: time.Second})
+
+	query := domain.Query{Statements: []domain.Statement{
+		{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"name": domain.Base64{Value: "batman"}}}},
+		{Method: domain.FromMethod, Resource: "sidekick", Alias: "partner", With: domain.Params{Values: map[string]interface{}{"id": domain.Chain{"hero", "sidekickIds"}}}},
+		{Method: domain.FromMethod, Resource: "villain", DependsOn: domain.DependsOn{Target: "hero"}},
+		{Method: domain.FromMethod, Resource: "weapon", DependsOn: domain.DependsOn{Target: "villain"}},
+		{Method: domain.FromMethod, Resource: "city", With: domain.Params{Values: map[string]interface{}{"villain": domain.Chain{"villain", "city"}}}},
+	}}
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{
+		"hero":     mapping(t, "http://hero.io/hero"),
+		"sidekick": mapping(t, "http://hero.io/sidekick"),
+		"villain":  mapping(t, "http://hero.io/villain"),
+		"weapon":   mapping(t, "http://hero.io/weapon"),
+		"city":     mapping(t, "http://hero.io/city"),
+	}}
+
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+	_, err := r.ExecuteQuery(ctx, query, queryCtx)
+	test.VerifyError(t, err)
+
+	hero := restql.Statement{Name: "hero", Method: "from", Resource: "hero", Params: map[string]interface{}{"name": "YmF0bWFu"}}
+	partner := func(index int, id interface{}) restql.Statement {
+		return restql.Statement{
+			Name:           "partner",
+			Method:         "from",
+			Resource:       "sidekick",
+			Alias:          "partner",
+			MultiplexIndex: []int{index},
+			Params:         map[string]interface{}{"id": id},
+			ChainedParams:  []string{"id"},
+		}
+	}
+	villain := restql.Statement{Name: "villain", Method: "from", Resource: "villain", DependsOn: "hero", Params: map[string]interface{}{}}
+	weapon := restql.Statement{Name: "weapon", Method: "from", Resource: "weapon", DependsOn: "villain", Params: map[string]interface{}{}}
+	city := restql.Statement{Name: "city", Method: "from", Resource: "city", Params: map[string]interface{}{"villain": runner.EmptyChained}, ChainedParams: []string{"villain"}}
+
+	test.Equal(t, recorder.sortedEvents(), []statementEvent{
+		{Hook: "OnStatementSkipped", Statement: city, Reason: restql.EmptyChainedParams},
+		{Hook: "BeforeStatement", Statement: hero},
+		{Hook: "AfterStatement", Statement: hero, Status: 200},
+		{Hook: "BeforeStatement", Statement: partner(0, float64(1))},
+		{Hook: "AfterStatement", Statement: partner(0, float64(1)), Status: 200},
+		{Hook: "BeforeStatement", Statement: partner(1, float64(2))},
+		{Hook: "AfterStatement", Statement: partner(1, float64(2)), Status: 200},
+		{Hook: "BeforeStatement", Statement: villain},
+		{Hook: "AfterStatement", Statement: villain, Status: 500},
+		{Hook: "OnStatementSkipped", Statement: weapon, Reason: restql.DependsOnUnresolved},
+	})
+}
+
+func TestExecutor_InvalidGraphQLBody(t *testing.T) {
+	client := statusClient{responses: map[string]string{"/graphql": `{"data": {}}`}}
+	recorder := &statementRecorder{}
+	lifecycle := plugins.NewLifecycle(test.NoOpLogger, []restql.LifecyclePlugin{recorder})
+	executor := runner.NewExecutor(test.NoOpLogger, client, lifecycle, time.Second, "")
+
+	statement := domain.Statement{Method: domain.ToMethod, Resource: "hero", DependsOn: domain.DependsOn{Resolved: true}, With: domain.Params{
+		Body:   []interface{}{"batman"},
+		Values: map[string]interface{}{"query": "mutation ($name: String!) { save(name: $name) { id } }"},
+	}}
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "graphql://hero.io/graphql")}}
+
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+	got := executor.DoStatement(ctx, statement, runner.StatementInfo{}, queryCtx)
+
+	test.Equal(t, got.Status, 400)
+	test.Equal(t, got.Success, false)
+	test.Equal(t, len(recorder.sortedEvents()), 1)
+	test.Equal(t, recorder.sortedEvents()[0].Reason, restql.InvalidGraphQLBody)
+}
@@ -47,6 +47,13 @@ func MakeRequest(defaultResourceTimeout time.Duration, forwardPrefix string, sta
 		Timeout: timeout,
 	}
 
+	if mapping.IsGraphQL() {
+		req.Method = http.MethodPost
+		req.Query = mapping.QueryWithParams(statement.With.Values)
+		req.Body = makeGraphQLBody(statement, mapping)
+		return req
+	}
+
 	if statement.Method == domain.ToMethod || statement.Method == domain.UpdateMethod || statement.Method == domain.IntoMethod {
 		req.Body = makeBody(statement, mapping)
 	}
@@ -54,6 +61,61 @@ func MakeRequest(defaultResourceTimeout time.Duration, forwardPrefix string, sta
 	return req
 }
 
+// Parameters with special meaning on GraphQL resources.
+// All the others are sent as the operation variables.
+const (
+	graphQLDocumentParam  = "query"
+	graphQLOperationParam = "operationName"
+)
+
+// makeGraphQLBody builds the operation from the statement, where
+// the body entries and the parameters, with functions unwrapped,
+// are the variables. Parameters take precedence over the body.
+func makeGraphQLBody(statement domain.Statement, mapping restql.Mapping) restql.Body {
+	body := map[string]interface{}{}
+	variables := make(map[string]interface{})
+
+	if bodyVariables, ok := graphQLBodyVariables(statement); ok {
+		for key, value := range bodyVariables {
+			variables[key] = value
+		}
+	}
+
+	for key, value := range statement.With.Values {
+		if mapping.IsPathParam(key) || mapping.IsQueryParam(key) {
+			continue
+		}
+
+		value = plainValue(value)
+
+		switch key {
+		case graphQLDocumentParam, graphQLOperationParam:
+			body[key] = value
+		default:
+			if value == nil || isPrimitiveValue(value) {
+				variables[key] = value
+			}
+		}
+	}
+
+	if len(variables) > 0 {
+		body["variables"] = variables
+	}
+
+	return body
+}
+
+// graphQLBodyVariables returns the statement body as variables,
+// reporting false when there is a body that is not an object.
+func graphQLBodyVariables(statement domain.Statement) (map[string]interface{}, bool) {
+	if statement.With.Body == nil {
+		return nil, true
+	}
+
+	variables, ok := parseBodyValue(plainValue(statement.With.Body)).(map[string]interface{})
+	return variables, ok
+}
+
 func makeBody(statement domain.Statement, mapping restql.Mapping) restql.Body {
 	if statement.With.Body != nil {
 		return statement.With.Body
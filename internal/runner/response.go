@@ -15,6 +15,7 @@ import (
 type DoneResourceOptions struct {
 	Debugging    bool
 	IgnoreErrors bool
+	Criticality  restql.Criticality
 	MaxAge       interface{}
 	SMaxAge      interface{}
 }
@@ -25,6 +26,7 @@ func NewDoneResource(request restql.HTTPRequest, response restql.HTTPResponse, o
 		Status:          response.StatusCode,
 		Success:         response.StatusCode >= 200 && response.StatusCode < 400,
 		IgnoreErrors:    options.IgnoreErrors,
+		Criticality:     options.Criticality,
 		CacheControl:    makeCacheControl(response, options),
 		Method:          request.Method,
 		URL:             response.URL,
@@ -47,6 +49,7 @@ func NewErrorResponse(log restql.Logger, err error, request restql.HTTPRequest,
 		Status:          response.StatusCode,
 		Success:         false,
 		IgnoreErrors:    options.IgnoreErrors,
+		Criticality:     options.Criticality,
 		ResponseBody:    rb,
 		Method:          request.Method,
 		URL:             response.URL,
@@ -58,6 +61,19 @@ func NewErrorResponse(log restql.Logger, err error, request restql.HTTPRequest,
 	}
 }
 
+// NewInvalidGraphQLBodyResponse builds a DoneResource for a statement
+// on a GraphQL resource with a body that cannot be the variables.
+func NewInvalidGraphQLBodyResponse(log restql.Logger, options DoneResourceOptions) restql.DoneResource {
+	rb := restql.NewResponseBodyFromValue(log, "The request was skipped due to a body that is not an object of GraphQL variables")
+	return restql.DoneResource{
+		Status:       400,
+		Success:      false,
+		IgnoreErrors: options.IgnoreErrors,
+		Criticality:  options.Criticality,
+		ResponseBody: rb,
+	}
+}
+
 // NewEmptyChainedResponse builds a DoneResource for a statement
 // with unresolved chain parameters.
 func NewEmptyChainedResponse(log restql.Logger, params []string, options DoneResourceOptions) restql.DoneResource {
@@ -76,6 +92,7 @@ func NewEmptyChainedResponse(log restql.Logger, params []string, options DoneRes
 		Status:       400,
 		Success:      false,
 		IgnoreErrors: options.IgnoreErrors,
+		Criticality:  options.Criticality,
 		ResponseBody: rb,
 	}
 }
@@ -128,6 +145,7 @@ func NewNewDependsOnUnresolvedResponse(log restql.Logger, stmt domain.Statement,
 		Status:       400,
 		Success:      false,
 		IgnoreErrors: options.IgnoreErrors,
+		Criticality:  options.Criticality,
 		ResponseBody: rb,
 	}
 }
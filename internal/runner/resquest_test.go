@@ -128,6 +128,88 @@ func TestMakeRequest(t *testing.T) {
 			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
 			restql.HTTPRequest{Method: http.MethodPost, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{"context": "something"}, Body: map[string]interface{}{"id": 1}, Headers: map[string]string{"Content-Type": "application/json"}},
 		},
+		{
+			"should make graphql request with document and variables",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"query": "query ($id: ID!) { hero(id: $id) { name } }", "id": "123", "name": domain.Variable{Target: "name"}}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "graphql://hero.io/graphql")}, Input: restql.QueryInput{Params: map[string]interface{}{"c_universe": "dc"}}},
+			restql.HTTPRequest{
+				Method:  http.MethodPost,
+				Schema:  "graphql",
+				Host:    "hero.io",
+				Path:    "/graphql",
+				Body:    map[string]interface{}{"query": "query ($id: ID!) { hero(id: $id) { name } }", "variables": map[string]interface{}{"id": "123"}},
+				Headers: map[string]string{"Content-Type": "application/json"},
+			},
+		},
+		{
+			"should make graphql request with operation name and mapping params",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"query": "mutation Save { save { id } }", "operationName": "Save", "version": "v1"}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "graphqls://hero.io/graphql?:version")}},
+			restql.HTTPRequest{
+				Method:  http.MethodPost,
+				Schema:  "graphqls",
+				Host:    "hero.io",
+				Path:    "/graphql",
+				Query:   map[string]interface{}{"version": "v1"},
+				Body:    map[string]interface{}{"query": "mutation Save { save { id } }", "operationName": "Save"},
+				Headers: map[string]string{"Content-Type": "application/json"},
+			},
+		},
+		{
+			"should make graphql request with list and object variables",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{
+				"query":  "query ($ids: [ID!], $filter: Filter) { heroes(ids: $ids, filter: $filter) { name } }",
+				"ids":    []interface{}{"1", "2"},
+				"filter": map[string]interface{}{"universe": "dc"},
+			}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "graphql://hero.io/graphql")}},
+			restql.HTTPRequest{
+				Method: http.MethodPost,
+				Schema: "graphql",
+				Host:   "hero.io",
+				Path:   "/graphql",
+				Body: map[string]interface{}{
+					"query":     "query ($ids: [ID!], $filter: Filter) { heroes(ids: $ids, filter: $filter) { name } }",
+					"variables": map[string]interface{}{"ids": []interface{}{"1", "2"}, "filter": map[string]interface{}{"universe": "dc"}},
+				},
+				Headers: map[string]string{"Content-Type": "application/json"},
+			},
+		},
+		{
+			"should make graphql request with variables wrapped in functions",
+			domain.Statement{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{
+				"query": "query ($ids: [ID!]) { heroes(ids: $ids) { name } }",
+				"ids":   domain.NoMultiplex{Value: []interface{}{"1", "2"}},
+			}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "graphql://hero.io/graphql")}},
+			restql.HTTPRequest{
+				Method:  http.MethodPost,
+				Schema:  "graphql",
+				Host:    "hero.io",
+				Path:    "/graphql",
+				Body:    map[string]interface{}{"query": "query ($ids: [ID!]) { heroes(ids: $ids) { name } }", "variables": map[string]interface{}{"ids": []interface{}{"1", "2"}}},
+				Headers: map[string]string{"Content-Type": "application/json"},
+			},
+		},
+		{
+			"should make graphql request with body variables",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{
+				Body:   map[string]interface{}{"input": map[string]interface{}{"name": "Batman"}, "id": "1"},
+				Values: map[string]interface{}{"query": "mutation ($input: HeroInput!) { save(input: $input) { id } }", "id": "2"},
+			}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "graphql://hero.io/graphql")}},
+			restql.HTTPRequest{
+				Method: http.MethodPost,
+				Schema: "graphql",
+				Host:   "hero.io",
+				Path:   "/graphql",
+				Body: map[string]interface{}{
+					"query":     "mutation ($input: HeroInput!) { save(input: $input) { id } }",
+					"variables": map[string]interface{}{"input": map[string]interface{}{"name": "Batman"}, "id": "2"},
+				},
+				Headers: map[string]string{"Content-Type": "application/json"},
+			},
+		},
 	}
 
 	forwardPrefix := "c_"
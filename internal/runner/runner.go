@@ -3,6 +3,7 @@ package runner
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
@@ -41,21 +42,51 @@ type Runner struct {
 	queryLimiter     *limiter
 	goroutineLimiter *limiter
 	options          Options
+	globalTimeout    *atomic.Int64
 }
 
 // NewRunner returns a Runner instance.
 func NewRunner(log restql.Logger, executor Executor, options Options) Runner {
-	return Runner{
+	r := Runner{
 		log:              log,
 		executor:         executor,
 		queryLimiter:     newLimiter(int32(options.MaxConcurrentQueries)),
 		goroutineLimiter: newLimiter(int32(options.MaxConcurrentGoroutines)),
 		options:          options,
+		globalTimeout:    &atomic.Int64{},
+	}
+	r.SetTimeouts(options.GlobalQueryTimeout, 0)
+
+	return r
+}
+
+// SetTimeouts changes the default timeout of the queries and,
+// when resourceTimeout is positive, of the upstream requests.
+// The queries already running keep their timeout.
+func (r Runner) SetTimeouts(globalQueryTimeout, resourceTimeout time.Duration) {
+	r.globalTimeout.Store(int64(globalQueryTimeout))
+	if resourceTimeout > 0 {
+		r.executor.SetResourceTimeout(resourceTimeout)
 	}
 }
 
 // ExecuteQuery process a query into a Resource collection.
 func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx restql.QueryContext) (domain.Resources, error) {
+	return r.execute(ctx, query, queryCtx, nil)
+}
+
+// StreamQuery process a query into a Resource collection,
+// calling the emit function with the statement results as
+// soon as they and the statements aggregated into them are done.
+//
+// The emit function is called in order from a separate goroutine,
+// hence a slow consumer does not delay the query execution, and
+// every call has returned when StreamQuery returns.
+func (r Runner) StreamQuery(ctx context.Context, query domain.Query, queryCtx restql.QueryContext, emit EmitFn) (domain.Resources, error) {
+	return r.execute(ctx, query, queryCtx, emit)
+}
+
+func (r Runner) execute(ctx context.Context, query domain.Query, queryCtx restql.QueryContext, emit EmitFn) (domain.Resources, error) {
 	log := restql.GetLogger(ctx)
 
 	success := r.queryLimiter.Acquire()
@@ -64,6 +95,9 @@ func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx r
 	}
 	defer r.queryLimiter.Release()
 
+	streamer := newStreamer(query, emit)
+	defer streamer.Close()
+
 	var cancel context.CancelFunc
 	queryTimeout, ok := r.parseQueryTimeout(query)
 	if ok {
@@ -92,6 +126,7 @@ func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx r
 		outputCh:         outputCh,
 		errorCh:          errorCh,
 		state:            state,
+		streamer:         streamer,
 		ctx:              ctx,
 		goroutineLimiter: r.goroutineLimiter,
 	}
@@ -122,14 +157,16 @@ func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx r
 }
 
 func (r Runner) parseQueryTimeout(query domain.Query) (time.Duration, bool) {
+	globalTimeout := time.Duration(r.globalTimeout.Load())
+
 	timeout, found := query.Use["timeout"]
 	if !found {
-		return r.options.GlobalQueryTimeout, false
+		return globalTimeout, false
 	}
 
 	duration, ok := timeout.(int)
 	if !ok {
-		return r.options.GlobalQueryTimeout, false
+		return globalTimeout, false
 	}
 
 	return time.Millisecond * time.Duration(duration), true
@@ -158,6 +195,7 @@ func (r Runner) initializeResources(query domain.Query) (domain.Resources, error
 type request struct {
 	ResourceIdentifier domain.ResourceID
 	Statement          interface{}
+	ChainedParams      []string
 }
 
 type result struct {
@@ -172,6 +210,7 @@ type stateWorker struct {
 	outputCh         chan domain.Resources
 	errorCh          chan error
 	state            *State
+	streamer         *streamer
 	ctx              context.Context
 	goroutineLimiter *limiter
 }
@@ -183,6 +222,8 @@ func (sw *stateWorker) Run() {
 			sw.state.SetAsRequest(resourceID)
 		}
 
+		chainedParams := GetChainedParams(availableResources)
+
 		availableResources = ResolveChainedValues(availableResources, sw.state.Done())
 		availableResources = ResolveDependsOn(availableResources, sw.state.Done())
 		availableResources = ApplyEncoders(availableResources, sw.log)
@@ -203,7 +244,7 @@ func (sw *stateWorker) Run() {
 
 			go func() {
 				select {
-				case sw.requestCh <- request{ResourceIdentifier: resourceID, Statement: stmt}:
+				case sw.requestCh <- request{ResourceIdentifier: resourceID, Statement: stmt, ChainedParams: chainedParams[resourceID]}:
 				case <-sw.ctx.Done():
 				}
 
@@ -214,6 +255,7 @@ func (sw *stateWorker) Run() {
 		select {
 		case result := <-sw.resultCh:
 			sw.state.UpdateDone(result.ResourceIdentifier, result.Response)
+			sw.streamer.EmitReady(sw.state.Done())
 		case <-sw.ctx.Done():
 			return
 		}
@@ -252,16 +294,18 @@ func (rw *requestWorker) Run() {
 				return
 			}
 
+			info := StatementInfo{ChainedParams: req.ChainedParams}
+
 			switch statement := statement.(type) {
 			case domain.Statement:
 				go func() {
-					response := rw.executor.DoStatement(rw.ctx, statement, rw.queryCtx)
+					response := rw.executor.DoStatement(rw.ctx, statement, info, rw.queryCtx)
 					writeResult(rw.ctx, rw.resultCh, result{ResourceIdentifier: resourceID, Response: response})
 					rw.goroutineLimiter.Release()
 				}()
 			case []interface{}:
 				go func() {
-					result := rw.runMultiplexedStatement(statement, resourceID)
+					result := rw.runMultiplexedStatement(statement, resourceID, info)
 					writeResult(rw.ctx, rw.resultCh, result)
 					rw.goroutineLimiter.Release()
 				}()
@@ -275,7 +319,7 @@ func (rw *requestWorker) Run() {
 	}
 }
 
-func (rw *requestWorker) runMultiplexedStatement(statements []interface{}, resourceID domain.ResourceID) result {
+func (rw *requestWorker) runMultiplexedStatement(statements []interface{}, resourceID domain.ResourceID, info StatementInfo) result {
 	responseChans := make([]chan interface{}, len(statements))
 	for i := range responseChans {
 		responseChans[i] = make(chan interface{}, 1)
@@ -293,6 +337,10 @@ func (rw *requestWorker) runMultiplexedStatement(statements []interface{}, resou
 
 		i, stmt := i, stmt
 		ch := responseChans[i]
+		stmtInfo := StatementInfo{
+			MultiplexIndex: append(append([]int{}, info.MultiplexIndex...), i),
+			ChainedParams:  info.ChainedParams,
+		}
 
 		success := rw.goroutineLimiter.Acquire()
 		if !success {
@@ -307,14 +355,14 @@ func (rw *requestWorker) runMultiplexedStatement(statements []interface{}, resou
 		switch stmt := stmt.(type) {
 		case domain.Statement:
 			go func() {
-				response := rw.executor.DoStatement(rw.ctx, stmt, rw.queryCtx)
+				response := rw.executor.DoStatement(rw.ctx, stmt, stmtInfo, rw.queryCtx)
 				ch <- response
 				wg.Done()
 				rw.goroutineLimiter.Release()
 			}()
 		case []interface{}:
 			go func() {
-				subResult := rw.runMultiplexedStatement(stmt, resourceID)
+				subResult := rw.runMultiplexedStatement(stmt, resourceID, stmtInfo)
 				ch <- subResult.Response
 				wg.Done()
 				rw.goroutineLimiter.Release()
@@ -0,0 +1,191 @@
+package runner
+
+import (
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// EmitFn receives a partial collection of Resources
+// as soon as they are resolved during query execution.
+type EmitFn func(resources domain.Resources)
+
+// streamer tracks which statements results are ready to be
+// emitted before the query execution finishes.
+//
+// Statements related by the `in` keyword are grouped together,
+// as the origin result is merged into the target result, hence
+// a group is only emitted when all of its members are done.
+//
+// Ready groups are delivered to the emit function by a separate
+// goroutine, so that a slow consumer does not hold the query
+// execution. The queue has room for every group, which are
+// emitted once, hence enqueueing never blocks.
+type streamer struct {
+	emit    EmitFn
+	groups  [][]domain.ResourceID
+	emitted []bool
+
+	mu        sync.Mutex
+	closed    bool
+	ready     chan domain.Resources
+	delivered chan struct{}
+}
+
+func newStreamer(query domain.Query, emit EmitFn) *streamer {
+	if emit == nil {
+		return nil
+	}
+
+	groups := groupByAggregation(query.Statements)
+	s := &streamer{
+		emit:      emit,
+		groups:    groups,
+		emitted:   make([]bool, len(groups)),
+		ready:     make(chan domain.Resources, len(groups)),
+		delivered: make(chan struct{}),
+	}
+
+	go s.deliver()
+
+	return s
+}
+
+// EmitReady enqueues copies of the results of every group
+// that have all of its statements done.
+// Copies are used because the done results are still used to
+// resolve chained values and to build the final query result.
+func (s *streamer) EmitReady(done domain.Resources) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	for i, group := range s.groups {
+		if s.emitted[i] || !isGroupDone(group, done) {
+			continue
+		}
+
+		ready := make(domain.Resources, len(group))
+		for _, resourceID := range group {
+			ready[resourceID] = cloneResult(done[resourceID])
+		}
+
+		s.emitted[i] = true
+		s.ready <- ready
+	}
+}
+
+// Close stops accepting results and waits for the
+// enqueued ones to be delivered to the emit function.
+func (s *streamer) Close() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.ready)
+	}
+	s.mu.Unlock()
+
+	<-s.delivered
+}
+
+func (s *streamer) deliver() {
+	defer close(s.delivered)
+
+	for resources := range s.ready {
+		s.emit(resources)
+	}
+}
+
+func isGroupDone(group []domain.ResourceID, done domain.Resources) bool {
+	for _, resourceID := range group {
+		if _, found := done[resourceID]; !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func groupByAggregation(statements []domain.Statement) [][]domain.ResourceID {
+	parent := make(map[domain.ResourceID]domain.ResourceID)
+
+	var find func(id domain.ResourceID) domain.ResourceID
+	find = func(id domain.ResourceID) domain.ResourceID {
+		p, found := parent[id]
+		if !found || p == id {
+			parent[id] = id
+			return id
+		}
+
+		root := find(p)
+		parent[id] = root
+		return root
+	}
+
+	var order []domain.ResourceID
+	for _, stmt := range statements {
+		resourceID := domain.NewResourceID(stmt)
+		order = append(order, resourceID)
+		find(resourceID)
+	}
+
+	for _, stmt := range statements {
+		if len(stmt.In) == 0 {
+			continue
+		}
+
+		targetID := domain.ResourceID(stmt.In[0])
+		if _, found := parent[targetID]; !found {
+			continue
+		}
+
+		origin := find(domain.NewResourceID(stmt))
+		target := find(targetID)
+		parent[origin] = target
+	}
+
+	index := make(map[domain.ResourceID]int)
+	var groups [][]domain.ResourceID
+	for _, resourceID := range order {
+		root := find(resourceID)
+
+		i, found := index[root]
+		if !found {
+			i = len(groups)
+			index[root] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], resourceID)
+	}
+
+	return groups
+}
+
+func cloneResult(r interface{}) interface{} {
+	switch r := r.(type) {
+	case restql.DoneResource:
+		r.ResponseBody = r.ResponseBody.Clone()
+		return r
+	case restql.DoneResources:
+		l := make(restql.DoneResources, len(r))
+		for i, dr := range r {
+			l[i] = cloneResult(dr)
+		}
+		return l
+	default:
+		return r
+	}
+}
@@ -0,0 +1,104 @@
+package runner_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestRunner_StreamQuery(t *testing.T) {
+	client := stubClient{responses: map[string]string{
+		"/hero":     `{"name": "Batman", "sidekickId": 2}`,
+		"/sidekick": `{"name": "Robin"}`,
+		"/villain":  `{"name": "Joker"}`,
+	}}
+	executor := runner.NewExecutor(test.NoOpLogger, client, plugins.NoOpLifecycle, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{GlobalQueryTimeout: time.Second})
+
+	query := domain.Query{Statements: []domain.Statement{
+		{Method: domain.FromMethod, Resource: "hero"},
+		{Method: domain.FromMethod, Resource: "sidekick", With: domain.Params{Values: map[string]interface{}{"id": domain.Chain{"hero", "sidekickId"}}}, In: []string{"hero", "sidekick"}},
+		{Method: domain.FromMethod, Resource: "villain"},
+	}}
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{
+		"hero":     mapping(t, "http://hero.io/hero"),
+		"sidekick": mapping(t, "http://hero.io/sidekick"),
+		"villain":  mapping(t, "http://hero.io/villain"),
+	}}
+
+	var mu sync.Mutex
+	var emitted [][]string
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+	result, err := r.StreamQuery(ctx, query, queryCtx, func(resources domain.Resources) {
+		var ids []string
+		for id, r := range resources {
+			ids = append(ids, string(id))
+
+			dr := r.(restql.DoneResource)
+			dr.ResponseBody.SetValue("changed")
+		}
+		sort.Strings(ids)
+
+		mu.Lock()
+		emitted = append(emitted, ids)
+		mu.Unlock()
+	})
+	test.VerifyError(t, err)
+
+	sort.Slice(emitted, func(i, j int) bool { return emitted[i][0] < emitted[j][0] })
+	test.Equal(t, emitted, [][]string{{"hero", "sidekick"}, {"villain"}})
+
+	hero := result["hero"].(restql.DoneResource)
+	test.Equal(t, hero.ResponseBody.Unmarshal(), test.Unmarshal(`{"name": "Batman", "sidekickId": 2}`))
+}
+
+func TestRunner_StreamQuery_SlowConsumer(t *testing.T) {
+	client := stubClient{responses: map[string]string{
+		"/hero":    `{"name": "Batman"}`,
+		"/villain": `{"name": "Joker"}`,
+	}}
+	executor := runner.NewExecutor(test.NoOpLogger, client, plugins.NoOpLifecycle, time.Second, "")
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{GlobalQueryTimeout: 100 * time.Millisecond})
+
+	query := domain.Query{Statements: []domain.Statement{
+		{Method: domain.FromMethod, Resource: "hero"},
+		{Method: domain.FromMethod, Resource: "villain"},
+	}}
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{
+		"hero":    mapping(t, "http://hero.io/hero"),
+		"villain": mapping(t, "http://hero.io/villain"),
+	}}
+
+	var mu sync.Mutex
+	emitted := 0
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger)
+	_, err := r.StreamQuery(ctx, query, queryCtx, func(resources domain.Resources) {
+		time.Sleep(150 * time.Millisecond)
+
+		mu.Lock()
+		emitted += len(resources)
+		mu.Unlock()
+	})
+	test.VerifyError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	test.Equal(t, emitted, 2)
+}
+
+type stubClient struct {
+	responses map[string]string
+}
+
+func (s stubClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	body := restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(s.responses[request.Path]))
+	return restql.HTTPResponse{StatusCode: 200, Body: body}, nil
+}
@@ -0,0 +1,98 @@
+package restql
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Audit actions recorded for the administrative API mutations.
+const (
+	AuditMappingCreate          = "mapping.create"
+	AuditMappingUpdate          = "mapping.update"
+	AuditMappingDelete          = "mapping.delete"
+	AuditTenantCreate           = "tenant.create"
+	AuditTenantDelete           = "tenant.delete"
+	AuditRevisionCreate         = "revision.create"
+	AuditRevisionArchiving      = "revision.archiving"
+	AuditQueryArchiving         = "query.archiving"
+	AuditQueryMove              = "query.move"
+	AuditNamespaceRename        = "namespace.rename"
+	AuditNamespaceDelete        = "namespace.delete"
+	AuditDefaultFindEventsLimit = 100
+)
+
+// ErrAuditNotEnabled is returned when reading
+// the audit events without an audit store.
+var ErrAuditNotEnabled = errors.New("audit log not enabled")
+
+// AuditEvent is the record of a change made through the
+// administrative API, with the values before and after it.
+//
+// Actor is the name of the admin key used on the request.
+// Tenant or Namespace, with Name, identify the changed entity,
+// like a mapping resource or a query. Before and After are the
+// changed values, like a mapping URL, a query revision text
+// or its archiving state, and are empty when there is no value,
+// like before a creation or after a deletion.
+type AuditEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Actor     string    `json:"actor"`
+	RequestID string    `json:"requestId,omitempty"`
+	Action    string    `json:"action"`
+	Tenant    string    `json:"tenant,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Revision  int       `json:"revision,omitempty"`
+	Before    string    `json:"before,omitempty"`
+	After     string    `json:"after,omitempty"`
+}
+
+// AuditFilter selects the audit events to be returned, where
+// the zero value of each field matches any event. Since is
+// inclusive while Until is exclusive.
+type AuditFilter struct {
+	Actor     string
+	Action    string
+	Tenant    string
+	Namespace string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// Matches tells if the event is selected by the filter,
+// ignoring the Limit.
+func (f AuditFilter) Matches(e AuditEvent) bool {
+	switch {
+	case f.Actor != "" && f.Actor != e.Actor:
+		return false
+	case f.Action != "" && f.Action != e.Action:
+		return false
+	case f.Tenant != "" && f.Tenant != e.Tenant:
+		return false
+	case f.Namespace != "" && f.Namespace != e.Namespace:
+		return false
+	case !f.Since.IsZero() && e.Timestamp.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !e.Timestamp.Before(f.Until):
+		return false
+	default:
+		return true
+	}
+}
+
+// AuditDatabasePlugin is an optional interface implemented by
+// DatabasePlugin that can store the audit events of the
+// administrative API.
+//
+// CreateAuditEvent must only append events, never changing
+// the stored ones. FindAuditEvents returns the events matching
+// the filter ordered from the newest to the oldest, up to the
+// filter Limit or to AuditDefaultFindEventsLimit when it is zero.
+type AuditDatabasePlugin interface {
+	DatabasePlugin
+
+	CreateAuditEvent(ctx context.Context, event AuditEvent) error
+	FindAuditEvents(ctx context.Context, filter AuditFilter) ([]AuditEvent, error)
+}
@@ -0,0 +1,540 @@
+/*
+Package databasetest provides a conformance test suite for
+restql.DatabasePlugin implementations, verifying they follow
+the semantics expected by restQL:
+• Query revisions are numbered sequentially from 1 and
+are always returned ordered by their number.
+• Archiving a query archives all its revisions, while unarchiving
+it keeps the revisions archived.
+• Unarchiving a revision also unarchives its query.
+• Lookups of missing namespaces, queries and mappings fail
+with the restql.Err*NotFound* errors, that are compared by identity.
+• For restql.AdminDatabasePlugin implementations, renaming moves
+the revisions as they were, and deleting or renaming to an existing
+target fails with the same kind of errors. These tests are skipped
+for databases that do not implement it.
+• For restql.AuditDatabasePlugin implementations, audit events are
+returned as they were created, from the newest to the oldest, and
+filtered as restql.AuditFilter.Matches does. These tests are skipped
+for databases that do not implement it.
+
+A plugin runs the suite from its own tests, providing
+a function that returns an empty database:
+
+	func TestDatabase(t *testing.T) {
+		databasetest.Run(t, func(t *testing.T) restql.DatabasePlugin {
+			return newEmptyDatabase(t)
+		})
+	}
+*/
+package databasetest
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/google/go-cmp/cmp"
+)
+
+// NewDatabaseFunc returns an empty database for each test of the suite.
+type NewDatabaseFunc func(t *testing.T) restql.DatabasePlugin
+
+// Run executes the conformance test suite against the databases
+// returned by newDatabase, each one in its own subtest.
+func Run(t *testing.T, newDatabase NewDatabaseFunc) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, db restql.DatabasePlugin)
+	}{
+		{"namespaces", testNamespaces},
+		{"query revisions", testQueryRevisions},
+		{"queries for namespace", testQueriesForNamespace},
+		{"query not found", testQueryNotFound},
+		{"query archiving", testQueryArchiving},
+		{"revision archiving", testRevisionArchiving},
+		{"tenants", testTenants},
+		{"mappings", testMappings},
+		{"mappings not found", testMappingsNotFound},
+		{"tenant creation and deletion", testTenantAdmin},
+		{"mapping deletion", testMappingDeletion},
+		{"namespace renaming", testNamespaceRenaming},
+		{"namespace deletion", testNamespaceDeletion},
+		{"query renaming", testQueryRenaming},
+		{"audit events", testAuditEvents},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newDatabase(t))
+		})
+	}
+}
+
+func testNamespaces(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+
+	createRevisions(t, db, "ns-a", "hero", "from hero")
+	createRevisions(t, db, "ns-a", "sidekick", "from sidekick")
+	createRevisions(t, db, "ns-b", "villain", "from villain")
+
+	namespaces, err := db.FindAllNamespaces(ctx)
+	verifyError(t, err)
+	equal(t, sorted(namespaces), []string{"ns-a", "ns-b"})
+}
+
+func testQueryRevisions(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+
+	createRevisions(t, db, "ns", "hero", "from hero", "from hero with id = 1", "from hero with id = 2")
+
+	revision, err := db.FindQuery(ctx, "ns", "hero", 2)
+	verifyError(t, err)
+	equal(t, revision, restql.SavedQueryRevision{Name: "hero", Text: "from hero with id = 1", Revision: 2})
+
+	query, err := db.FindQueryWithAllRevisions(ctx, "ns", "hero", false)
+	verifyError(t, err)
+	equal(t, query, restql.SavedQuery{
+		Namespace: "ns",
+		Name:      "hero",
+		Revisions: []restql.SavedQueryRevision{
+			{Name: "hero", Text: "from hero", Revision: 1},
+			{Name: "hero", Text: "from hero with id = 1", Revision: 2},
+			{Name: "hero", Text: "from hero with id = 2", Revision: 3},
+		},
+	})
+}
+
+func testQueriesForNamespace(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+
+	createRevisions(t, db, "ns", "hero", "from hero", "from hero with id = 1")
+	createRevisions(t, db, "ns", "sidekick", "from sidekick")
+	createRevisions(t, db, "other", "villain", "from villain")
+
+	queries, err := db.FindQueriesForNamespace(ctx, "ns", false)
+	verifyError(t, err)
+	equal(t, sortedQueries(queries), []restql.SavedQuery{
+		{
+			Namespace: "ns",
+			Name:      "hero",
+			Revisions: []restql.SavedQueryRevision{
+				{Name: "hero", Text: "from hero", Revision: 1},
+				{Name: "hero", Text: "from hero with id = 1", Revision: 2},
+			},
+		},
+		{
+			Namespace: "ns",
+			Name:      "sidekick",
+			Revisions: []restql.SavedQueryRevision{
+				{Name: "sidekick", Text: "from sidekick", Revision: 1},
+			},
+		},
+	})
+
+	archived, err := db.FindQueriesForNamespace(ctx, "ns", true)
+	verifyError(t, err)
+	equal(t, len(archived), 0)
+
+	_, err = db.FindQueriesForNamespace(ctx, "unknown", false)
+	verifySentinel(t, err, restql.ErrNamespaceNotFound)
+}
+
+func testQueryNotFound(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+
+	createRevisions(t, db, "ns", "hero", "from hero")
+
+	_, err := db.FindQuery(ctx, "ns", "hero", 2)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+
+	_, err = db.FindQuery(ctx, "ns", "villain", 1)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+
+	_, err = db.FindQueryWithAllRevisions(ctx, "ns", "villain", false)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+
+	_, err = db.FindQueryWithAllRevisions(ctx, "ns", "hero", true)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+}
+
+func testQueryArchiving(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+
+	createRevisions(t, db, "ns", "hero", "from hero", "from hero with id = 1")
+
+	err := db.UpdateQueryArchiving(ctx, "ns", "hero", true)
+	verifyError(t, err)
+
+	_, err = db.FindQueryWithAllRevisions(ctx, "ns", "hero", false)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+
+	query, err := db.FindQueryWithAllRevisions(ctx, "ns", "hero", true)
+	verifyError(t, err)
+	equal(t, query, restql.SavedQuery{
+		Namespace: "ns",
+		Name:      "hero",
+		Archived:  true,
+		Revisions: []restql.SavedQueryRevision{
+			{Name: "hero", Text: "from hero", Revision: 1, Archived: true},
+			{Name: "hero", Text: "from hero with id = 1", Revision: 2, Archived: true},
+		},
+	})
+
+	revision, err := db.FindQuery(ctx, "ns", "hero", 1)
+	verifyError(t, err)
+	equal(t, revision, restql.SavedQueryRevision{Name: "hero", Text: "from hero", Revision: 1, Archived: true})
+
+	err = db.UpdateQueryArchiving(ctx, "ns", "hero", false)
+	verifyError(t, err)
+
+	query, err = db.FindQueryWithAllRevisions(ctx, "ns", "hero", false)
+	verifyError(t, err)
+	equal(t, query, restql.SavedQuery{
+		Namespace: "ns",
+		Name:      "hero",
+		Revisions: []restql.SavedQueryRevision{
+			{Name: "hero", Text: "from hero", Revision: 1, Archived: true},
+			{Name: "hero", Text: "from hero with id = 1", Revision: 2, Archived: true},
+		},
+	})
+
+	err = db.UpdateQueryArchiving(ctx, "ns", "villain", true)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+}
+
+func testRevisionArchiving(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+
+	createRevisions(t, db, "ns", "hero", "from hero", "from hero with id = 1")
+
+	err := db.UpdateRevisionArchiving(ctx, "ns", "hero", 1, true)
+	verifyError(t, err)
+
+	query, err := db.FindQueryWithAllRevisions(ctx, "ns", "hero", false)
+	verifyError(t, err)
+	equal(t, query.Revisions, []restql.SavedQueryRevision{
+		{Name: "hero", Text: "from hero", Revision: 1, Archived: true},
+		{Name: "hero", Text: "from hero with id = 1", Revision: 2},
+	})
+
+	err = db.UpdateQueryArchiving(ctx, "ns", "hero", true)
+	verifyError(t, err)
+
+	err = db.UpdateRevisionArchiving(ctx, "ns", "hero", 2, false)
+	verifyError(t, err)
+
+	query, err = db.FindQueryWithAllRevisions(ctx, "ns", "hero", false)
+	verifyError(t, err)
+	equal(t, query.Revisions, []restql.SavedQueryRevision{
+		{Name: "hero", Text: "from hero", Revision: 1, Archived: true},
+		{Name: "hero", Text: "from hero with id = 1", Revision: 2},
+	})
+
+	err = db.UpdateRevisionArchiving(ctx, "ns", "hero", 3, true)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+
+	createRevisions(t, db, "ns", "hero", "from hero with id = 2")
+
+	revision, err := db.FindQuery(ctx, "ns", "hero", 3)
+	verifyError(t, err)
+	equal(t, revision, restql.SavedQueryRevision{Name: "hero", Text: "from hero with id = 2", Revision: 3})
+}
+
+func testTenants(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+
+	verifyError(t, db.CreateMapping(ctx, "tenant-a", "hero", "http://hero.api/"))
+	verifyError(t, db.CreateMapping(ctx, "tenant-a", "sidekick", "http://sidekick.api/"))
+	verifyError(t, db.CreateMapping(ctx, "tenant-b", "hero", "http://hero.api/"))
+
+	tenants, err := db.FindAllTenants(ctx)
+	verifyError(t, err)
+	equal(t, sorted(tenants), []string{"tenant-a", "tenant-b"})
+}
+
+func testMappings(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+
+	verifyError(t, db.CreateMapping(ctx, "tenant", "hero", "http://hero.api/"))
+	verifyError(t, db.CreateMapping(ctx, "tenant", "sidekick", "http://sidekick.api/"))
+	verifyError(t, db.CreateMapping(ctx, "other", "villain", "http://villain.api/"))
+
+	err := db.CreateMapping(ctx, "tenant", "hero", "http://another-hero.api/")
+	verifySentinel(t, err, restql.ErrMappingAlreadyExistsInDatabase)
+
+	verifyError(t, db.SetMapping(ctx, "tenant", "sidekick", "http://sidekick.api/:id"))
+	verifyError(t, db.SetMapping(ctx, "tenant", "villain", "http://villain.api/"))
+
+	mappings, err := db.FindMappingsForTenant(ctx, "tenant")
+	verifyError(t, err)
+	equal(t, mappingURLs(mappings), map[string]string{
+		"hero":     "http://hero.api/",
+		"sidekick": "http://sidekick.api/:id",
+		"villain":  "http://villain.api/",
+	})
+}
+
+func testMappingsNotFound(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+
+	verifyError(t, db.CreateMapping(ctx, "tenant", "hero", "http://hero.api/"))
+
+	_, err := db.FindMappingsForTenant(ctx, "unknown")
+	verifySentinel(t, err, restql.ErrMappingsNotFoundInDatabase)
+}
+
+func testTenantAdmin(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+	adb := adminDatabase(t, db)
+
+	verifyError(t, adb.CreateTenant(ctx, "empty"))
+	verifyError(t, adb.CreateMapping(ctx, "tenant", "hero", "http://hero.api/"))
+
+	err := adb.CreateTenant(ctx, "tenant")
+	verifySentinel(t, err, restql.ErrTenantAlreadyExistsInDatabase)
+
+	tenants, err := adb.FindAllTenants(ctx)
+	verifyError(t, err)
+	equal(t, sorted(tenants), []string{"empty", "tenant"})
+
+	verifyError(t, adb.DeleteTenant(ctx, "tenant"))
+	verifyError(t, adb.DeleteTenant(ctx, "empty"))
+
+	err = adb.DeleteTenant(ctx, "tenant")
+	verifySentinel(t, err, restql.ErrTenantNotFoundInDatabase)
+
+	tenants, err = adb.FindAllTenants(ctx)
+	verifyError(t, err)
+	equal(t, len(tenants), 0)
+
+	_, err = adb.FindMappingsForTenant(ctx, "tenant")
+	verifySentinel(t, err, restql.ErrMappingsNotFoundInDatabase)
+}
+
+func testMappingDeletion(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+	adb := adminDatabase(t, db)
+
+	verifyError(t, adb.CreateMapping(ctx, "tenant", "hero", "http://hero.api/"))
+	verifyError(t, adb.CreateMapping(ctx, "tenant", "sidekick", "http://sidekick.api/"))
+
+	verifyError(t, adb.DeleteMapping(ctx, "tenant", "hero"))
+
+	err := adb.DeleteMapping(ctx, "tenant", "hero")
+	verifySentinel(t, err, restql.ErrMappingsNotFoundInDatabase)
+
+	mappings, err := adb.FindMappingsForTenant(ctx, "tenant")
+	verifyError(t, err)
+	equal(t, mappingURLs(mappings), map[string]string{"sidekick": "http://sidekick.api/"})
+}
+
+func testNamespaceRenaming(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+	adb := adminDatabase(t, db)
+
+	createRevisions(t, adb, "old", "hero", "from hero", "from hero with id = 1")
+	createRevisions(t, adb, "taken", "villain", "from villain")
+	verifyError(t, adb.UpdateRevisionArchiving(ctx, "old", "hero", 1, true))
+
+	err := adb.RenameNamespace(ctx, "old", "taken")
+	verifySentinel(t, err, restql.ErrNamespaceAlreadyExistsInDatabase)
+
+	err = adb.RenameNamespace(ctx, "unknown", "new")
+	verifySentinel(t, err, restql.ErrNamespaceNotFound)
+
+	verifyError(t, adb.RenameNamespace(ctx, "old", "new"))
+
+	namespaces, err := adb.FindAllNamespaces(ctx)
+	verifyError(t, err)
+	equal(t, sorted(namespaces), []string{"new", "taken"})
+
+	revision, err := adb.FindQuery(ctx, "new", "hero", 1)
+	verifyError(t, err)
+	equal(t, revision, restql.SavedQueryRevision{Name: "hero", Text: "from hero", Revision: 1, Archived: true})
+
+	_, err = adb.FindQuery(ctx, "old", "hero", 1)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+}
+
+func testNamespaceDeletion(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+	adb := adminDatabase(t, db)
+
+	createRevisions(t, adb, "ns", "hero", "from hero")
+	createRevisions(t, adb, "other", "villain", "from villain")
+
+	verifyError(t, adb.DeleteNamespace(ctx, "ns"))
+
+	err := adb.DeleteNamespace(ctx, "ns")
+	verifySentinel(t, err, restql.ErrNamespaceNotFound)
+
+	namespaces, err := adb.FindAllNamespaces(ctx)
+	verifyError(t, err)
+	equal(t, namespaces, []string{"other"})
+
+	_, err = adb.FindQuery(ctx, "ns", "hero", 1)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+
+	createRevisions(t, adb, "ns", "hero", "from hero with id = 1")
+
+	revision, err := adb.FindQuery(ctx, "ns", "hero", 1)
+	verifyError(t, err)
+	equal(t, revision, restql.SavedQueryRevision{Name: "hero", Text: "from hero with id = 1", Revision: 1})
+}
+
+func testQueryRenaming(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+	adb := adminDatabase(t, db)
+
+	createRevisions(t, adb, "ns", "hero", "from hero", "from hero with id = 1")
+	createRevisions(t, adb, "ns", "villain", "from villain")
+	verifyError(t, adb.UpdateQueryArchiving(ctx, "ns", "hero", true))
+
+	err := adb.RenameQuery(ctx, "ns", "hero", "ns", "villain")
+	verifySentinel(t, err, restql.ErrQueryAlreadyExistsInDatabase)
+
+	err = adb.RenameQuery(ctx, "ns", "unknown", "ns", "new")
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+
+	verifyError(t, adb.RenameQuery(ctx, "ns", "hero", "other", "champion"))
+
+	query, err := adb.FindQueryWithAllRevisions(ctx, "other", "champion", true)
+	verifyError(t, err)
+	equal(t, query, restql.SavedQuery{
+		Namespace: "other",
+		Name:      "champion",
+		Archived:  true,
+		Revisions: []restql.SavedQueryRevision{
+			{Name: "champion", Text: "from hero", Revision: 1, Archived: true},
+			{Name: "champion", Text: "from hero with id = 1", Revision: 2, Archived: true},
+		},
+	})
+
+	_, err = adb.FindQueryWithAllRevisions(ctx, "ns", "hero", true)
+	verifySentinel(t, err, restql.ErrQueryNotFoundInDatabase)
+}
+
+func testAuditEvents(t *testing.T, db restql.DatabasePlugin) {
+	ctx := context.Background()
+	adb := auditDatabase(t, db)
+
+	start := time.Date(2021, time.March, 10, 12, 0, 0, 0, time.UTC)
+	events := []restql.AuditEvent{
+		{Timestamp: start, Actor: "ops", RequestID: "1", Action: restql.AuditMappingCreate, Tenant: "dc", Name: "hero", After: "http://hero.api"},
+		{Timestamp: start.Add(time.Minute), Actor: "ops", Action: restql.AuditMappingUpdate, Tenant: "dc", Name: "hero", Before: "http://hero.api", After: "http://heroes.api"},
+		{Timestamp: start.Add(2 * time.Minute), Actor: "checkout", Action: restql.AuditRevisionCreate, Namespace: "checkout", Name: "cart", Revision: 1, After: "from cart"},
+		{Timestamp: start.Add(3 * time.Minute), Actor: "checkout", Action: restql.AuditQueryArchiving, Namespace: "checkout", Name: "cart", Before: "false", After: "true"},
+	}
+	for _, e := range events {
+		verifyError(t, adb.CreateAuditEvent(ctx, e))
+	}
+
+	tests := []struct {
+		name     string
+		filter   restql.AuditFilter
+		expected []restql.AuditEvent
+	}{
+		{"all", restql.AuditFilter{}, []restql.AuditEvent{events[3], events[2], events[1], events[0]}},
+		{"actor", restql.AuditFilter{Actor: "ops"}, []restql.AuditEvent{events[1], events[0]}},
+		{"action", restql.AuditFilter{Action: restql.AuditRevisionCreate}, []restql.AuditEvent{events[2]}},
+		{"tenant", restql.AuditFilter{Tenant: "dc", Action: restql.AuditMappingCreate}, []restql.AuditEvent{events[0]}},
+		{"namespace", restql.AuditFilter{Namespace: "checkout"}, []restql.AuditEvent{events[3], events[2]}},
+		{"period", restql.AuditFilter{Since: start.Add(time.Minute), Until: start.Add(3 * time.Minute)}, []restql.AuditEvent{events[2], events[1]}},
+		{"limit", restql.AuditFilter{Limit: 1}, []restql.AuditEvent{events[3]}},
+		{"none", restql.AuditFilter{Actor: "unknown"}, []restql.AuditEvent{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := adb.FindAuditEvents(ctx, tt.filter)
+			verifyError(t, err)
+			if len(got) == 0 && len(tt.expected) == 0 {
+				return
+			}
+
+			equal(t, got, tt.expected)
+		})
+	}
+}
+
+func adminDatabase(t *testing.T, db restql.DatabasePlugin) restql.AdminDatabasePlugin {
+	t.Helper()
+
+	adb, ok := db.(restql.AdminDatabasePlugin)
+	if !ok {
+		t.Skipf("database %s does not implement restql.AdminDatabasePlugin", db.Name())
+	}
+
+	return adb
+}
+
+func auditDatabase(t *testing.T, db restql.DatabasePlugin) restql.AuditDatabasePlugin {
+	t.Helper()
+
+	adb, ok := db.(restql.AuditDatabasePlugin)
+	if !ok {
+		t.Skipf("database %s does not implement restql.AuditDatabasePlugin", db.Name())
+	}
+
+	return adb
+}
+
+func createRevisions(t *testing.T, db restql.DatabasePlugin, namespace string, name string, texts ...string) {
+	t.Helper()
+
+	for _, text := range texts {
+		err := db.CreateQueryRevision(context.Background(), namespace, name, text)
+		if err != nil {
+			t.Fatalf("failed to create revision of %s/%s: %s", namespace, name, err)
+		}
+	}
+}
+
+func sorted(values []string) []string {
+	result := append([]string{}, values...)
+	sort.Strings(result)
+	return result
+}
+
+func sortedQueries(queries []restql.SavedQuery) []restql.SavedQuery {
+	result := append([]restql.SavedQuery{}, queries...)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func mappingURLs(mappings []restql.Mapping) map[string]string {
+	result := make(map[string]string, len(mappings))
+	for _, m := range mappings {
+		result[m.ResourceName()] = m.URL()
+	}
+	return result
+}
+
+func equal(t *testing.T, got, expected interface{}) {
+	t.Helper()
+
+	if !cmp.Equal(got, expected) {
+		t.Errorf("got = %+#v, want = %+#v\nMismatch (-want +got):\n%s", got, expected, cmp.Diff(expected, got))
+	}
+}
+
+func verifyError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Errorf("unexpected error returned: %s", err)
+	}
+}
+
+func verifySentinel(t *testing.T, err error, target error) {
+	t.Helper()
+
+	if err != target {
+		t.Errorf("got error = %v, want = %v", err, target)
+	}
+}
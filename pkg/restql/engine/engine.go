@@ -0,0 +1,343 @@
+/*
+Package engine provides the restQL interpreter to be embedded in Go services,
+running queries in-process instead of calling a restQL server over HTTP.
+
+An Engine is built from Options, where every dependency is optional:
+• Logger: defaults to a no operation logger.
+• HTTPClient: defaults to the built-in client, with support to HTTP and GraphQL resources.
+• Mappings and Queries: in-memory mappings and saved queries, which can be replaced
+by custom MappingsReader and QueryReader.
+• LifecyclePlugins: hooks executed around the queries and, for the built-in client, the upstream calls.
+• Functions: custom functions available through the apply operator (->).
+*/
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/cache"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/decoder"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/graphqlclient"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/httpclient"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// Errors returned by the Engine, which can
+// be checked with errors.Is.
+var (
+	// ErrValidation is returned when the query options
+	// or input have invalid information, like an empty tenant.
+	ErrValidation = eval.ErrValidation
+	// ErrParser is returned when the query has invalid syntax.
+	ErrParser = eval.ErrParser
+	// ErrInvalidQuery is returned by Validate when the query has invalid syntax.
+	ErrInvalidQuery = parser.ErrInvalidQuery
+	// ErrTimeout is returned when the query execution
+	// exceeds the GlobalQueryTimeout.
+	ErrTimeout = eval.ErrTimeout
+	// ErrMapping is returned when the query
+	// references a resource without mapping.
+	ErrMapping = eval.ErrMapping
+	// ErrMaxQueryDenied is returned when the
+	// MaxConcurrentQueries limit is reached.
+	ErrMaxQueryDenied = runner.ErrMaxQueryDenied
+	// ErrMaxGoroutineDenied is returned when the
+	// MaxConcurrentGoroutines limit is reached.
+	ErrMaxGoroutineDenied = runner.ErrMaxGoroutineDenied
+)
+
+// Default values used for the zero value Options.
+const (
+	DefaultResourceTimeout    = 5 * time.Second
+	DefaultGlobalQueryTimeout = 30 * time.Second
+)
+
+// MappingsReader is an interface implemented by types that
+// can fetch a collection of mapping for the given tenant.
+type MappingsReader interface {
+	FromTenant(ctx context.Context, tenant string) (map[string]restql.Mapping, error)
+}
+
+// QueryReader is an interface implemented by types that
+// can fetch a query for the given identification (namespace, id, revision).
+type QueryReader interface {
+	Get(ctx context.Context, namespace, id string, revision int) (restql.SavedQueryRevision, error)
+}
+
+// Options define the dependencies and limits of an Engine.
+type Options struct {
+	Logger     restql.Logger
+	HTTPClient restql.HTTPClient
+
+	// Tenant is used when the query Input has no tenant.
+	Tenant string
+	// Mappings are indexed by tenant and then by resource name,
+	// for example {"default": {"hero": "http://hero.api/heroes"}}.
+	// They are ignored if a MappingsReader is provided.
+	Mappings       map[string]map[string]string
+	MappingsReader MappingsReader
+	// Queries are indexed by namespace and then by query name,
+	// with a list of revisions, the first one being revision 1.
+	// They are ignored if a QueryReader is provided.
+	Queries     map[string]map[string][]string
+	QueryReader QueryReader
+
+	LifecyclePlugins []restql.LifecyclePlugin
+	// Functions are available through the apply operator (->)
+	// besides the built-in ones, like `base64` and `matches`.
+	Functions []restql.Function
+
+	ResourceTimeout         time.Duration
+	GlobalQueryTimeout      time.Duration
+	ForwardPrefix           string
+	MaxConcurrentQueries    int
+	MaxConcurrentGoroutines int
+	// ParserCacheSize is the number of parsed queries kept
+	// in memory, the parsing is not cached when it is zero.
+	ParserCacheSize int
+}
+
+// Input is the client information used by the query.
+type Input struct {
+	Tenant  string
+	Params  map[string]interface{}
+	Headers map[string]string
+	Body    interface{}
+}
+
+func (i Input) queryInput() restql.QueryInput {
+	qi := restql.QueryInput{Params: i.Params, Headers: i.Headers, Body: i.Body}
+	if qi.Params == nil {
+		qi.Params = map[string]interface{}{}
+	}
+	if qi.Headers == nil {
+		qi.Headers = map[string]string{}
+	}
+
+	return qi
+}
+
+// Engine executes restQL queries in-process.
+// It is safe for concurrent use.
+type Engine struct {
+	log       restql.Logger
+	tenant    string
+	client    restql.HTTPClient
+	parser    parser.Parser
+	runner    runner.Runner
+	evaluator eval.Evaluator
+}
+
+// New constructs an Engine from the options.
+func New(options Options) (*Engine, error) {
+	log := options.Logger
+	if log == nil {
+		log = restql.GetLogger(context.Background())
+	}
+
+	defaultParser, err := parser.New(options.Functions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var p parser.Parser = defaultParser
+	if options.ParserCacheSize > 0 {
+		parserCacheLoader := cache.New(log, options.ParserCacheSize, cache.ParserCacheLoader(defaultParser))
+		p = cache.NewParserCache(log, parserCacheLoader)
+	}
+
+	lifecycle := plugins.NewLifecycle(log, options.LifecyclePlugins)
+
+	client := options.HTTPClient
+	if client == nil {
+		client = newDefaultClient(log, lifecycle)
+	}
+
+	mr, qr, err := newReaders(log, options)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTimeout, globalQueryTimeout := timeoutsOrDefault(options.ResourceTimeout, options.GlobalQueryTimeout)
+
+	executor := runner.NewExecutor(log, client, lifecycle, resourceTimeout, options.ForwardPrefix)
+	r := runner.NewRunner(log, executor, runner.Options{
+		GlobalQueryTimeout:      globalQueryTimeout,
+		MaxConcurrentQueries:    options.MaxConcurrentQueries,
+		MaxConcurrentGoroutines: options.MaxConcurrentGoroutines,
+	})
+
+	return &Engine{
+		log:       log,
+		tenant:    options.Tenant,
+		client:    client,
+		parser:    defaultParser,
+		runner:    r,
+		evaluator: eval.NewEvaluator(log, mr, qr, r, p, lifecycle),
+	}, nil
+}
+
+func timeoutsOrDefault(resourceTimeout, globalQueryTimeout time.Duration) (time.Duration, time.Duration) {
+	if resourceTimeout <= 0 {
+		resourceTimeout = DefaultResourceTimeout
+	}
+
+	if globalQueryTimeout <= 0 {
+		globalQueryTimeout = DefaultGlobalQueryTimeout
+	}
+
+	return resourceTimeout, globalQueryTimeout
+}
+
+func newDefaultClient(log restql.Logger, lifecycle plugins.Lifecycle) restql.HTTPClient {
+	decoders := decoder.New(log, plugins.NewResponseDecoders(log))
+	client := httpclient.New(log, lifecycle, decoders, conf.Defaults())
+
+	graphQLClient := graphqlclient.New(log, client)
+	return httpclient.NewRouter(client, map[string]restql.HTTPClient{
+		"graphql":  graphQLClient,
+		"graphqls": graphQLClient,
+	})
+}
+
+func newReaders(log restql.Logger, options Options) (MappingsReader, QueryReader, error) {
+	mr := options.MappingsReader
+	qr := options.QueryReader
+	if mr != nil && qr != nil {
+		return mr, qr, nil
+	}
+
+	db, err := persistence.NewDatabase(log, persistence.DatabaseOptions{Disabled: true})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if mr == nil {
+		mr = persistence.NewMappingReader(log, noEnv{}, options.Mappings, db)
+	}
+
+	if qr == nil {
+		qr = persistence.NewQueryReader(log, options.Queries, db)
+	}
+
+	return mr, qr, nil
+}
+
+// noEnv disables the mappings defined by environment
+// variables, which are only used by the restQL server.
+type noEnv struct{}
+
+func (noEnv) GetString(key string) string { return "" }
+func (noEnv) GetAll() map[string]string   { return nil }
+
+// Validate checks if the query has a valid syntax,
+// returning an ErrInvalidQuery otherwise.
+func (e *Engine) Validate(queryText string) error {
+	_, err := e.parser.Parse(queryText)
+	if err != nil {
+		return parser.WrapSyntaxError(fmt.Errorf("%w: %s", ErrInvalidQuery, err), err)
+	}
+
+	return nil
+}
+
+// SetTimeouts changes the ResourceTimeout and GlobalQueryTimeout
+// of the following queries, using the defaults for zero values.
+func (e *Engine) SetTimeouts(resourceTimeout, globalQueryTimeout time.Duration) {
+	resourceTimeout, globalQueryTimeout = timeoutsOrDefault(resourceTimeout, globalQueryTimeout)
+	e.runner.SetTimeouts(globalQueryTimeout, resourceTimeout)
+}
+
+// Close releases the resources held by the upstream
+// client, when it supports being closed.
+func (e *Engine) Close() error {
+	if closer, ok := e.client.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// Run executes the query text with the client input.
+func (e *Engine) Run(ctx context.Context, queryText string, input Input) (Result, error) {
+	result, err := e.evaluator.AdHocQuery(e.context(ctx), queryText, e.options(input), input.queryInput())
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(result), nil
+}
+
+// RunSaved executes the saved query identified by
+// namespace, id and revision with the client input.
+func (e *Engine) RunSaved(ctx context.Context, namespace, id string, revision int, input Input) (Result, error) {
+	options := e.options(input)
+	options.Namespace = namespace
+	options.Id = id
+	options.Revision = revision
+
+	result, err := e.evaluator.SavedQuery(e.context(ctx), options, input.queryInput())
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(result), nil
+}
+
+// EmitFunc receives the statements results that are done
+// during a streamed query execution, with `only`, `in`
+// and `hidden` clauses already applied.
+type EmitFunc func(statements []StatementResult)
+
+// Stream executes the query text like Run, calling the emit
+// function with the statements results as soon as they are done.
+func (e *Engine) Stream(ctx context.Context, queryText string, input Input, emit EmitFunc) (Result, error) {
+	result, err := e.evaluator.StreamAdHocQuery(e.context(ctx), queryText, e.options(input), input.queryInput(), emitResources(emit))
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(result), nil
+}
+
+// StreamSaved executes the saved query like RunSaved, calling the emit
+// function with the statements results as soon as they are done.
+func (e *Engine) StreamSaved(ctx context.Context, namespace, id string, revision int, input Input, emit EmitFunc) (Result, error) {
+	options := e.options(input)
+	options.Namespace = namespace
+	options.Id = id
+	options.Revision = revision
+
+	result, err := e.evaluator.StreamSavedQuery(e.context(ctx), options, input.queryInput(), emitResources(emit))
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(result), nil
+}
+
+func (e *Engine) context(ctx context.Context) context.Context {
+	if restql.HasLogger(ctx) {
+		return ctx
+	}
+
+	return restql.WithLogger(ctx, e.log)
+}
+
+func (e *Engine) options(input Input) restql.QueryOptions {
+	tenant := input.Tenant
+	if tenant == "" {
+		tenant = e.tenant
+	}
+
+	return restql.QueryOptions{Tenant: tenant}
+}
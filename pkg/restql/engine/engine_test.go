@@ -0,0 +1,231 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql/engine"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+// fakeClient responds with the body registered for the request
+// host, like hero.api, and records the requests made.
+type fakeClient struct {
+	bodies map[string]string
+
+	mu       sync.Mutex
+	requests []restql.HTTPRequest
+}
+
+func (fc *fakeClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	fc.mu.Lock()
+	fc.requests = append(fc.requests, request)
+	fc.mu.Unlock()
+
+	body, found := fc.bodies[request.Host]
+	if !found {
+		return restql.HTTPResponse{StatusCode: 404, Body: restql.NewResponseBodyFromValue(test.NoOpLogger, nil)}, nil
+	}
+
+	return restql.HTTPResponse{
+		URL:        request.Schema + "://" + request.Host + request.Path,
+		StatusCode: 200,
+		Body:       restql.NewResponseBodyFromBytes(test.NoOpLogger, []byte(body)),
+	}, nil
+}
+
+func newEngine(t *testing.T, client restql.HTTPClient) *engine.Engine {
+	e, err := engine.New(engine.Options{
+		Logger:     test.NoOpLogger,
+		HTTPClient: client,
+		Tenant:     "default",
+		Mappings: map[string]map[string]string{
+			"default": {
+				"hero":     "http://hero.api/heroes",
+				"sidekick": "http://sidekick.api/sidekicks",
+			},
+		},
+		Queries: map[string]map[string][]string{
+			"dc": {"heroes": {"from hero", "from hero\nfrom sidekick hidden"}},
+		},
+	})
+	test.VerifyError(t, err)
+
+	return e
+}
+
+func TestEngine_Run(t *testing.T) {
+	client := &fakeClient{bodies: map[string]string{
+		"hero.api":     `{"name": "Batman"}`,
+		"sidekick.api": `{"name": "Robin"}`,
+	}}
+	e := newEngine(t, client)
+
+	result, err := e.Run(context.Background(), "from sidekick\nfrom hero with name = $name", engine.Input{
+		Params: map[string]interface{}{"name": "batman"},
+	})
+	test.VerifyError(t, err)
+
+	test.Equal(t, len(result.Statements), 2)
+	test.Equal(t, result.Statements[0].Name, "sidekick")
+	test.Equal(t, result.Statements[1].Name, "hero")
+
+	hero, found := result.Statement("hero")
+	test.Equal(t, found, true)
+	test.Equal(t, hero.IsMultiplexed(), false)
+	test.Equal(t, hero.Done.Status, 200)
+	test.Equal(t, hero.Done.ResponseBody.Unmarshal(), test.Unmarshal(`{"name": "Batman"}`))
+}
+
+func TestEngine_RunMultiplexed(t *testing.T) {
+	client := &fakeClient{bodies: map[string]string{"hero.api": `{"name": "Batman"}`}}
+	e := newEngine(t, client)
+
+	result, err := e.Run(context.Background(), `from hero with name = ["batman", "robin"]`, engine.Input{})
+	test.VerifyError(t, err)
+
+	hero, _ := result.Statement("hero")
+	test.Equal(t, hero.IsMultiplexed(), true)
+	test.Equal(t, len(hero.Multiplexed), 2)
+	test.Equal(t, len(client.requests), 2)
+}
+
+func TestEngine_SetTimeouts(t *testing.T) {
+	client := &fakeClient{bodies: map[string]string{"hero.api": `{"name": "Batman"}`}}
+	e := newEngine(t, client)
+
+	_, err := e.Run(context.Background(), "from hero", engine.Input{})
+	test.VerifyError(t, err)
+
+	e.SetTimeouts(2*time.Second, time.Minute)
+
+	_, err = e.Run(context.Background(), "from hero", engine.Input{})
+	test.VerifyError(t, err)
+
+	test.Equal(t, client.requests[0].Timeout, engine.DefaultResourceTimeout)
+	test.Equal(t, client.requests[1].Timeout, 2*time.Second)
+}
+
+func TestEngine_RunSaved(t *testing.T) {
+	client := &fakeClient{bodies: map[string]string{
+		"hero.api":     `{"name": "Batman"}`,
+		"sidekick.api": `{"name": "Robin"}`,
+	}}
+	e := newEngine(t, client)
+
+	result, err := e.RunSaved(context.Background(), "dc", "heroes", 2, engine.Input{})
+	test.VerifyError(t, err)
+
+	test.Equal(t, len(result.Statements), 1)
+	test.Equal(t, result.Statements[0].Name, "hero")
+	test.Equal(t, len(client.requests), 2)
+}
+
+func TestEngine_Stream(t *testing.T) {
+	client := &fakeClient{bodies: map[string]string{"hero.api": `{"name": "Batman"}`}}
+	e := newEngine(t, client)
+
+	var emitted []string
+	_, err := e.Stream(context.Background(), "from hero", engine.Input{}, func(statements []engine.StatementResult) {
+		for _, s := range statements {
+			emitted = append(emitted, s.Name)
+		}
+	})
+	test.VerifyError(t, err)
+
+	test.Equal(t, emitted, []string{"hero"})
+}
+
+func TestEngine_RunWithFunctions(t *testing.T) {
+	upper := restql.Function{
+		Name: "upper",
+		Apply: func(value interface{}, args []interface{}) (interface{}, error) {
+			s, ok := value.(string)
+			if !ok {
+				return nil, errors.New("value is not a string")
+			}
+			return strings.ToUpper(s), nil
+		},
+	}
+	pad := restql.Function{
+		Name:   "pad",
+		Params: []restql.FunctionParam{{Name: "size", Type: restql.IntArg}},
+		Apply: func(value interface{}, args []interface{}) (interface{}, error) {
+			return fmt.Sprintf("%0*v", args[0].(int), value), nil
+		},
+	}
+
+	client := &fakeClient{bodies: map[string]string{"hero.api": `{"name": "Batman", "city": "Gotham"}`}}
+	e, err := engine.New(engine.Options{
+		Logger:     test.NoOpLogger,
+		HTTPClient: client,
+		Tenant:     "default",
+		Mappings:   map[string]map[string]string{"default": {"hero": "http://hero.api/heroes"}},
+		Functions:  []restql.Function{upper, pad},
+	})
+	test.VerifyError(t, err)
+
+	result, err := e.Run(context.Background(), "from hero with id = $id -> pad($size), name = $name -> upper\nonly name -> upper, city", engine.Input{
+		Params: map[string]interface{}{"id": 42, "size": "5", "name": 1},
+	})
+	test.VerifyError(t, err)
+
+	hero, _ := result.Statement("hero")
+	test.Equal(t, hero.Done.ResponseBody.Unmarshal(), test.Unmarshal(`{"name": "BATMAN", "city": "Gotham"}`))
+	test.Equal(t, len(client.requests), 1)
+	test.Equal(t, client.requests[0].Query, map[string]interface{}{"id": "00042", "name": 1})
+
+	err = e.Validate("from hero with id = $id -> unknown")
+	test.Equal(t, errors.Is(err, engine.ErrInvalidQuery), true)
+}
+
+func TestEngine_Errors(t *testing.T) {
+	e := newEngine(t, &fakeClient{})
+
+	tests := []struct {
+		name     string
+		run      func() error
+		expected error
+	}{
+		{
+			"should return parser error for invalid ad-hoc query",
+			func() error {
+				_, err := e.Run(context.Background(), "from", engine.Input{})
+				return err
+			},
+			engine.ErrParser,
+		},
+		{
+			"should return mapping error for unknown resource",
+			func() error {
+				_, err := e.Run(context.Background(), "from villain", engine.Input{})
+				return err
+			},
+			engine.ErrMapping,
+		},
+		{
+			"should return invalid query on validation",
+			func() error {
+				return e.Validate("from")
+			},
+			engine.ErrInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+
+	test.VerifyError(t, e.Validate("from hero"))
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// StatementResult is the outcome of a query statement, identified
+// by its alias or, in its absence, by the resource name.
+//
+// Statements with multiplexed calls, like the ones using a
+// list as parameter, have their outcome in Multiplexed,
+// which can be nested for multiple list parameters.
+type StatementResult struct {
+	Name        string
+	Done        restql.DoneResource
+	Multiplexed restql.DoneResources
+}
+
+// IsMultiplexed returns true if the statement
+// was executed as multiple upstream calls.
+func (sr StatementResult) IsMultiplexed() bool {
+	return sr.Multiplexed != nil
+}
+
+// Value returns the statement outcome as a
+// restql.DoneResource or restql.DoneResources.
+func (sr StatementResult) Value() interface{} {
+	if sr.IsMultiplexed() {
+		return sr.Multiplexed
+	}
+
+	return sr.Done
+}
+
+// Result is the outcome of a query execution.
+type Result struct {
+	// Statements are in the same order as declared in the query,
+	// with the hidden statements omitted.
+	Statements []StatementResult
+	// Modifiers are the query `use` clauses.
+	Modifiers map[string]interface{}
+}
+
+// Statement returns the result of the statement with the given name.
+func (r Result) Statement(name string) (StatementResult, bool) {
+	for _, s := range r.Statements {
+		if s.Name == name {
+			return s, true
+		}
+	}
+
+	return StatementResult{}, false
+}
+
+// Resources returns the statements results indexed by name,
+// with each value being a restql.DoneResource or restql.DoneResources.
+func (r Result) Resources() map[string]interface{} {
+	resources := make(map[string]interface{}, len(r.Statements))
+	for _, s := range r.Statements {
+		resources[s.Name] = s.Value()
+	}
+
+	return resources
+}
+
+func newResult(result eval.Result) Result {
+	statements := make([]StatementResult, 0, len(result.Statements))
+	for _, id := range result.Statements {
+		resource, found := result.Resources[id]
+		if !found {
+			continue
+		}
+
+		statements = append(statements, newStatementResult(id, resource))
+	}
+
+	return Result{Statements: statements, Modifiers: result.Modifiers}
+}
+
+func newStatementResult(id domain.ResourceID, resource interface{}) StatementResult {
+	sr := StatementResult{Name: string(id)}
+
+	switch r := resource.(type) {
+	case restql.DoneResource:
+		sr.Done = r
+	case restql.DoneResources:
+		sr.Multiplexed = r
+	}
+
+	return sr
+}
+
+func emitResources(emit EmitFunc) func(resources domain.Resources) {
+	return func(resources domain.Resources) {
+		statements := make([]StatementResult, 0, len(resources))
+		for id, resource := range resources {
+			statements = append(statements, newStatementResult(id, resource))
+		}
+
+		sort.Slice(statements, func(i, j int) bool {
+			return statements[i].Name < statements[j].Name
+		})
+
+		emit(statements)
+	}
+}
@@ -0,0 +1,139 @@
+package restql
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ErrInvalidFunctionArgument is the error returned when a
+// function is called with a wrong number of arguments or
+// with a value that cannot be converted to the parameter type.
+var ErrInvalidFunctionArgument = errors.New("invalid function argument")
+
+// Function parameter types
+const (
+	AnyArg ArgType = iota
+	StringArg
+	IntArg
+	FloatArg
+	BoolArg
+)
+
+// ArgType is an enum of the types accepted by
+// Function parameters, currently supports
+// AnyArg, StringArg, IntArg, FloatArg and BoolArg.
+type ArgType int
+
+func (at ArgType) String() string {
+	switch at {
+	case AnyArg:
+		return "any"
+	case StringArg:
+		return "string"
+	case IntArg:
+		return "int"
+	case FloatArg:
+		return "float"
+	case BoolArg:
+		return "bool"
+	default:
+		return "unknown"
+	}
+}
+
+// Cast converts the value to the Go type associated with the
+// ArgType: string, int, float64 or bool. Since query parameters
+// are received as strings, they are parsed when possible,
+// like "10" for IntArg.
+func (at ArgType) Cast(value interface{}) (interface{}, error) {
+	switch at {
+	case AnyArg:
+		return value, nil
+	case StringArg:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case int, float64, bool:
+			return fmt.Sprintf("%v", v), nil
+		}
+	case IntArg:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case float64:
+			if v == math.Trunc(v) {
+				return int(v), nil
+			}
+		case string:
+			if i, err := strconv.Atoi(v); err == nil {
+				return i, nil
+			}
+		}
+	case FloatArg:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, nil
+			}
+		}
+	case BoolArg:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: expected %s but got %T with value %v", ErrInvalidFunctionArgument, at, value, value)
+}
+
+// FunctionParam describes an argument accepted by a Function.
+type FunctionParam struct {
+	Name string
+	Type ArgType
+}
+
+// Function is an operation provided by a FunctionPlugin that
+// can be used through the apply operator (->), transforming
+// `with` parameters before the upstream call, like
+// `with id = $id -> pad(10, "0")`, and fields selected in
+// the `only` clause, like `only name -> upper`.
+//
+// Apply receives the value and the arguments already
+// converted to the types defined by Params.
+type Function struct {
+	Name   string
+	Params []FunctionParam
+	Apply  func(value interface{}, args []interface{}) (interface{}, error)
+}
+
+// Call applies the function on the value after checking
+// the number of arguments and converting them to the
+// parameters types, returning an ErrInvalidFunctionArgument
+// if the arguments are not compatible with Params.
+func (f Function) Call(value interface{}, args ...interface{}) (interface{}, error) {
+	if len(args) != len(f.Params) {
+		return nil, fmt.Errorf("%w: %s expects %d arguments but got %d", ErrInvalidFunctionArgument, f.Name, len(f.Params), len(args))
+	}
+
+	castArgs := make([]interface{}, len(args))
+	for i, param := range f.Params {
+		arg, err := param.Type.Cast(args[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s argument %s : %w", f.Name, param.Name, err)
+		}
+
+		castArgs[i] = arg
+	}
+
+	return f.Apply(value, castArgs)
+}
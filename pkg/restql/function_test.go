@@ -0,0 +1,87 @@
+package restql_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestArgTypeCast(t *testing.T) {
+	tests := []struct {
+		name     string
+		argType  restql.ArgType
+		value    interface{}
+		expected interface{}
+	}{
+		{"any should keep value", restql.AnyArg, []interface{}{1}, []interface{}{1}},
+		{"string should keep string", restql.StringArg, "batman", "batman"},
+		{"string should format number", restql.StringArg, 10, "10"},
+		{"int should keep int", restql.IntArg, 10, 10},
+		{"int should parse string", restql.IntArg, "10", 10},
+		{"int should convert integral float", restql.IntArg, 10.0, 10},
+		{"float should convert int", restql.FloatArg, 10, 10.0},
+		{"float should parse string", restql.FloatArg, "10.5", 10.5},
+		{"bool should parse string", restql.BoolArg, "true", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.argType.Cast(tt.value)
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestArgTypeCastError(t *testing.T) {
+	tests := []struct {
+		name    string
+		argType restql.ArgType
+		value   interface{}
+	}{
+		{"string should not accept list", restql.StringArg, []interface{}{"batman"}},
+		{"int should not accept fractional float", restql.IntArg, 10.5},
+		{"int should not accept invalid string", restql.IntArg, "ten"},
+		{"float should not accept bool", restql.FloatArg, true},
+		{"bool should not accept number", restql.BoolArg, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.argType.Cast(tt.value)
+			if !errors.Is(err, restql.ErrInvalidFunctionArgument) {
+				t.Errorf("Cast() error = %v, want %v", err, restql.ErrInvalidFunctionArgument)
+			}
+		})
+	}
+}
+
+func TestFunctionCall(t *testing.T) {
+	repeat := restql.Function{
+		Name:   "repeat",
+		Params: []restql.FunctionParam{{Name: "times", Type: restql.IntArg}},
+		Apply: func(value interface{}, args []interface{}) (interface{}, error) {
+			result := make([]interface{}, args[0].(int))
+			for i := range result {
+				result[i] = value
+			}
+			return result, nil
+		},
+	}
+
+	got, err := repeat.Call("batman", "2")
+	test.VerifyError(t, err)
+	test.Equal(t, got, []interface{}{"batman", "batman"})
+
+	_, err = repeat.Call("batman")
+	if !errors.Is(err, restql.ErrInvalidFunctionArgument) {
+		t.Errorf("Call() error = %v, want %v", err, restql.ErrInvalidFunctionArgument)
+	}
+
+	_, err = repeat.Call("batman", "twice")
+	if !errors.Is(err, restql.ErrInvalidFunctionArgument) {
+		t.Errorf("Call() error = %v, want %v", err, restql.ErrInvalidFunctionArgument)
+	}
+}
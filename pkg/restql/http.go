@@ -1,6 +1,8 @@
 package restql
 
 import (
+	"context"
+	"errors"
 	"time"
 )
 
@@ -33,3 +35,15 @@ type HTTPResponse struct {
 	Duration   time.Duration
 }
 
+// ErrRequestTimeout is the error returned by a HTTPClient
+// when a HTTP call fails due to the request exceeding
+// the timeout defined in HTTPRequest.
+var ErrRequestTimeout = errors.New("request timed out")
+
+// HTTPClient is the interface that wrap the method Do
+//
+// Do takes an HTTPRequest and execute it respecting
+// the cancellation signal from the given Context.
+type HTTPClient interface {
+	Do(ctx context.Context, request HTTPRequest) (HTTPResponse, error)
+}
@@ -30,6 +30,13 @@ func WithLogger(ctx context.Context, l Logger) context.Context {
 	return context.WithValue(ctx, loggerCtxKey{}, l)
 }
 
+// HasLogger returns true if a logger instance
+// is present in the given context.Context.
+func HasLogger(ctx context.Context) bool {
+	_, ok := ctx.Value(loggerCtxKey{}).(Logger)
+	return ok
+}
+
 // GetLogger extracts a logger instance from the given
 // context.Context. If none is present, then a no operation
 // logger is returned.
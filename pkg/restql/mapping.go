@@ -9,7 +9,7 @@ import (
 )
 
 var pathParamRegex = regexp.MustCompile(":([^/]+)/?")
-var urlRegex = regexp.MustCompile(`(https?)://([^/]+)([^?]*)\??(.*)`)
+var urlRegex = regexp.MustCompile(`(https?|grpcs?|graphqls?)://([^/]+)([^?]*)\??(.*)`)
 
 // Mapping represents the association of a name to a REST resource url.
 // It support special syntax in the URL to provide dynamic value substitution, like:
@@ -19,6 +19,12 @@ var urlRegex = regexp.MustCompile(`(https?)://([^/]+)([^?]*)\??(.*)`)
 //• QueryRevisions parameters: can be defined by placing a colon (:) before an identifier in the URL query,
 // for example "http://some.api?:page", will replace ":page" by the value of the "page" parameter
 // in the query definition creating the URL "http://some.api?page=<value>".
+//
+// Besides HTTP resources, it also supports gRPC unary methods by using the "grpc"
+// or "grpcs" (for TLS connections) schemas with the method full name as the path,
+// for example "grpc://some.api:9090/package.Service/Method", and GraphQL endpoints
+// by using the "graphql" or "graphqls" (for TLS connections) schemas, for example
+// "graphql://some.api/graphql".
 type Mapping struct {
 	resourceName  string
 	url           string
@@ -113,6 +119,11 @@ func (m Mapping) Schema() string {
 	return m.schema
 }
 
+// IsGraphQL returns true if the resource is a GraphQL endpoint
+func (m Mapping) IsGraphQL() bool {
+	return m.schema == "graphql" || m.schema == "graphqls"
+}
+
 // Host returns the resource URL host
 func (m Mapping) Host() string {
 	return m.host
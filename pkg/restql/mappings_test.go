@@ -94,3 +94,22 @@ func TestMappingsQueryWithParams(t *testing.T) {
 		})
 	}
 }
+
+func TestMappingsGRPC(t *testing.T) {
+	mapping, err := restql.NewMapping("hero", "grpc://hero.api:9090/heroes.v1.HeroService/GetHero")
+	test.VerifyError(t, err)
+
+	test.Equal(t, mapping.Schema(), "grpc")
+	test.Equal(t, mapping.Host(), "hero.api:9090")
+	test.Equal(t, mapping.PathWithParams(map[string]interface{}{"id": "1"}), "/heroes.v1.HeroService/GetHero")
+}
+
+func TestMappingsGraphQL(t *testing.T) {
+	mapping, err := restql.NewMapping("hero", "graphqls://hero.api/graphql")
+	test.VerifyError(t, err)
+
+	test.Equal(t, mapping.IsGraphQL(), true)
+	test.Equal(t, mapping.Schema(), "graphqls")
+	test.Equal(t, mapping.Host(), "hero.api")
+	test.Equal(t, mapping.PathWithParams(nil), "/graphql")
+}
@@ -3,6 +3,7 @@ package restql
 import (
 	"context"
 	"errors"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
@@ -23,16 +24,24 @@ var (
 type pluginIndex struct {
 	lifecycle []PluginInfo
 	dbPlugin  *PluginInfo
+	decoders  []PluginInfo
+	encoders  []PluginInfo
+	functions []PluginInfo
 }
 
 // Plugin types
 const (
 	LifecyclePluginType PluginType = iota
 	DatabasePluginType
+	ResponseDecoderPluginType
+	ResponseEncoderPluginType
+	FunctionPluginType
 )
 
 // PluginType is an enum of possible plugin types supported by restQL,
-// currently supports LifecyclePluginType and DatabasePluginType.
+// currently supports LifecyclePluginType, DatabasePluginType,
+// ResponseDecoderPluginType, ResponseEncoderPluginType
+// and FunctionPluginType.
 type PluginType int
 
 func (pt PluginType) String() string {
@@ -41,6 +50,12 @@ func (pt PluginType) String() string {
 		return "Lifecycle"
 	case DatabasePluginType:
 		return "Database"
+	case ResponseDecoderPluginType:
+		return "ResponseDecoder"
+	case ResponseEncoderPluginType:
+		return "ResponseEncoder"
+	case FunctionPluginType:
+		return "Function"
 	default:
 		return "Unknown"
 	}
@@ -56,8 +71,8 @@ type PluginInfo struct {
 
 // RegisterPlugin indexes the provided plugin information
 // for latter usage by restQL in runtime.
-// It supports registration of multiple Lifecycle plugins
-// but only one Database plugin.
+// It supports registration of multiple Lifecycle, ResponseDecoder,
+// ResponseEncoder and Function plugins but only one Database plugin.
 // In case of failure to register the plugin a warn
 // message will be printed to the os.Stdout.
 func RegisterPlugin(pluginInfo PluginInfo) {
@@ -74,11 +89,19 @@ func RegisterPlugin(pluginInfo PluginInfo) {
 		}
 
 		plugins.dbPlugin = &pluginInfo
+	case ResponseDecoderPluginType:
+		plugins.decoders = append(plugins.decoders, pluginInfo)
+	case ResponseEncoderPluginType:
+		plugins.encoders = append(plugins.encoders, pluginInfo)
+	case FunctionPluginType:
+		plugins.functions = append(plugins.functions, pluginInfo)
 	default:
 		log.Printf("[WARN] unknown plugin type: %s", pluginInfo.Type)
 	}
 }
 
+// GetLifecyclePlugins returns all registered
+// Lifecycle plugins, in registration order.
 func GetLifecyclePlugins() []PluginInfo {
 	pluginsMu.RLock()
 	defer pluginsMu.RUnlock()
@@ -88,6 +111,41 @@ func GetLifecyclePlugins() []PluginInfo {
 	return lp
 }
 
+// GetResponseDecoderPlugins returns all registered
+// ResponseDecoder plugins, in registration order.
+func GetResponseDecoderPlugins() []PluginInfo {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	dp := plugins.decoders
+
+	return dp
+}
+
+// GetResponseEncoderPlugins returns all registered
+// ResponseEncoder plugins, in registration order.
+func GetResponseEncoderPlugins() []PluginInfo {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	ep := plugins.encoders
+
+	return ep
+}
+
+// GetFunctionPlugins returns all registered
+// Function plugins, in registration order.
+func GetFunctionPlugins() []PluginInfo {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	fp := plugins.functions
+
+	return fp
+}
+
+// GetDatabasePlugin returns the registered Database
+// plugin, if there is one.
 func GetDatabasePlugin() (PluginInfo, bool) {
 	pluginsMu.RLock()
 	defer pluginsMu.RUnlock()
@@ -112,6 +170,50 @@ type LifecyclePlugin interface {
 	AfterRequest(ctx context.Context, request HTTPRequest, response HTTPResponse, err error) context.Context
 }
 
+// MutatingLifecyclePlugin is an optional interface implemented by
+// LifecyclePlugin that need to modify the data flowing through
+// the query execution, instead of only observing it.
+//
+// MutateQueryInput is called before BeforeQuery and returns the
+// client input used to resolve the query variables and headers.
+// MutateRequest is called before BeforeRequest and returns the
+// request dispatched to the upstream, like one with a signed header
+// or a rewritten host. MutateResponse is called before AfterRequest
+// and returns the response used by the query, allowing to normalize
+// its status, headers or body.
+//
+// The mutations are applied in the order the plugins were registered,
+// each plugin receiving the value returned by the previous one. Then,
+// the LifecyclePlugin hooks of all plugins are called with the final
+// value. If a plugin panics, the value it received is kept.
+type MutatingLifecyclePlugin interface {
+	LifecyclePlugin
+	MutateQueryInput(ctx context.Context, query string, queryCtx QueryContext) (context.Context, QueryInput)
+	MutateRequest(ctx context.Context, request HTTPRequest) (context.Context, HTTPRequest)
+	MutateResponse(ctx context.Context, request HTTPRequest, response HTTPResponse, err error) (context.Context, HTTPResponse)
+}
+
+// StatementLifecyclePlugin is an optional interface implemented by
+// LifecyclePlugin that need to observe the execution of each statement.
+//
+// BeforeStatement is called before the statement upstream request,
+// and its returned context is given to the request hooks.
+// AfterStatement is called with the statement result, including
+// failures from the upstream. OnStatementSkipped is called instead
+// of both when the statement is not executed, like when its
+// `depends-on` target failed. For multiplexed statements, these
+// hooks are called once for each statement created.
+//
+// OnQueryError is called when the query execution fails,
+// like due to an invalid syntax or a timeout.
+type StatementLifecyclePlugin interface {
+	LifecyclePlugin
+	BeforeStatement(ctx context.Context, statement Statement) context.Context
+	AfterStatement(ctx context.Context, statement Statement, result DoneResource) context.Context
+	OnStatementSkipped(ctx context.Context, statement Statement, reason SkipReason) context.Context
+	OnQueryError(ctx context.Context, query string, err error) context.Context
+}
+
 // TransactionRequest represents a query execution
 // transaction received through the /run-query/* endpoints.
 type TransactionRequest struct {
@@ -147,12 +249,95 @@ type DatabasePlugin interface {
 	SetMapping(ctx context.Context, tenantID string, mappingsName string, url string) error
 }
 
+// AdminDatabasePlugin is an optional interface implemented by
+// DatabasePlugin that support removing and reorganizing the stored
+// mappings and queries. When the database does not implement it,
+// these administrative operations fail with ErrDatabaseOperationNotSupported.
+//
+// CreateTenant stores a tenant without mappings, which is listed by
+// FindAllTenants, failing with ErrTenantAlreadyExistsInDatabase when
+// the tenant is already stored. DeleteTenant removes the tenant with
+// all its mappings and DeleteMapping removes a single mapping, failing
+// with ErrTenantNotFoundInDatabase and ErrMappingsNotFoundInDatabase
+// respectively when there is nothing to remove.
+//
+// RenameNamespace moves all queries of the namespace to a new one,
+// failing with ErrNamespaceAlreadyExistsInDatabase when the new
+// namespace has queries. DeleteNamespace removes all queries of the
+// namespace with their revisions. Both fail with ErrNamespaceNotFound
+// when the namespace has no queries.
+//
+// RenameQuery moves the query with all its revisions to another
+// name, namespace or both, keeping the revision numbers and archiving
+// state. It fails with ErrQueryNotFoundInDatabase when the query does
+// not exist and with ErrQueryAlreadyExistsInDatabase when the target does.
+type AdminDatabasePlugin interface {
+	DatabasePlugin
+
+	CreateTenant(ctx context.Context, tenantID string) error
+	DeleteTenant(ctx context.Context, tenantID string) error
+	DeleteMapping(ctx context.Context, tenantID string, mappingsName string) error
+
+	RenameNamespace(ctx context.Context, namespace string, newNamespace string) error
+	DeleteNamespace(ctx context.Context, namespace string) error
+	RenameQuery(ctx context.Context, namespace string, queryName string, newNamespace string, newQueryName string) error
+}
+
+// ResponseDecoderPlugin is the interface that defines
+// a decoder for upstream response bodies which are not JSON.
+//
+// MediaTypes returns the content types handled by the decoder,
+// like "application/xml". The decoder is selected by matching
+// the upstream response Content-Type against these values.
+type ResponseDecoderPlugin interface {
+	Plugin
+	ResponseDecoder
+	MediaTypes() []string
+}
+
+// ResponseEncoderPlugin is the interface that defines
+// an encoder for restQL API responses in formats other than JSON.
+//
+// MediaTypes returns the content types produced by the encoder,
+// like "application/msgpack". The encoder is selected by matching
+// the client Accept header against these values.
+type ResponseEncoderPlugin interface {
+	Plugin
+	ResponseEncoder
+	MediaTypes() []string
+}
+
+// FunctionPlugin is the interface that defines a provider
+// of functions used through the apply operator (->).
+//
+// Functions returns the available functions, which are
+// referenced in the queries by their names. Built-in functions,
+// like `base64` or `matches`, take precedence over the plugins ones.
+type FunctionPlugin interface {
+	Plugin
+	Functions() []Function
+}
+
+// ResponseEncoder is the interface that wraps the Encode method.
+//
+// Encode writes the given value, composed of maps, slices
+// and primitives, in the encoder format.
+type ResponseEncoder interface {
+	Encode(w io.Writer, v interface{}) error
+}
+
 // Errors returned by Database plugin
 var (
 	ErrMappingsNotFoundInDatabase     = errors.New("mappings not found in database")
 	ErrQueryNotFoundInDatabase        = errors.New("query not found in database")
 	ErrDatabaseCommunicationFailed    = errors.New("failed to communicate with the database")
 	ErrMappingAlreadyExistsInDatabase = errors.New("mapping already exist in database, create operation not allowed")
+
+	ErrTenantNotFoundInDatabase         = errors.New("tenant not found in database")
+	ErrTenantAlreadyExistsInDatabase    = errors.New("tenant already exist in database, create operation not allowed")
+	ErrNamespaceAlreadyExistsInDatabase = errors.New("namespace already exist in database, rename operation not allowed")
+	ErrQueryAlreadyExistsInDatabase     = errors.New("query already exist in database, rename operation not allowed")
+	ErrDatabaseOperationNotSupported    = errors.New("operation not supported by the database")
 )
 
 // ErrMappingsNotFound is the error returned when
@@ -6,6 +6,7 @@ var (
 	DatabaseSource   Source = "database"
 	ConfigFileSource Source = "config"
 	EnvSource        Source = "env"
+	FileSystemSource Source = "filesystem"
 )
 
 // SavedQuery represents a query stored in database.
@@ -50,3 +51,49 @@ type QueryInput struct {
 	Body    interface{}
 	Headers map[string]string
 }
+
+// Statement is a read-only description of a query
+// statement provided to the StatementLifecyclePlugin hooks.
+// Its values are copies, hence changing them does not
+// affect the query execution.
+//
+// Name is the statement alias or, if it has none, its resource.
+// MultiplexIndex is the position of the statement among the ones
+// created by multiplexing, with one index for each nesting level,
+// being empty when the statement is not multiplexed.
+// ChainedParams are the names of the parameters whose values
+// were taken from other statements results.
+type Statement struct {
+	Name           string
+	Method         string
+	Resource       string
+	Alias          string
+	DependsOn      string
+	MultiplexIndex []int
+	Params         map[string]interface{}
+	ChainedParams  []string
+	IgnoreErrors   bool
+	Criticality    Criticality
+}
+
+// IsMultiplexed returns true if the statement was
+// created by multiplexing a list parameter.
+func (s Statement) IsMultiplexed() bool {
+	return len(s.MultiplexIndex) > 0
+}
+
+// Reasons for a statement execution to be skipped.
+const (
+	// DependsOnUnresolved is used when the statement
+	// `depends-on` target failed or was skipped.
+	DependsOnUnresolved SkipReason = "depends-on-unresolved"
+	// EmptyChainedParams is used when a chained parameter
+	// value could not be resolved from its target result.
+	EmptyChainedParams SkipReason = "empty-chained-params"
+	// InvalidGraphQLBody is used when the body of a statement
+	// on a GraphQL resource is not an object of variables.
+	InvalidGraphQLBody SkipReason = "invalid-graphql-body"
+)
+
+// SkipReason describes why a statement was not executed.
+type SkipReason string
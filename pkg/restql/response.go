@@ -17,10 +17,32 @@ import (
 // If the byte slice is unmarshalled or a new value is set on the response body
 // with SetValue method, then the Marshal and Unmarshal function will operate
 // using this value rather then the byte slice.
+//
+// When the upstream response is not JSON, a ResponseDecoder can be attached
+// to the ResponseBody. It will be lazily used to transform the byte slice
+// in a generic value, the first time restQL needs to access its content.
 type ResponseBody struct {
-	log Logger
+	log       Logger
+	decoder   ResponseDecoder
 	jsonBytes []byte
 	jsonValue interface{}
+
+	// decoded is set once the decoder has run, as it
+	// may legitimately return a nil value.
+	decoded bool
+
+	// undecodable is set when the decoder failed, in which
+	// case jsonValue holds the byte slice as a string.
+	undecodable bool
+}
+
+// ResponseDecoder is the interface that wraps the Decode method.
+//
+// Decode takes the raw HTTP body of an upstream response and
+// transforms it into a generic value, composed of maps, slices
+// and primitives, that can be manipulated as a JSON value.
+type ResponseDecoder interface {
+	Decode(body []byte) (interface{}, error)
 }
 
 // NewResponseBodyFromBytes creates a ResponseBody wrapper from
@@ -31,6 +53,14 @@ func NewResponseBodyFromBytes(log Logger, b []byte) *ResponseBody {
 	return r
 }
 
+// NewDecodableResponseBody creates a ResponseBody wrapper from
+// an HTTP response data which will be decoded by the given
+// ResponseDecoder instead of a JSON parser.
+func NewDecodableResponseBody(log Logger, b []byte, decoder ResponseDecoder) *ResponseBody {
+	r := &ResponseBody{log: log, jsonBytes: b, decoder: decoder}
+	return r
+}
+
 // NewResponseBodyFromValue creates a ResponseBody wrapper from
 // a generic value, usually from an error.
 func NewResponseBodyFromValue(log Logger, v interface{}) *ResponseBody {
@@ -54,20 +84,31 @@ func (r *ResponseBody) Value() interface{} {
 // the byte slice data.
 func (r *ResponseBody) SetValue(v interface{}) {
 	r.jsonValue = v
+	r.undecodable = false
 }
 
 // Marshal returns the content of ResponseBody ready to
 // be sent to downstream.
 //
-// This method can process the content in 4 ways:
+// This method can process the content in 5 ways:
 // - If there is a generic data, marshal it using a JSON parser
 //   and return the result as a json.RawMessage.
 // - Else, if the byte slice is empty, return nil.
+// - Else, if there is a decoder, decode the byte slice and
+//   marshal the result using a JSON parser.
 // - Else, if the byte slice is not an valid JSON, stringify it.
 // - Finally, if the byte slice is not empty and is a valid json,
 //   return it as a json.RawMessage.
 func (r *ResponseBody) Marshal() (interface{}, error) {
-	if r.jsonValue != nil {
+	if r.needsDecoding() {
+		r.decode()
+	}
+
+	if r.undecodable {
+		return string(r.jsonBytes), nil
+	}
+
+	if r.jsonValue != nil || r.decoded {
 		b, err := json.Marshal(r.jsonValue)
 		if err != nil {
 			return nil, err
@@ -90,14 +131,20 @@ func (r *ResponseBody) Marshal() (interface{}, error) {
 // Unmarshal returns the content of ResponseBody ready to
 // be manipulated internally by restQL.
 //
-// This method can process the content in 4 ways:
+// This method can process the content in 5 ways:
 // - If there is a generic data, return it.
+// - Else, if there is a decoder and the byte slice is not empty,
+//   decode it and return.
 // - Else, if the byte slice is empty or is not a valid json,
 //   return it as a string.
 // - Finally, if it is valid to be manipulated, then unmarshal it
 //   and return.
 func (r *ResponseBody) Unmarshal() interface{} {
-	if r.jsonValue != nil {
+	if r.needsDecoding() {
+		return r.decode()
+	}
+
+	if r.jsonValue != nil || r.decoded {
 		return r.jsonValue
 	}
 
@@ -119,20 +166,83 @@ func (r *ResponseBody) Unmarshal() interface{} {
 	return responseBody
 }
 
+func (r *ResponseBody) needsDecoding() bool {
+	return !r.decoded && r.jsonValue == nil && r.decoder != nil && len(r.jsonBytes) > 0
+}
+
+func (r *ResponseBody) decode() interface{} {
+	r.decoded = true
+
+	value, err := r.decoder.Decode(r.jsonBytes)
+	if err != nil {
+		body := string(r.jsonBytes)
+		r.log.Error("failed to decode response body", err, "body", body)
+
+		r.jsonValue = body
+		r.undecodable = true
+		return body
+	}
+
+	r.jsonValue = value
+	return value
+}
+
 // Valid return true if the ResponseBody content
 // can be manipulated by restQL.
 func (r *ResponseBody) Valid() bool {
-	if r.jsonValue != nil {
-		return true
+	if r.needsDecoding() {
+		r.decode()
+	}
+
+	if r.jsonValue != nil || r.decoded {
+		return !r.undecodable
 	}
 
 	return len(r.jsonBytes) > 0 && json.Valid(r.jsonBytes)
 }
 
+// Clone returns a copy of the ResponseBody that can be
+// manipulated without affecting the original one.
+func (r *ResponseBody) Clone() *ResponseBody {
+	if r == nil {
+		return nil
+	}
+
+	return &ResponseBody{
+		log:         r.log,
+		decoder:     r.decoder,
+		jsonBytes:   r.jsonBytes,
+		jsonValue:   deepCopy(r.jsonValue),
+		decoded:     r.decoded,
+		undecodable: r.undecodable,
+	}
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = deepCopy(value)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = deepCopy(value)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
 // Clear removes all internal content.
 func (r *ResponseBody) Clear() {
 	r.jsonBytes = nil
 	r.jsonValue = nil
+	r.decoded = false
+	r.undecodable = false
 }
 
 // ResourceCacheControlValue represents the values a cache control
@@ -152,11 +262,30 @@ type ResourceCacheControl struct {
 	SMaxAge ResourceCacheControlValue
 }
 
+// Criticality defines whether a statement failure
+// influences the query response status code.
+type Criticality string
+
+// Statement criticality values, defined by the
+// `critical` and `optional` statement flags.
+const (
+	// DefaultCriticality leaves to the query status
+	// policy the decision of considering the statement.
+	DefaultCriticality Criticality = ""
+	// Critical statements always have their failures
+	// considered in the query status code.
+	Critical Criticality = "critical"
+	// Optional statements never have their failures
+	// considered in the query status code.
+	Optional Criticality = "optional"
+)
+
 // DoneResource represents a statement result.
 type DoneResource struct {
 	Status          int
 	Success         bool
 	IgnoreErrors    bool
+	Criticality     Criticality
 	CacheControl    ResourceCacheControl
 	Method          string
 	URL             string
@@ -0,0 +1,33 @@
+package restql_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+type countingDecoder struct {
+	calls int
+}
+
+func (c *countingDecoder) Decode(body []byte) (interface{}, error) {
+	c.calls++
+	return nil, nil
+}
+
+func TestResponseBody_DecodesOnce(t *testing.T) {
+	decoder := &countingDecoder{}
+	body := restql.NewDecodableResponseBody(test.NoOpLogger, []byte("<empty/>"), decoder)
+
+	test.Equal(t, body.Valid(), true)
+	test.Equal(t, body.Unmarshal(), nil)
+
+	marshaled, err := body.Marshal()
+	test.VerifyError(t, err)
+	test.Equal(t, marshaled, json.RawMessage("null"))
+
+	test.Equal(t, body.Clone().Unmarshal(), nil)
+	test.Equal(t, decoder.calls, 1)
+}